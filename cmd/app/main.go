@@ -5,15 +5,25 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/stwrtrio/movie-festival/config"
 	"github.com/stwrtrio/movie-festival/internal/controllers"
+	"github.com/stwrtrio/movie-festival/internal/helpers"
 	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/notifiers"
+	"github.com/stwrtrio/movie-festival/internal/oidc"
 	"github.com/stwrtrio/movie-festival/internal/repositories"
 	"github.com/stwrtrio/movie-festival/internal/routes"
 	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/workers"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
@@ -29,6 +39,17 @@ func main() {
 		log.Fatal("Error loading .env file")
 	}
 
+	// Load the JWT signing and verification keys
+	if err := helpers.InitJWTKeys(); err != nil {
+		log.Fatalf("Error loading JWT keys: %v", err)
+	}
+
+	// Load the OpenID Connect providers users can log in with
+	oidcProviders, err := oidc.NewProvidersFromEnv()
+	if err != nil {
+		log.Fatalf("Error loading OIDC providers: %v", err)
+	}
+
 	// Connect to database
 	config.InitDB()
 	defer config.DB.Close()
@@ -41,24 +62,86 @@ func main() {
 	e := echo.New()
 	e.Validator = &middlewares.CustomValidator{Validator: validator.New()}
 
+	// Only trust X-Forwarded-For when running behind a reverse proxy, clients could spoof it otherwise
+	e.IPExtractor = echo.ExtractIPDirect()
+	if os.Getenv("BEHIND_PROXY") == "true" {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	}
+
 	// Dependency Injection
 	// Repository
 	movieRepo := repositories.NewMovieRepository(config.DB)
 	userRepo := repositories.NewUserRepository(config.DB)
+	sessionRepo := repositories.NewSessionRepository(config.DB)
+	artistRepo := repositories.NewArtistRepository(config.DB)
+	genreRepo := repositories.NewGenreRepository(config.DB)
+	reviewRepo := repositories.NewReviewRepository(config.DB)
+	movieListRepo := repositories.NewMovieListRepository(config.DB)
+	watchProgressRepo := repositories.NewWatchProgressRepository(config.DB)
+	lockoutRepo := repositories.NewLockoutRepository(config.DB)
+	roleRepo := repositories.NewRoleRepository(config.DB)
+	identityRepo := repositories.NewIdentityRepository(config.DB)
+	editionRepo := repositories.NewEditionRepository(config.DB)
+	competitionRepo := repositories.NewCompetitionRepository(config.DB)
+	juryRepo := repositories.NewJuryRepository(config.DB)
+	votingRepo := repositories.NewVotingRepository(config.DB)
+
+	// Notifier
+	notifier, err := notifiers.NewNotifier()
+	if err != nil {
+		log.Fatalf("Error loading notifier: %v", err)
+	}
 
 	// Service
 	movieService := services.NewMovieService(movieRepo, config.RedisClient)
-	userService := services.NewUserService(userRepo, config.RedisClient)
+	lockoutService := services.NewLockoutService(lockoutRepo, config.RedisClient)
+	roleService := services.NewRoleService(roleRepo, config.RedisClient)
+	userService := services.NewUserService(userRepo, sessionRepo, config.RedisClient, notifier, lockoutService, roleService)
+	oidcService := services.NewOIDCService(identityRepo, userRepo, userService, oidcProviders, config.RedisClient)
+	artistService := services.NewArtistService(artistRepo)
+	genreService := services.NewGenreService(genreRepo)
+	editionService := services.NewEditionService(editionRepo)
+	competitionService := services.NewCompetitionService(competitionRepo, editionRepo)
+	juryService := services.NewJuryService(juryRepo, competitionRepo, userRepo, roleService)
+	votingService := services.NewVotingService(votingRepo, movieRepo, editionRepo, competitionRepo)
+	reviewService := services.NewReviewService(reviewRepo, movieRepo)
+	movieListService := services.NewMovieListService(movieListRepo, movieRepo)
+	watchProgressService := services.NewWatchProgressService(watchProgressRepo, movieRepo)
 
 	// Controller
 	movieController := controllers.NewMovieController(movieService)
 	userController := controllers.NewUserController(userService)
+	artistController := controllers.NewArtistController(artistService)
+	genreController := controllers.NewGenreController(genreService)
+	reviewController := controllers.NewReviewController(reviewService)
+	movieListController := controllers.NewMovieListController(movieListService)
+	watchProgressController := controllers.NewWatchProgressController(watchProgressService)
+	lockoutController := controllers.NewLockoutController(lockoutService)
+	roleController := controllers.NewRoleController(roleService)
+	oidcController := controllers.NewOIDCController(oidcService)
+	editionController := controllers.NewEditionController(editionService)
+	competitionController := controllers.NewCompetitionController(competitionService)
+	juryController := controllers.NewJuryController(juryService)
+	votingController := controllers.NewVotingController(votingService)
+
+	// Background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workersDone sync.WaitGroup
+	workersDone.Add(2)
+	go func() {
+		defer workersDone.Done()
+		workers.NewViewRollupWorker(movieService).Start(workerCtx)
+	}()
+	go func() {
+		defer workersDone.Done()
+		workers.NewViewFlushWorker(movieService).Start(workerCtx)
+	}()
 
 	// Swagger route
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	// Register Routes
-	routes.RegisterRoutes(e, movieController, userController)
+	routes.RegisterRoutes(e, movieController, userController, artistController, genreController, reviewController, movieListController, watchProgressController, lockoutController, roleController, oidcController, editionController, competitionController, juryController, votingController, roleService)
 
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -66,5 +149,34 @@ func main() {
 	}
 
 	// Start server
-	e.Logger.Fatal(e.Start(":" + port))
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	// Wait for an interrupt or a server failure, then stop accepting requests before the workers flush the buffered view counts
+	quit, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var startErr error
+	select {
+	case <-quit.Done():
+	case startErr = <-serverErr:
+		e.Logger.Error(startErr)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Error(err)
+	}
+
+	stopWorkers()
+	workersDone.Wait()
+
+	if startErr != nil {
+		os.Exit(1)
+	}
 }
@@ -15,14 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/admin/most-viewed": {
+        "/.well-known/jwks.json": {
             "get": {
+                "description": "The public keys access tokens are signed with, for other services to verify tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "Public keys",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/artist": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "To get most viewd movie",
+                "description": "To create artist profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,10 +52,21 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Get Most Viewed Movie",
+                "summary": "Create Artist",
+                "parameters": [
+                    {
+                        "description": "Artist Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ArtistRequest"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "Success get most viewd movie",
+                    "201": {
+                        "description": "Success create artist",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -46,8 +77,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Artist already exists",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -55,14 +86,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/most-viewed-genres": {
-            "get": {
+        "/api/admin/artist/{id}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "To get most viewed movie genre",
+                "description": "To update artist profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,37 +103,28 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Get Most Viewed Movie Genre",
+                "summary": "Update Artist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of the most view movie",
+                        "description": "id of the artist",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number for pagination",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order (ASC or DESC), default is DESC",
-                        "name": "sort_order",
-                        "in": "query"
+                        "description": "Artist Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ArtistRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success get most viewd movie",
+                        "description": "Success update artist",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -113,23 +135,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Artist not found",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/admin/movie": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "To create movie",
+                "description": "To delete artist and its movie credits",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,33 +159,25 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Create Movie",
+                "summary": "Delete Artist",
                 "parameters": [
                     {
-                        "description": "Movie Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateMovieRequest"
-                        }
+                        "type": "string",
+                        "description": "id of the artist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success create movie",
-                        "schema": {
-                            "$ref": "#/definitions/utils.JsonResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                        "description": "Success delete artist",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Artist not found",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -173,14 +185,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/movie/:id": {
+        "/api/admin/artist/{id}/merge": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "To update movie",
+                "description": "To merge duplicated artists into the artist with the given id",
                 "consumes": [
                     "application/json"
                 ],
@@ -190,21 +202,28 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Update Movie",
+                "summary": "Merge Artists",
                 "parameters": [
                     {
-                        "description": "Movie Request",
+                        "type": "string",
+                        "description": "id of the artist to keep",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge Artists Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateMovieRequest"
+                            "$ref": "#/definitions/models.MergeArtistsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success update movie",
+                        "description": "Success merge artists",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -215,8 +234,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Artist not found",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -224,14 +243,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/movies/most-voted": {
+        "/api/admin/artists": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "To get most voted movie",
+                "description": "To list artists by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -241,37 +260,14 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Most Voted Movie",
-                "responses": {
-                    "200": {
-                        "description": "Success vote movie",
-                        "schema": {
-                            "$ref": "#/definitions/utils.JsonResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
-                        "schema": {
-                            "$ref": "#/definitions/utils.JsonResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/movies": {
-            "get": {
-                "description": "To get all movie",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Get All Movie",
+                "summary": "List Artists",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Keyword to search artist name",
+                        "name": "query",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Limit number for pagination",
@@ -283,23 +279,11 @@ const docTemplate = `{
                         "description": "Offset of items per page",
                         "name": "offset",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Offset of items per page",
-                        "name": "use-cache",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success get most viewd movie",
-                        "schema": {
-                            "$ref": "#/definitions/utils.JsonResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                        "description": "Success list artists",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -307,9 +291,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/movies/search": {
-            "get": {
-                "description": "To search all movie by keyword",
+        "/api/admin/category/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rename award category",
                 "consumes": [
                     "application/json"
                 ],
@@ -317,32 +306,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Search Movie",
+                "summary": "Update Award Category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Keyword to search movie",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit number for pagination",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "id of the award category",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset of items per page",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Award Category Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success search movie",
+                        "description": "Success update award category",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -352,13 +339,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Award category not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Award category already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/movies/{id}/view": {
-            "post": {
-                "description": "To track view movie",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete award category with its winners",
                 "consumes": [
                     "application/json"
                 ],
@@ -366,27 +368,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Track View Movie",
+                "summary": "Delete Award Category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of the movie",
+                        "description": "id of the award category",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success track movie",
+                        "description": "Success delete award category",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "404": {
+                        "description": "Award category not found",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -394,9 +396,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/login": {
+        "/api/admin/category/{id}/winners": {
             "post": {
-                "description": "Authenticate a user and return a JWT token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To record movie nominated in the section of award category as a winner of the award",
                 "consumes": [
                     "application/json"
                 ],
@@ -404,23 +411,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "User Login",
+                "summary": "Add Winner",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "type": "string",
+                        "description": "id of the award category",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Winner Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.WinnerRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Access granted, includes JWT token",
+                        "description": "Success add winner",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -431,8 +445,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Award category not found or movie not nominated",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -440,14 +454,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/logout": {
-            "post": {
+        "/api/admin/category/{id}/winners/{movie_id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout an authenticated user by invalidating token.",
+                "description": "To remove movie from the winners of award category",
                 "consumes": [
                     "application/json"
                 ],
@@ -455,24 +469,83 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
+                ],
+                "summary": "Remove Winner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the award category",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "User Logout",
                 "responses": {
                     "200": {
-                        "description": "Success create user",
+                        "description": "Success remove winner",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "404": {
+                        "description": "Award category not found or movie not a winner",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    }
+                }
+            }
+        },
+        "/api/admin/criterion/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete scoring criterion of competition section whose scoring is open, with the scores given on it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Scoring Criterion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the criterion",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete criterion",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Criterion not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scoring closed",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -480,9 +553,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/movies/{id}/unvote": {
+        "/api/admin/edition": {
             "post": {
-                "description": "To unvote the movie",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To create festival edition as a draft",
                 "consumes": [
                     "application/json"
                 ],
@@ -490,21 +568,122 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Unvote Movie",
+                "summary": "Create Edition",
+                "parameters": [
+                    {
+                        "description": "Edition Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EditionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create edition",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/edition/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get festival edition with the movies entered into it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Edition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of the movie",
+                        "description": "id of the edition",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success unvote movie",
+                        "description": "Success get edition",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rename festival edition or move its dates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Edition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edition Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EditionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update edition",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -514,13 +693,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/user/movies/{id}/vote": {
+        "/api/admin/edition/{id}/close": {
             "post": {
-                "description": "To vote the movie",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To close open festival edition, its entries can no longer change",
                 "consumes": [
                     "application/json"
                 ],
@@ -528,27 +724,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Vote Movie",
+                "summary": "Close Edition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of the movie",
+                        "description": "id of the edition",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success vote movie",
+                        "description": "Success close edition",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition not open",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -556,9 +758,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/register": {
+        "/api/admin/edition/{id}/movies": {
             "post": {
-                "description": "Create User",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To enter movies into festival edition that is not closed",
                 "consumes": [
                     "application/json"
                 ],
@@ -566,23 +773,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "User Register",
+                "summary": "Enter Movies",
                 "parameters": [
                     {
-                        "description": "Register Request",
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edition Movies Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
+                            "$ref": "#/definitions/models.EditionMoviesRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success create user",
+                        "description": "Success enter movies",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -593,8 +807,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Edition or movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
@@ -602,14 +822,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/votes": {
-            "get": {
+        "/api/admin/edition/{id}/movies/{movie_id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "To get movie voted by user",
+                "description": "To withdraw movie from festival edition that is not closed",
                 "consumes": [
                     "application/json"
                 ],
@@ -617,71 +837,5849 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
+                ],
+                "summary": "Withdraw Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get User Vote",
                 "responses": {
                     "200": {
-                        "description": "Success get movie voted by user",
+                        "description": "Success withdraw movie",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "404": {
+                        "description": "Edition not found or movie not entered",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed",
                         "schema": {
                             "$ref": "#/definitions/utils.JsonResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.CreateMovieRequest": {
-            "type": "object",
-            "required": [
-                "artists",
-                "description",
-                "duration",
-                "genres",
-                "title",
-                "watch_url"
-            ],
-            "properties": {
-                "artists": {
-                    "description": "List of artist names",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "description": {
-                    "type": "string"
-                },
-                "duration": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "genres": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 150
-                },
-                "watch_url": {
-                    "type": "string"
-                }
-            }
         },
-        "models.LoginRequest": {
-            "type": "object",
+        "/api/admin/edition/{id}/open": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To open festival edition to the audience, or reopen a closed one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Open Edition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success open edition",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition already open",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/edition/{id}/section": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To add competition section to festival edition that is not closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Section",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Section Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create section",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Section already exists or edition closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/edition/{id}/sections": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the competition sections of festival edition with their nominees, award categories and winners",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Sections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list sections",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/edition/{id}/voting-period": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To add voting period to festival edition that is not closed, for all its entries or for the nominees of one of its sections",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Voting Period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voting Period Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VotingPeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create voting period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition or section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/edition/{id}/voting-periods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the voting periods of festival edition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Voting Periods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list voting periods",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/editions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list festival editions, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Editions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only list editions with this status (draft, open or closed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list editions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/genre": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To create genre, optionally as subgenre of a top-level genre",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Genre",
+                "parameters": [
+                    {
+                        "description": "Genre Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create genre",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Genre already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/genre/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rename genre or change its parent genre",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id of the genre",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update genre",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete genre and unlink it from its movies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id of the genre",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete genre",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/genre/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To merge duplicated genres into the genre with the given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Merge Genres",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id of the genre to keep",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge Genres Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeGenresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success merge genres",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/genres": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list all genres with their parent genre",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Genres",
+                "responses": {
+                    "200": {
+                        "description": "Success list genres",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/lockouts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list login lockouts and their unlocks, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Lockout Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the events of this username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the events of this client address",
+                        "name": "ip_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list lockout events",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/lockouts/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To lift the login lockout and clear the failed logins of a username and/or a client address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unlock Login",
+                "parameters": [
+                    {
+                        "description": "Unlock Login Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UnlockLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success unlock login",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/most-viewed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get most viewd movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Most Viewed Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only count views of the last window, e.g. 24h or 7d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views from this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views before this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count the movies entered into this festival edition",
+                        "name": "edition",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get most viewd movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No viewed movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/most-viewed-genres": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get most viewed movie genre",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Most Viewed Movie Genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the most view movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (ASC or DESC), default is DESC",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count views of subgenres towards their parent genre",
+                        "name": "rollup",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views of the last window, e.g. 24h or 7d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views from this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views before this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count the movies entered into this festival edition",
+                        "name": "edition",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get most viewd movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movie": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To create movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Movie",
+                "parameters": [
+                    {
+                        "description": "Movie Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMovieRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success create movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movie/:id": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To update movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Movie",
+                "parameters": [
+                    {
+                        "description": "Movie Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMovieRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movie/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To soft-delete movie, hiding it from listings and statistics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movie/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To permanently remove soft-deleted movie with its genres, artists, views and votes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Purge Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success purge movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movie/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To restore soft-deleted movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success restore movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movies/most-voted": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get most voted movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Most Voted Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only count votes of the last window, e.g. 24h or 7d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count votes from this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count votes before this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count the movies entered into this festival edition",
+                        "name": "edition",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success vote movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No voted movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movies/suspicious-views": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list client addresses with an unusual number of views of a single movie, over the last 24 hours by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Suspected View Inflation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Minimum views of one movie from one address, default is 20",
+                        "name": "min_views",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views of the last window, e.g. 24h or 7d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views from this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views before this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get suspected view inflation",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/movies/{id}/views": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get hourly or daily views, unique viewers and watch time of a movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Movie View Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket size (hour or day), default is day",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views of the last window, e.g. 24h or 7d",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views from this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count views before this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get movie view statistics",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the permissions a role can grant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Permissions",
+                "responses": {
+                    "200": {
+                        "description": "Success list permissions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/review/{id}/actions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list which admin approved or rejected a review and when",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Moderation Actions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list moderation actions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/review/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To publish a review and resolve its reports",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Approve Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success approve review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/review/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To hide a review from the public and resolve its reports",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reject Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success reject review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list reviews by moderation state, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Moderation Queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Moderation state (pending, approved or rejected), default is pending",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list moderation queue",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/role": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To create role granting a set of permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Role",
+                "parameters": [
+                    {
+                        "description": "Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create role",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role grants permissions the admin does not have",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/role/{name}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To replace the description and the permissions of a role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name of the role",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role Request, the name must match the path",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update role",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role grants permissions the admin does not have",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete role that is not assigned to any user, the users having the role have to be given another role first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name of the role",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete role",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role grants permissions the admin does not have",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role still assigned",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list all roles with their permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Roles",
+                "responses": {
+                    "200": {
+                        "description": "Success list roles",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rename competition section of festival edition that is not closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Section",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Section Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update section",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Section already exists or edition closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete competition section of festival edition that is not closed, with its nominations and awards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Section",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete section",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/category": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To add award category to competition section",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Award Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Award Category Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create award category",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Award category already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/criteria": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the scoring criteria of competition section",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Scoring Criteria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list criteria",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To add weighted scoring criterion to competition section whose scoring is open",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Scoring Criterion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scoring Criterion Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScoringCriterionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create criterion",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Criterion already exists or scoring closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/jurors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the jurors of competition section",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Jurors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list jurors",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To assign user whose role grants jury:score to the jury of competition section",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Assign Juror",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Juror Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JurorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success assign juror",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or user not in the jury role",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/jurors/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To remove juror from competition section, the scores they gave in the section are removed too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Remove Juror",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the juror",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success remove juror",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found or user not a juror",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/nominations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To nominate movies entered into festival edition in one of its sections",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Nominate Movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nominations Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NominationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success nominate movies",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found or movie not entered",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/nominations/{movie_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To remove movie from competition section, the awards it won in the section are removed too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Remove Nomination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success remove nomination",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found or movie not nominated",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/ranking": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rank the nominees of competition section by the aggregation of their jury scores",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Jury Ranking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "mean (default), trimmed_mean or median",
+                        "name": "aggregation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get ranking",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid aggregation",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/scoring/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To close the scoring of competition section, the jurors can then see each other's scores",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Close Scoring",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success close scoring",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scoring already closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/section/{id}/scoring/open": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To reopen the scoring of competition section, the jurors no longer see each other's scores",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Open Scoring",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success open scoring",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scoring already open",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get user with the summary of their votes, reviews, views and sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/{id}/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To block the logins of a user and sign out all their sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Disable User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success disable user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User has permissions the admin does not have",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/{id}/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To let a disabled user log in again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Enable User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success enable user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/{id}/password-reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To sign out all sessions of a user and block their logins until they reset the password with the reset token sent to them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Force Password Reset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success force password reset",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User has permissions the admin does not have",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/{id}/role": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To change the role of a user, the access tokens of the user stop working and have to be refreshed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Assign Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AssignRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success assign role",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role grants permissions the admin does not have",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/{id}/votes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the movies a user voted for, newest vote first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List User Votes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list user votes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list and search users, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the username or the email",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users with this role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only active or disabled users",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list users",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/voting-period/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To move voting period of festival edition that is not closed, change its section, its ballot mode or its vote budget",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Voting Period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the voting period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voting Period Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VotingPeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update voting period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voting period or section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Edition closed or ballot mode locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete voting period with its ranked ballots, the approval votes cast in it are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Voting Period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the voting period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete voting period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voting period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/voting-period/{id}/tally": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To tally the ballots of voting period round by round, approval periods by their votes and ranked periods by instant-runoff or Borda count",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Tally Voting Period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the voting period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "approval, instant_runoff (default for ranked periods) or borda",
+                        "name": "method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success tally voting period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tally method",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voting period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/artists/{id}": {
+            "get": {
+                "description": "To get artist profile with filmography, total views and total votes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Artist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the artist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get artist",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Artist not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/awards": {
+            "get": {
+                "description": "To list the award winners of open and closed festival editions per edition and section",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Festival Awards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only list the winners of this edition",
+                        "name": "edition",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list awards",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/editions": {
+            "get": {
+                "description": "To list the open and closed festival editions, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Festival Editions",
+                "responses": {
+                    "200": {
+                        "description": "Success list editions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/editions/{id}": {
+            "get": {
+                "description": "To get open or closed festival edition with the movies entered into it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Festival Edition Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the edition",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get edition",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Edition not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jury/section/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get competition section with its nominees, criteria and the scores of the juror, every score once scoring is closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jury"
+                ],
+                "summary": "Jury Sheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get jury sheet",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User not a juror of the section",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jury/section/{id}/scores": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To score nominee of competition section on its criteria, while scoring is open",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jury"
+                ],
+                "summary": "Submit Jury Scores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the section",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Jury Scores Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JuryScoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success submit scores",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or unknown criterion",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User not a juror of the section",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found or movie not nominated",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scoring closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jury/sections": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the competition sections the user is a juror of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jury"
+                ],
+                "summary": "Jury Sections",
+                "responses": {
+                    "200": {
+                        "description": "Success list sections",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies": {
+            "get": {
+                "description": "To get all movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get All Movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Offset of items per page",
+                        "name": "use-cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get most viewd movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/search": {
+            "get": {
+                "description": "To search all movie by keyword",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Search Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Keyword to search movie",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Artist name to search movie",
+                        "name": "artist",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Credit role of the artist (director, actor, writer, composer)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success search movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{id}": {
+            "get": {
+                "description": "To get movie detail with genres, artists, views and votes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Movie Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get movie detail",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{id}/reviews": {
+            "get": {
+                "description": "To list the reviews of a movie with its average rating and rating histogram",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Movie Reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the reviews (newest or helpful), default is newest",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit number for pagination",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of items per page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list reviews",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{id}/view": {
+            "post": {
+                "description": "To track view movie, attributed to the logged in user when a token is sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Track View Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Track View Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.TrackViewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success track movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/continue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the movies the user started and did not finish, most recently played first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Continue Watching",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of movies",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success list movies",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/identities": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the OpenID Connect provider accounts linked to the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Linked Providers",
+                "responses": {
+                    "200": {
+                        "description": "Linked provider accounts",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/lists/{list}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get the watchlist or favorites of the user with the movie details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Movie List",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "watchlist or favorites",
+                        "name": "list",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get movie list",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid list",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To add a movie at the end of the watchlist or favorites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Add Movie To List",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "watchlist or favorites",
+                        "name": "list",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Movie Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddMovieListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success add movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Movie already in list",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/lists/{list}/order": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To change the order of the watchlist or favorites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reorder Movie List",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "watchlist or favorites",
+                        "name": "list",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reorder Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReorderMovieListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success reorder list",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/lists/{list}/{movie_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To remove a movie from the watchlist or favorites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Remove Movie From List",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "watchlist or favorites",
+                        "name": "list",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success remove movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not in list",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/lists/{list}/{movie_id}/watched": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To mark a movie of the watchlist or favorites as watched or not watched",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Mark Movie Watched",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "watchlist or favorites",
+                        "name": "list",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mark Watched Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MarkWatchedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success mark movie watched",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not in list",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login": {
+            "post": {
+                "description": "Authenticate a user and return a short-lived JWT token with a refresh token. Users with two-factor authentication get an MFA token to complete the login at /api/user/login/mfa instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "User Login",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access granted, includes JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled or waiting for a password reset",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked after too many failed logins",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed logins, retry after the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login/mfa": {
+            "post": {
+                "description": "Complete a login with the MFA token of the login and a TOTP code or a recovery code. A user who enrolled during the login gets the recovery codes in the response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Complete MFA Login",
+                "parameters": [
+                    {
+                        "description": "MFA Login Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFALoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access granted, includes JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid MFA token or code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled or waiting for a password reset",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked after too many failed logins",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login/mfa/setup": {
+            "post": {
+                "description": "Create the TOTP secret of a user who has to enroll before completing the login, confirm it at /api/user/login/mfa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Enroll MFA During Login",
+                "parameters": [
+                    {
+                        "description": "MFA Token Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFATokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TOTP secret and otpauth URI",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid MFA token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logout an authenticated user by invalidating token and ending its session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "User Logout",
+                "responses": {
+                    "200": {
+                        "description": "Success create user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To turn two-factor authentication off with the password and a TOTP code or a recovery code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Disable MFA",
+                "parameters": [
+                    {
+                        "description": "Disable MFA Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DisableMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success disable MFA",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or MFA not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid password or MFA code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Two-factor authentication is required for the account",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked after too many failed logins",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many invalid codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/mfa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To confirm the TOTP secret of the setup with a code. The recovery codes of the response are only shown once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Enable MFA",
+                "parameters": [
+                    {
+                        "description": "MFA Code Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success enable MFA, includes recovery codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or MFA not set up",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid MFA code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many invalid codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To replace every recovery code after checking a TOTP code. The new codes are only shown once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Regenerate Recovery Codes",
+                "parameters": [
+                    {
+                        "description": "MFA Code Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New recovery codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or MFA not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid MFA code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many invalid codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/mfa/setup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To create a TOTP secret to enroll in an authenticator app. Two-factor authentication is enabled once a code confirms it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Set Up MFA",
+                "responses": {
+                    "200": {
+                        "description": "TOTP secret and otpauth URI",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/movies/{id}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get the position to resume a movie from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Watch Progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get progress",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To report the playback position of the user in a movie. The movie counts as completed past the configured share of its duration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Save Watch Progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watch Progress Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchProgressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success save progress",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/movies/{id}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rate a movie from 1 to 10 with an optional written review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success create review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Movie already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/movies/{id}/unvote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To unvote the movie while the voting period of the vote is open, the vote goes back to the budget",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Unvote Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success unvote movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Not voted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Voting closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/movies/{id}/vote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To vote the movie while a voting period is open for it, within the vote budget of the period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Vote Movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the movie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success vote movie",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Already voted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Voting closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Vote budget exhausted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/providers": {
+            "get": {
+                "description": "List the OpenID Connect providers users can log in with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List OIDC Providers",
+                "responses": {
+                    "200": {
+                        "description": "Provider names",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/{provider}/authorize": {
+            "get": {
+                "description": "Start a login with an OpenID Connect provider, send the user to the authorization URL in the same browser, it keeps the state in a cookie. With a bearer token the provider account is linked to the logged in user instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Start OIDC Login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name of the provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization URL and state",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown provider",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/oidc/{provider}/callback": {
+            "get": {
+                "description": "Complete a login with the code and the state the provider redirected back with. Users with two-factor authentication get an MFA token to complete the login at /api/user/login/mfa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Complete OIDC Login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name of the provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state of the authorization request",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access granted, includes JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid state, flow started in another browser or failed login at the provider",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled or waiting for a password reset",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Provider account or email already linked to another user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To change the password of the user. Every session is signed out and a new access token and refresh token are returned for the device of the request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change Password",
+                "parameters": [
+                    {
+                        "description": "Change Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success change password, includes JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or password does not meet the password policy",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Current password is incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked after too many failed logins",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed logins, retry after the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/password/forgot": {
+            "post": {
+                "description": "To send a password reset token to the user through the configured notifier. The response is the same whether the username exists or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Forgot Password",
+                "parameters": [
+                    {
+                        "description": "Forgot Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset token sent if the user exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests from the client address",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/password/reset": {
+            "post": {
+                "description": "To set a new password with a password reset token. The token can be used once and every session of the user is signed out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset Password",
+                "parameters": [
+                    {
+                        "description": "Reset Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success reset password",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token, or password does not meet the password policy",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and refresh token. A refresh token can be used once, using it again revokes the session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access granted, includes JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or reused refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/register": {
+            "post": {
+                "description": "Create User",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "User Register",
+                "parameters": [
+                    {
+                        "description": "Register Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success create user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or password does not meet the password policy",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/review/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To change the rating and text of your own review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success update review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To delete your own review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success delete review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/review/{id}/helpful": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To mark the review of another user as helpful",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Mark Review Helpful",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success mark review helpful",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Own review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To withdraw a helpful mark on a review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Unmark Review Helpful",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success unmark review helpful",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/review/{id}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To report an inappropriate review, which sends it back to the moderation queue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Report Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the review",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report Review Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReportReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success report review",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the active sessions of the user with their device and IP address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Sessions",
+                "responses": {
+                    "200": {
+                        "description": "Success list sessions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To sign out every device of the user, including the one of the request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Revoke All Sessions",
+                "responses": {
+                    "200": {
+                        "description": "Success revoke sessions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To sign out the device of a session of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Revoke Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the session",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success revoke session",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/votes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get movie voted by user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User Vote",
+                "responses": {
+                    "200": {
+                        "description": "Success get movie voted by user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/voting": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To list the voting periods open now with the votes the user has left in each of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List Open Voting Periods",
+                "responses": {
+                    "200": {
+                        "description": "Success list voting periods",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/voting/{id}/ballot": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To get the ballot the user cast in ranked voting period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Ballot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the voting period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success get ballot",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voting period or ballot not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To rank movies of open ranked voting period in order of preference, replacing the previous ballot of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Cast Ballot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the voting period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ballot Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BallotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success cast ballot",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Voting period closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voting period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "To withdraw the ballot the user cast in ranked voting period while it is open",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Withdraw Ballot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the voting period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success withdraw ballot",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Voting period closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voting period or ballot not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JsonResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "helpers.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "OKP",
+                    "type": "string"
+                },
+                "e": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "description": "OKP",
+                    "type": "string"
+                }
+            }
+        },
+        "helpers.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helpers.JWK"
+                    }
+                }
+            }
+        },
+        "models.AddMovieListItemRequest": {
+            "type": "object",
+            "required": [
+                "movie_id"
+            ],
+            "properties": {
+                "movie_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ArtistRequest": {
+            "type": "object",
+            "required": [
+                "external_ids",
+                "name"
+            ],
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "external_ids": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "photo_url": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.AssignRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.AwardCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "models.BallotRequest": {
+            "type": "object",
+            "required": [
+                "movie_ids"
+            ],
+            "properties": {
+                "movie_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72
+                }
+            }
+        },
+        "models.CreateMovieRequest": {
+            "type": "object",
+            "required": [
+                "artists",
+                "description",
+                "duration",
+                "genres",
+                "title",
+                "watch_url"
+            ],
+            "properties": {
+                "artists": {
+                    "description": "List of artist names, credited as actors",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "credits": {
+                    "description": "List of artists with their role",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CreditRequest"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "genres": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "watch_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreditRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "billing_order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "director",
+                        "actor",
+                        "writer",
+                        "composer"
+                    ]
+                }
+            }
+        },
+        "models.CriterionScore": {
+            "type": "object",
+            "required": [
+                "criterion_id"
+            ],
+            "properties": {
+                "criterion_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 0
+                }
+            }
+        },
+        "models.DisableMFARequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EditionMoviesRequest": {
+            "type": "object",
+            "required": [
+                "movie_ids"
+            ],
+            "properties": {
+                "movie_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.EditionRequest": {
+            "type": "object",
+            "required": [
+                "ends_on",
+                "name",
+                "starts_on"
+            ],
+            "properties": {
+                "ends_on": {
+                    "description": "YYYY-MM-DD, the last day of the edition",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "starts_on": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GenreRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.JurorRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JuryScoresRequest": {
+            "type": "object",
+            "required": [
+                "movie_id"
+            ],
+            "properties": {
+                "movie_id": {
+                    "type": "string"
+                },
+                "scores": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.CriterionScore"
+                    }
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
             "required": [
                 "password",
                 "username"
@@ -695,6 +6693,118 @@ const docTemplate = `{
                 }
             }
         },
+        "models.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "models.MFALoginRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFATokenRequest": {
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarkWatchedRequest": {
+            "type": "object",
+            "properties": {
+                "watched": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.MergeArtistsRequest": {
+            "type": "object",
+            "required": [
+                "source_ids"
+            ],
+            "properties": {
+                "source_ids": {
+                    "description": "Artists merged into the target artist",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.MergeGenresRequest": {
+            "type": "object",
+            "properties": {
+                "source_ids": {
+                    "description": "Genres merged into the target genre",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.ModerationRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.NominationsRequest": {
+            "type": "object",
+            "required": [
+                "movie_ids"
+            ],
+            "properties": {
+                "movie_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
         "models.RegisterRequest": {
             "type": "object",
             "required": [
@@ -702,10 +6812,14 @@ const docTemplate = `{
                 "username"
             ],
             "properties": {
+                "email": {
+                    "description": "Where password reset tokens are sent",
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "password": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 6
+                    "maxLength": 72
                 },
                 "username": {
                     "type": "string",
@@ -714,6 +6828,202 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ReorderMovieListRequest": {
+            "type": "object",
+            "required": [
+                "movie_ids"
+            ],
+            "properties": {
+                "movie_ids": {
+                    "description": "Every movie of the list in the new order",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ReportReviewRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                }
+            }
+        },
+        "models.RoleRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ScoringCriterionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "weight": {
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "models.SectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "models.TrackViewRequest": {
+            "type": "object",
+            "properties": {
+                "session_id": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "watch_duration": {
+                    "description": "seconds",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.UnlockLoginRequest": {
+            "type": "object",
+            "properties": {
+                "ip_address": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.VotingPeriodRequest": {
+            "type": "object",
+            "required": [
+                "closes_at",
+                "opens_at"
+            ],
+            "properties": {
+                "ballot_mode": {
+                    "type": "string",
+                    "enum": [
+                        "approval",
+                        "ranked"
+                    ]
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "max_rankings": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 1
+                },
+                "opens_at": {
+                    "type": "string"
+                },
+                "section_id": {
+                    "type": "string"
+                },
+                "vote_budget": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.WatchProgressRequest": {
+            "type": "object",
+            "properties": {
+                "position_seconds": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.WinnerRequest": {
+            "type": "object",
+            "required": [
+                "movie_id"
+            ],
+            "properties": {
+                "movie_id": {
+                    "type": "string"
+                }
+            }
+        },
         "utils.JsonResponse": {
             "type": "object",
             "properties": {
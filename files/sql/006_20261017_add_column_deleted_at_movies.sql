@@ -0,0 +1,3 @@
+ALTER TABLE movie_festival.movies
+ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER updated_at,
+ADD INDEX idx_movies_deleted_at (deleted_at);
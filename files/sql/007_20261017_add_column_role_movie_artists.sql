@@ -0,0 +1,10 @@
+ALTER TABLE movie_festival.movie_artists
+ADD COLUMN role ENUM('director', 'actor', 'writer', 'composer') NOT NULL DEFAULT 'actor' AFTER artist_id,
+ADD COLUMN billing_order INT NOT NULL DEFAULT 0 AFTER role;
+
+-- An artist can hold several roles on the same movie (e.g. director and writer)
+ALTER TABLE movie_festival.movie_artists
+DROP INDEX unique_movie_artist,
+DROP PRIMARY KEY,
+ADD PRIMARY KEY (movie_id, artist_id, role),
+ADD INDEX idx_movie_artists_role (role);
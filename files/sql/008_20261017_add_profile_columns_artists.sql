@@ -0,0 +1,8 @@
+ALTER TABLE movie_festival.artists
+ADD COLUMN bio TEXT NULL AFTER name,
+ADD COLUMN birth_date DATE NULL AFTER bio,
+ADD COLUMN photo_url VARCHAR(255) NULL AFTER birth_date,
+ADD COLUMN external_ids JSON NULL AFTER photo_url,
+ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+ADD INDEX idx_artists_name (name);
@@ -0,0 +1,4 @@
+ALTER TABLE movie_festival.genres
+ADD COLUMN parent_id INT NULL AFTER name,
+ADD INDEX idx_genres_name (name),
+ADD CONSTRAINT fk_genres_parent FOREIGN KEY (parent_id) REFERENCES genres(id) ON DELETE SET NULL;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS movie_festival.movie_view_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    movie_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NULL,
+    session_id VARCHAR(100) NULL, -- anonymous session or device fingerprint
+    watch_duration INT NOT NULL DEFAULT 0, -- seconds
+    viewed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_view_events_viewed_at (viewed_at),
+    INDEX idx_view_events_movie (movie_id, viewed_at),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.movie_view_stats_hourly (
+    movie_id VARCHAR(50) NOT NULL,
+    bucket_start DATETIME NOT NULL,
+    view_count BIGINT NOT NULL DEFAULT 0,
+    unique_viewers BIGINT NOT NULL DEFAULT 0,
+    watch_seconds BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (movie_id, bucket_start),
+    INDEX idx_view_stats_hourly_bucket (bucket_start),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.movie_view_stats_daily (
+    movie_id VARCHAR(50) NOT NULL,
+    bucket_start DATE NOT NULL,
+    view_count BIGINT NOT NULL DEFAULT 0,
+    unique_viewers BIGINT NOT NULL DEFAULT 0,
+    watch_seconds BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (movie_id, bucket_start),
+    INDEX idx_view_stats_daily_bucket (bucket_start),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE movie_festival.movie_view_events
+    ADD COLUMN ip_address VARCHAR(45) NULL AFTER session_id,
+    ADD INDEX idx_view_events_ip (ip_address, viewed_at);
@@ -0,0 +1,22 @@
+-- movie_views had no unique key, so every view upsert inserted a new row. Merge them into one row per movie.
+CREATE TABLE movie_festival.movie_views_merged AS
+SELECT movie_id, SUM(view_count) AS view_count, MAX(last_viewed_at) AS last_viewed_at
+FROM movie_festival.movie_views
+GROUP BY movie_id;
+
+DELETE FROM movie_festival.movie_views;
+
+INSERT INTO movie_festival.movie_views (movie_id, view_count, last_viewed_at)
+SELECT movie_id, view_count, last_viewed_at FROM movie_festival.movie_views_merged;
+
+DROP TABLE movie_festival.movie_views_merged;
+
+ALTER TABLE movie_festival.movie_views
+ADD UNIQUE KEY uq_movie_views_movie (movie_id);
+
+-- Batches of buffered view counts already applied, so a batch replayed after a crash is not counted twice
+CREATE TABLE IF NOT EXISTS movie_festival.movie_view_flushes (
+    batch_id VARCHAR(50) PRIMARY KEY,
+    flushed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_view_flushes_flushed_at (flushed_at)
+);
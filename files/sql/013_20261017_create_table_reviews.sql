@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS movie_festival.reviews (
+    id VARCHAR(50) PRIMARY KEY,
+    movie_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    rating TINYINT NOT NULL, -- 1 to 10
+    body TEXT NULL,
+    helpful_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE (movie_id, user_id),
+    INDEX idx_reviews_movie_created (movie_id, created_at),
+    INDEX idx_reviews_movie_helpful (movie_id, helpful_count),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.review_helpful_votes (
+    review_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id),
+    FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,27 @@
+ALTER TABLE movie_festival.reviews
+ADD COLUMN status ENUM('pending', 'approved', 'rejected') NOT NULL DEFAULT 'approved' AFTER helpful_count,
+ADD COLUMN moderation_reason VARCHAR(255) NULL AFTER status, -- why the review is waiting for moderation
+ADD INDEX idx_reviews_status (status, created_at);
+
+CREATE TABLE IF NOT EXISTS movie_festival.review_reports (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    review_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    resolved_at DATETIME NULL, -- set when an admin moderated the review after the report
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (review_id, user_id),
+    FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.review_moderation_actions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    review_id VARCHAR(50) NOT NULL,
+    admin_id VARCHAR(50) NOT NULL,
+    action ENUM('approve', 'reject') NOT NULL,
+    reason VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_moderation_actions_review (review_id, created_at),
+    FOREIGN KEY (admin_id) REFERENCES users(id)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS movie_festival.user_movie_lists (
+    user_id VARCHAR(50) NOT NULL,
+    list ENUM('watchlist', 'favorites') NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    position INT NOT NULL, -- 1-based order chosen by the user
+    watched_at DATETIME NULL,
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, list, movie_id),
+    INDEX idx_user_movie_lists_position (user_id, list, position),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS movie_festival.watch_progress (
+    user_id VARCHAR(50) NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    position_seconds INT NOT NULL DEFAULT 0, -- last playback position reported by the player
+    completed_at DATETIME NULL, -- set once the position passed WATCH_COMPLETION_THRESHOLD, cleared on a rewatch
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, movie_id),
+    INDEX idx_watch_progress_recent (user_id, completed_at, updated_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+-- One row per completed watch, rolled up into the view statistics
+CREATE TABLE IF NOT EXISTS movie_festival.movie_completions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    movie_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    completed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_movie_completions_completed_at (completed_at),
+    INDEX idx_movie_completions_movie (movie_id, completed_at),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+ALTER TABLE movie_festival.movie_view_stats_hourly
+    ADD COLUMN completions BIGINT NOT NULL DEFAULT 0 AFTER watch_seconds;
+
+ALTER TABLE movie_festival.movie_view_stats_daily
+    ADD COLUMN completions BIGINT NOT NULL DEFAULT 0 AFTER watch_seconds;
@@ -0,0 +1,22 @@
+-- A session is one login of a user on a device, its refresh tokens form a rotation family
+CREATE TABLE IF NOT EXISTS movie_festival.user_sessions (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    user_agent VARCHAR(255) NULL,
+    ip_address VARCHAR(45) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, -- last login or refresh
+    expires_at DATETIME NOT NULL, -- extended on every refresh
+    revoked_at DATETIME NULL,
+    INDEX idx_user_sessions_user (user_id, revoked_at, expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.refresh_tokens (
+    id VARCHAR(50) PRIMARY KEY,
+    session_id VARCHAR(50) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 of the token, the token itself is never stored
+    used_at DATETIME NULL, -- set when the token was exchanged, a second use revokes the session
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES user_sessions(id) ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+ALTER TABLE movie_festival.users
+ADD COLUMN email VARCHAR(255) NULL UNIQUE AFTER username; -- where password reset tokens are delivered
+
+CREATE TABLE IF NOT EXISTS movie_festival.password_reset_tokens (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 of the token, the token itself is only sent to the user
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL, -- set when the token reset the password or was replaced by a newer token
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_password_reset_tokens_user (user_id, used_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS movie_festival.login_lockout_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    scope ENUM('user', 'ip') NOT NULL,
+    subject VARCHAR(255) NOT NULL, -- the username or the IP address that was locked
+    action ENUM('lock', 'unlock') NOT NULL,
+    ip_address VARCHAR(45) NULL, -- the client of the failed attempt that caused the lockout
+    failed_attempts INT NULL,
+    locked_until DATETIME NULL,
+    admin_id VARCHAR(50) NULL, -- the admin who lifted the lockout
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_login_lockout_events_subject (scope, subject, created_at),
+    INDEX idx_login_lockout_events_created (created_at),
+    FOREIGN KEY (admin_id) REFERENCES users(id) ON DELETE SET NULL
+);
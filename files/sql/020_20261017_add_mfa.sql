@@ -0,0 +1,13 @@
+ALTER TABLE movie_festival.users
+ADD COLUMN mfa_secret VARCHAR(64) NULL AFTER role, -- base32 TOTP secret, set at enrollment before it is confirmed
+ADD COLUMN mfa_enabled_at DATETIME NULL AFTER mfa_secret; -- set once the user confirmed the secret with a code
+
+CREATE TABLE IF NOT EXISTS movie_festival.mfa_recovery_codes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    code_hash CHAR(64) NOT NULL, -- SHA-256 of the code, the codes are only shown to the user once
+    used_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
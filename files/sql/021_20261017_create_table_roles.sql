@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS movie_festival.roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.role_permissions (
+    role VARCHAR(50) NOT NULL,
+    permission VARCHAR(50) NOT NULL, -- e.g. movie:write, checked by the RequirePermission middleware
+    PRIMARY KEY (role, permission),
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+INSERT IGNORE INTO movie_festival.roles (name, description) VALUES
+    ('user', 'Festival audience, no admin permissions'),
+    ('admin', 'Every permission'),
+    ('editor', 'Manages the catalog of movies, artists and genres'),
+    ('analyst', 'Reads the view and vote analytics'),
+    ('moderator', 'Moderates reviews');
+
+INSERT IGNORE INTO movie_festival.role_permissions (role, permission) VALUES
+    ('admin', 'movie:write'),
+    ('admin', 'analytics:read'),
+    ('admin', 'review:moderate'),
+    ('admin', 'user:manage'),
+    ('admin', 'role:manage'),
+    ('editor', 'movie:write'),
+    ('analyst', 'analytics:read'),
+    ('moderator', 'review:moderate');
+
+-- Roles are rows of the roles table instead of an ENUM
+ALTER TABLE movie_festival.users
+MODIFY COLUMN role VARCHAR(50) NOT NULL DEFAULT 'user',
+ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name);
@@ -0,0 +1,4 @@
+ALTER TABLE movie_festival.users
+ADD COLUMN disabled_at DATETIME NULL, -- disabled accounts cannot log in
+ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE, -- set by an admin, cleared by a password reset
+ADD INDEX idx_users_role (role);
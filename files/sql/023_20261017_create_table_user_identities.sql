@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS movie_festival.user_identities (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL, -- name of the provider in OIDC_PROVIDERS
+    subject VARCHAR(255) NOT NULL, -- sub claim, stable identifier of the user at the provider
+    email VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_login_at DATETIME NULL,
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS movie_festival.festival_editions (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE, -- e.g. Movie Festival 2026
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    status ENUM('draft', 'open', 'closed') NOT NULL DEFAULT 'draft',
+    opened_at DATETIME NULL,
+    closed_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_festival_editions_status (status, starts_on)
+);
+
+-- Movies entered into an edition, a movie can be entered into several editions
+CREATE TABLE IF NOT EXISTS movie_festival.edition_movies (
+    edition_id VARCHAR(50) NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    entered_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (edition_id, movie_id),
+    INDEX idx_edition_movies_movie_id (movie_id),
+    FOREIGN KEY (edition_id) REFERENCES festival_editions(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+INSERT IGNORE INTO movie_festival.role_permissions (role, permission) VALUES
+    ('admin', 'festival:manage');
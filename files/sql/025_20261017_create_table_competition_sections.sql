@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS movie_festival.competition_sections (
+    id VARCHAR(50) PRIMARY KEY,
+    edition_id VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL, -- e.g. Main Competition, Shorts, Documentary
+    description VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (edition_id, name),
+    FOREIGN KEY (edition_id) REFERENCES festival_editions(id) ON DELETE CASCADE
+);
+
+-- Movies of the edition competing in a section
+CREATE TABLE IF NOT EXISTS movie_festival.section_nominations (
+    section_id VARCHAR(50) NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    nominated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (section_id, movie_id),
+    INDEX idx_section_nominations_movie_id (movie_id),
+    FOREIGN KEY (section_id) REFERENCES competition_sections(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.award_categories (
+    id VARCHAR(50) PRIMARY KEY,
+    section_id VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL, -- e.g. Best Film, Jury Prize
+    description VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (section_id, name),
+    FOREIGN KEY (section_id) REFERENCES competition_sections(id) ON DELETE CASCADE
+);
+
+-- A category can have several winners (ex aequo), each one nominated in the section of the category
+CREATE TABLE IF NOT EXISTS movie_festival.award_winners (
+    category_id VARCHAR(50) NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    awarded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (category_id, movie_id),
+    INDEX idx_award_winners_movie_id (movie_id),
+    FOREIGN KEY (category_id) REFERENCES award_categories(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
@@ -0,0 +1,45 @@
+ALTER TABLE movie_festival.competition_sections
+ADD COLUMN scoring_closed_at DATETIME NULL; -- jurors see each other's scores once scoring is closed
+
+CREATE TABLE IF NOT EXISTS movie_festival.section_jurors (
+    section_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (section_id, user_id),
+    INDEX idx_section_jurors_user_id (user_id),
+    FOREIGN KEY (section_id) REFERENCES competition_sections(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_festival.scoring_criteria (
+    id VARCHAR(50) PRIMARY KEY,
+    section_id VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL, -- e.g. Direction, Screenplay
+    weight DECIMAL(5,2) NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (section_id, name),
+    FOREIGN KEY (section_id) REFERENCES competition_sections(id) ON DELETE CASCADE
+);
+
+-- One score from 0 to 10 per juror, movie and criterion
+CREATE TABLE IF NOT EXISTS movie_festival.jury_scores (
+    criterion_id VARCHAR(50) NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    score DECIMAL(4,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (criterion_id, movie_id, user_id),
+    INDEX idx_jury_scores_movie_id (movie_id),
+    INDEX idx_jury_scores_user_id (user_id),
+    FOREIGN KEY (criterion_id) REFERENCES scoring_criteria(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT IGNORE INTO movie_festival.roles (name, description) VALUES
+    ('jury', 'Scores the movies of the competition sections they are assigned to');
+
+INSERT IGNORE INTO movie_festival.role_permissions (role, permission) VALUES
+    ('admin', 'jury:score'),
+    ('jury', 'jury:score');
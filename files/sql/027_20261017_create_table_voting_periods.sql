@@ -0,0 +1,20 @@
+-- Votes are only accepted while a voting period of an open edition covers the movie
+CREATE TABLE IF NOT EXISTS movie_festival.voting_periods (
+    id VARCHAR(50) PRIMARY KEY,
+    edition_id VARCHAR(50) NOT NULL,
+    section_id VARCHAR(50) NULL, -- only the nominees of the section, every entry of the edition when NULL
+    opens_at DATETIME NOT NULL,
+    closes_at DATETIME NOT NULL,
+    vote_budget INT NULL, -- votes a user can cast in the period, unlimited when NULL
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_voting_periods_window (opens_at, closes_at),
+    FOREIGN KEY (edition_id) REFERENCES festival_editions(id) ON DELETE CASCADE,
+    FOREIGN KEY (section_id) REFERENCES competition_sections(id) ON DELETE CASCADE
+);
+
+-- The voting period a vote counts against, NULL for the votes cast before voting periods
+ALTER TABLE movie_festival.votes
+ADD COLUMN voting_period_id VARCHAR(50) NULL,
+ADD INDEX idx_votes_voting_period_user (voting_period_id, user_id),
+ADD CONSTRAINT fk_votes_voting_period FOREIGN KEY (voting_period_id) REFERENCES voting_periods(id) ON DELETE SET NULL;
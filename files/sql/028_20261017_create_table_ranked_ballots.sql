@@ -0,0 +1,27 @@
+-- Approval periods take one vote per movie in votes, ranked periods take one ordered ballot per user
+ALTER TABLE movie_festival.voting_periods
+ADD COLUMN ballot_mode ENUM('approval', 'ranked') NOT NULL DEFAULT 'approval' AFTER closes_at,
+ADD COLUMN max_rankings INT NULL AFTER ballot_mode; -- how many movies a ranked ballot can order, NULL for approval
+
+CREATE TABLE IF NOT EXISTS movie_festival.ranked_ballots (
+    id VARCHAR(50) PRIMARY KEY,
+    voting_period_id VARCHAR(50) NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_ranked_ballots_period_user (voting_period_id, user_id),
+    FOREIGN KEY (voting_period_id) REFERENCES voting_periods(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- The movies of a ballot in order of preference, position 1 first
+CREATE TABLE IF NOT EXISTS movie_festival.ballot_rankings (
+    ballot_id VARCHAR(50) NOT NULL,
+    position INT NOT NULL,
+    movie_id VARCHAR(50) NOT NULL,
+    PRIMARY KEY (ballot_id, position),
+    UNIQUE KEY uq_ballot_rankings_movie (ballot_id, movie_id),
+    INDEX idx_ballot_rankings_movie_id (movie_id),
+    FOREIGN KEY (ballot_id) REFERENCES ranked_ballots(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
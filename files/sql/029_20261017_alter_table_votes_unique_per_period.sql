@@ -0,0 +1,5 @@
+-- A user votes for a movie once per voting period, so a movie entered into several editions can be voted for in each.
+-- The index created by UNIQUE(user_id, movie_id) is named after its first column.
+ALTER TABLE movie_festival.votes
+ADD UNIQUE KEY uq_votes_user_movie_period (user_id, movie_id, voting_period_id),
+DROP INDEX user_id;
@@ -0,0 +1,8 @@
+-- A user completes a movie once, seeking back below the threshold and forward again must not count as another completion.
+-- Keep the first completion of every user and movie before adding the key.
+DELETE later FROM movie_festival.movie_completions later
+JOIN movie_festival.movie_completions earlier
+    ON earlier.user_id = later.user_id AND earlier.movie_id = later.movie_id AND earlier.id < later.id;
+
+ALTER TABLE movie_festival.movie_completions
+ADD UNIQUE KEY uq_movie_completions_user_movie (user_id, movie_id);
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ArtistController struct {
+	service services.ArtistService
+}
+
+func NewArtistController(service services.ArtistService) *ArtistController {
+	return &ArtistController{service}
+}
+
+// @Summary Create Artist
+// @Description To create artist profile
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ArtistRequest true "Artist Request"
+// @Success 201 {object} utils.JsonResponse "Success create artist"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 409 {object} utils.JsonResponse "Artist already exists"
+// @Router /api/admin/artist [post]
+func (c *ArtistController) CreateArtist(ctx echo.Context) error {
+	req := new(models.ArtistRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	artist, err := c.service.CreateArtist(ctx.Request().Context(), *req)
+	if err != nil {
+		return artistFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Artist created successfully", artist)
+}
+
+// @Summary Update Artist
+// @Description To update artist profile
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the artist"
+// @Param request body models.ArtistRequest true "Artist Request"
+// @Success 200 {object} utils.JsonResponse "Success update artist"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Artist not found"
+// @Router /api/admin/artist/{id} [post]
+func (c *ArtistController) UpdateArtist(ctx echo.Context) error {
+	artistID := ctx.Param("id")
+	if artistID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Artist ID is required")
+	}
+
+	req := new(models.ArtistRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	artist, err := c.service.UpdateArtist(ctx.Request().Context(), artistID, *req)
+	if err != nil {
+		return artistFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Artist updated successfully", artist)
+}
+
+// @Summary Delete Artist
+// @Description To delete artist and its movie credits
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the artist"
+// @Success 200 {object} utils.JsonResponse "Success delete artist"
+// @Failure 404 {object} utils.JsonResponse "Artist not found"
+// @Router /api/admin/artist/{id} [delete]
+func (c *ArtistController) DeleteArtist(ctx echo.Context) error {
+	artistID := ctx.Param("id")
+	if artistID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Artist ID is required")
+	}
+
+	if err := c.service.DeleteArtist(ctx.Request().Context(), artistID); err != nil {
+		return artistFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Artist deleted successfully", nil)
+}
+
+// @Summary List Artists
+// @Description To list artists by name
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param query query string false "Keyword to search artist name"
+// @Param limit query int false "Limit number for pagination"
+// @Param offset query int false "Offset of items per page"
+// @Success 200 {object} utils.JsonResponse "Success list artists"
+// @Router /api/admin/artists [get]
+func (c *ArtistController) ListArtists(ctx echo.Context) error {
+	query := ctx.QueryParam("query")
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0 // default offset
+	}
+
+	artists, err := c.service.ListArtists(ctx.Request().Context(), query, limit, offset)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", artists)
+}
+
+// @Summary Merge Artists
+// @Description To merge duplicated artists into the artist with the given id
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the artist to keep"
+// @Param request body models.MergeArtistsRequest true "Merge Artists Request"
+// @Success 200 {object} utils.JsonResponse "Success merge artists"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Artist not found"
+// @Router /api/admin/artist/{id}/merge [post]
+func (c *ArtistController) MergeArtists(ctx echo.Context) error {
+	artistID := ctx.Param("id")
+	if artistID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Artist ID is required")
+	}
+
+	req := new(models.MergeArtistsRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.MergeArtists(ctx.Request().Context(), artistID, req.SourceIDs); err != nil {
+		return artistFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Artists merged successfully", nil)
+}
+
+// @Summary Get Artist
+// @Description To get artist profile with filmography, total views and total votes
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path string true "id of the artist"
+// @Success 200 {object} utils.JsonResponse "Success get artist"
+// @Failure 404 {object} utils.JsonResponse "Artist not found"
+// @Router /api/artists/{id} [get]
+func (c *ArtistController) GetArtist(ctx echo.Context) error {
+	artistID := ctx.Param("id")
+	if artistID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Artist ID is required")
+	}
+
+	filmography, err := c.service.GetArtistFilmography(ctx.Request().Context(), artistID)
+	if err != nil {
+		return artistFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", filmography)
+}
+
+func artistFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "artist is not exists")
+	case errors.Is(err, services.ErrArtistExists):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidArtistDate), errors.Is(err, services.ErrMergeSelf):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CompetitionController struct {
+	service services.CompetitionService
+}
+
+func NewCompetitionController(service services.CompetitionService) *CompetitionController {
+	return &CompetitionController{service}
+}
+
+// @Summary List Sections
+// @Description To list the competition sections of festival edition with their nominees, award categories and winners
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Success 200 {object} utils.JsonResponse "Success list sections"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Router /api/admin/edition/{id}/sections [get]
+func (c *CompetitionController) ListSections(ctx echo.Context) error {
+	sections, err := c.service.ListSections(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", sections)
+}
+
+// @Summary Create Section
+// @Description To add competition section to festival edition that is not closed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Param request body models.SectionRequest true "Section Request"
+// @Success 201 {object} utils.JsonResponse "Success create section"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Failure 409 {object} utils.JsonResponse "Section already exists or edition closed"
+// @Router /api/admin/edition/{id}/section [post]
+func (c *CompetitionController) CreateSection(ctx echo.Context) error {
+	req := new(models.SectionRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	section, err := c.service.CreateSection(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Section created successfully", section)
+}
+
+// @Summary Update Section
+// @Description To rename competition section of festival edition that is not closed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param request body models.SectionRequest true "Section Request"
+// @Success 200 {object} utils.JsonResponse "Success update section"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Failure 409 {object} utils.JsonResponse "Section already exists or edition closed"
+// @Router /api/admin/section/{id} [post]
+func (c *CompetitionController) UpdateSection(ctx echo.Context) error {
+	req := new(models.SectionRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	section, err := c.service.UpdateSection(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Section updated successfully", section)
+}
+
+// @Summary Delete Section
+// @Description To delete competition section of festival edition that is not closed, with its nominations and awards
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Success 200 {object} utils.JsonResponse "Success delete section"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Failure 409 {object} utils.JsonResponse "Edition closed"
+// @Router /api/admin/section/{id} [delete]
+func (c *CompetitionController) DeleteSection(ctx echo.Context) error {
+	if err := c.service.DeleteSection(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Section deleted successfully", nil)
+}
+
+// @Summary Nominate Movies
+// @Description To nominate movies entered into festival edition in one of its sections
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param request body models.NominationsRequest true "Nominations Request"
+// @Success 200 {object} utils.JsonResponse "Success nominate movies"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Section not found or movie not entered"
+// @Failure 409 {object} utils.JsonResponse "Edition closed"
+// @Router /api/admin/section/{id}/nominations [post]
+func (c *CompetitionController) NominateMovies(ctx echo.Context) error {
+	req := new(models.NominationsRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.NominateMovies(ctx.Request().Context(), ctx.Param("id"), req.MovieIDs); err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movies nominated successfully", nil)
+}
+
+// @Summary Remove Nomination
+// @Description To remove movie from competition section, the awards it won in the section are removed too
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param movie_id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success remove nomination"
+// @Failure 404 {object} utils.JsonResponse "Section not found or movie not nominated"
+// @Failure 409 {object} utils.JsonResponse "Edition closed"
+// @Router /api/admin/section/{id}/nominations/{movie_id} [delete]
+func (c *CompetitionController) RemoveNomination(ctx echo.Context) error {
+	if err := c.service.RemoveNomination(ctx.Request().Context(), ctx.Param("id"), ctx.Param("movie_id")); err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Nomination removed successfully", nil)
+}
+
+// @Summary Create Award Category
+// @Description To add award category to competition section
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param request body models.AwardCategoryRequest true "Award Category Request"
+// @Success 201 {object} utils.JsonResponse "Success create award category"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Failure 409 {object} utils.JsonResponse "Award category already exists"
+// @Router /api/admin/section/{id}/category [post]
+func (c *CompetitionController) CreateAwardCategory(ctx echo.Context) error {
+	req := new(models.AwardCategoryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	category, err := c.service.CreateAwardCategory(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Award category created successfully", category)
+}
+
+// @Summary Update Award Category
+// @Description To rename award category
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the award category"
+// @Param request body models.AwardCategoryRequest true "Award Category Request"
+// @Success 200 {object} utils.JsonResponse "Success update award category"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Award category not found"
+// @Failure 409 {object} utils.JsonResponse "Award category already exists"
+// @Router /api/admin/category/{id} [post]
+func (c *CompetitionController) UpdateAwardCategory(ctx echo.Context) error {
+	req := new(models.AwardCategoryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	category, err := c.service.UpdateAwardCategory(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Award category updated successfully", category)
+}
+
+// @Summary Delete Award Category
+// @Description To delete award category with its winners
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the award category"
+// @Success 200 {object} utils.JsonResponse "Success delete award category"
+// @Failure 404 {object} utils.JsonResponse "Award category not found"
+// @Router /api/admin/category/{id} [delete]
+func (c *CompetitionController) DeleteAwardCategory(ctx echo.Context) error {
+	if err := c.service.DeleteAwardCategory(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Award category deleted successfully", nil)
+}
+
+// @Summary Add Winner
+// @Description To record movie nominated in the section of award category as a winner of the award
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the award category"
+// @Param request body models.WinnerRequest true "Winner Request"
+// @Success 200 {object} utils.JsonResponse "Success add winner"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Award category not found or movie not nominated"
+// @Router /api/admin/category/{id}/winners [post]
+func (c *CompetitionController) AddWinner(ctx echo.Context) error {
+	req := new(models.WinnerRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.AddWinner(ctx.Request().Context(), ctx.Param("id"), req.MovieID); err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Winner added successfully", nil)
+}
+
+// @Summary Remove Winner
+// @Description To remove movie from the winners of award category
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the award category"
+// @Param movie_id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success remove winner"
+// @Failure 404 {object} utils.JsonResponse "Award category not found or movie not a winner"
+// @Router /api/admin/category/{id}/winners/{movie_id} [delete]
+func (c *CompetitionController) RemoveWinner(ctx echo.Context) error {
+	if err := c.service.RemoveWinner(ctx.Request().Context(), ctx.Param("id"), ctx.Param("movie_id")); err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Winner removed successfully", nil)
+}
+
+// @Summary Festival Awards
+// @Description To list the award winners of open and closed festival editions per edition and section
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param edition query string false "Only list the winners of this edition"
+// @Success 200 {object} utils.JsonResponse "Success list awards"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Router /api/awards [get]
+func (c *CompetitionController) ListAwards(ctx echo.Context) error {
+	awards, err := c.service.ListAwards(ctx.Request().Context(), ctx.QueryParam("edition"))
+	if err != nil {
+		return competitionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", awards)
+}
+
+func competitionFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "edition is not exists")
+	case errors.Is(err, services.ErrSectionNotExists),
+		errors.Is(err, services.ErrAwardCategoryNotExists),
+		errors.Is(err, services.ErrMovieNotEntered),
+		errors.Is(err, services.ErrMovieNotNominated),
+		errors.Is(err, services.ErrMovieNotAwarded):
+		return utils.FailResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrSectionExists),
+		errors.Is(err, services.ErrAwardCategoryExists),
+		errors.Is(err, services.ErrEditionClosed):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EditionController struct {
+	service services.EditionService
+}
+
+func NewEditionController(service services.EditionService) *EditionController {
+	return &EditionController{service}
+}
+
+// @Summary List Editions
+// @Description To list festival editions, the latest first
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Only list editions with this status (draft, open or closed)"
+// @Success 200 {object} utils.JsonResponse "Success list editions"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Router /api/admin/editions [get]
+func (c *EditionController) ListEditions(ctx echo.Context) error {
+	editions, err := c.service.ListEditions(ctx.Request().Context(), ctx.QueryParam("status"))
+	if err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", editions)
+}
+
+// @Summary Get Edition
+// @Description To get festival edition with the movies entered into it
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Success 200 {object} utils.JsonResponse "Success get edition"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Router /api/admin/edition/{id} [get]
+func (c *EditionController) GetEdition(ctx echo.Context) error {
+	edition, err := c.service.GetEdition(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", edition)
+}
+
+// @Summary Create Edition
+// @Description To create festival edition as a draft
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.EditionRequest true "Edition Request"
+// @Success 201 {object} utils.JsonResponse "Success create edition"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 409 {object} utils.JsonResponse "Edition already exists"
+// @Router /api/admin/edition [post]
+func (c *EditionController) CreateEdition(ctx echo.Context) error {
+	req := new(models.EditionRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	edition, err := c.service.CreateEdition(ctx.Request().Context(), *req)
+	if err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Edition created successfully", edition)
+}
+
+// @Summary Update Edition
+// @Description To rename festival edition or move its dates
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Param request body models.EditionRequest true "Edition Request"
+// @Success 200 {object} utils.JsonResponse "Success update edition"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Failure 409 {object} utils.JsonResponse "Edition already exists"
+// @Router /api/admin/edition/{id} [post]
+func (c *EditionController) UpdateEdition(ctx echo.Context) error {
+	req := new(models.EditionRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	edition, err := c.service.UpdateEdition(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Edition updated successfully", edition)
+}
+
+// @Summary Open Edition
+// @Description To open festival edition to the audience, or reopen a closed one
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Success 200 {object} utils.JsonResponse "Success open edition"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Failure 409 {object} utils.JsonResponse "Edition already open"
+// @Router /api/admin/edition/{id}/open [post]
+func (c *EditionController) OpenEdition(ctx echo.Context) error {
+	if err := c.service.OpenEdition(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Edition opened successfully", nil)
+}
+
+// @Summary Close Edition
+// @Description To close open festival edition, its entries can no longer change
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Success 200 {object} utils.JsonResponse "Success close edition"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Failure 409 {object} utils.JsonResponse "Edition not open"
+// @Router /api/admin/edition/{id}/close [post]
+func (c *EditionController) CloseEdition(ctx echo.Context) error {
+	if err := c.service.CloseEdition(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Edition closed successfully", nil)
+}
+
+// @Summary Enter Movies
+// @Description To enter movies into festival edition that is not closed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Param request body models.EditionMoviesRequest true "Edition Movies Request"
+// @Success 200 {object} utils.JsonResponse "Success enter movies"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Edition or movie not found"
+// @Failure 409 {object} utils.JsonResponse "Edition closed"
+// @Router /api/admin/edition/{id}/movies [post]
+func (c *EditionController) EnterMovies(ctx echo.Context) error {
+	req := new(models.EditionMoviesRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.EnterMovies(ctx.Request().Context(), ctx.Param("id"), req.MovieIDs); err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movies entered successfully", nil)
+}
+
+// @Summary Withdraw Movie
+// @Description To withdraw movie from festival edition that is not closed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Param movie_id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success withdraw movie"
+// @Failure 404 {object} utils.JsonResponse "Edition not found or movie not entered"
+// @Failure 409 {object} utils.JsonResponse "Edition closed"
+// @Router /api/admin/edition/{id}/movies/{movie_id} [delete]
+func (c *EditionController) WithdrawMovie(ctx echo.Context) error {
+	if err := c.service.WithdrawMovie(ctx.Request().Context(), ctx.Param("id"), ctx.Param("movie_id")); err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie withdrawn successfully", nil)
+}
+
+// @Summary List Festival Editions
+// @Description To list the open and closed festival editions, the latest first
+// @Tags User
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.JsonResponse "Success list editions"
+// @Router /api/editions [get]
+func (c *EditionController) ListPublishedEditions(ctx echo.Context) error {
+	editions, err := c.service.ListPublishedEditions(ctx.Request().Context())
+	if err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", editions)
+}
+
+// @Summary Festival Edition Detail
+// @Description To get open or closed festival edition with the movies entered into it
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path string true "id of the edition"
+// @Success 200 {object} utils.JsonResponse "Success get edition"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Router /api/editions/{id} [get]
+func (c *EditionController) GetPublishedEdition(ctx echo.Context) error {
+	edition, err := c.service.GetPublishedEdition(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return editionFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", edition)
+}
+
+func editionFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "edition is not exists")
+	case errors.Is(err, services.ErrEditionMovieNotExists), errors.Is(err, services.ErrMovieNotEntered):
+		return utils.FailResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrEditionExists),
+		errors.Is(err, services.ErrEditionAlreadyOpen),
+		errors.Is(err, services.ErrEditionNotOpen),
+		errors.Is(err, services.ErrEditionClosed):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidEditionDate),
+		errors.Is(err, services.ErrInvalidEditionDates),
+		errors.Is(err, services.ErrInvalidEditionStatus):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GenreController struct {
+	service services.GenreService
+}
+
+func NewGenreController(service services.GenreService) *GenreController {
+	return &GenreController{service}
+}
+
+// @Summary List Genres
+// @Description To list all genres with their parent genre
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success list genres"
+// @Router /api/admin/genres [get]
+func (c *GenreController) ListGenres(ctx echo.Context) error {
+	genres, err := c.service.ListGenres(ctx.Request().Context())
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", genres)
+}
+
+// @Summary Create Genre
+// @Description To create genre, optionally as subgenre of a top-level genre
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.GenreRequest true "Genre Request"
+// @Success 201 {object} utils.JsonResponse "Success create genre"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 409 {object} utils.JsonResponse "Genre already exists"
+// @Router /api/admin/genre [post]
+func (c *GenreController) CreateGenre(ctx echo.Context) error {
+	req := new(models.GenreRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	genre, err := c.service.CreateGenre(ctx.Request().Context(), *req)
+	if err != nil {
+		return genreFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Genre created successfully", genre)
+}
+
+// @Summary Update Genre
+// @Description To rename genre or change its parent genre
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "id of the genre"
+// @Param request body models.GenreRequest true "Genre Request"
+// @Success 200 {object} utils.JsonResponse "Success update genre"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Genre not found"
+// @Router /api/admin/genre/{id} [post]
+func (c *GenreController) UpdateGenre(ctx echo.Context) error {
+	genreID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || genreID <= 0 {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid genre ID")
+	}
+
+	req := new(models.GenreRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	genre, err := c.service.UpdateGenre(ctx.Request().Context(), genreID, *req)
+	if err != nil {
+		return genreFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Genre updated successfully", genre)
+}
+
+// @Summary Delete Genre
+// @Description To delete genre and unlink it from its movies
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "id of the genre"
+// @Success 200 {object} utils.JsonResponse "Success delete genre"
+// @Failure 404 {object} utils.JsonResponse "Genre not found"
+// @Router /api/admin/genre/{id} [delete]
+func (c *GenreController) DeleteGenre(ctx echo.Context) error {
+	genreID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || genreID <= 0 {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid genre ID")
+	}
+
+	if err := c.service.DeleteGenre(ctx.Request().Context(), genreID); err != nil {
+		return genreFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Genre deleted successfully", nil)
+}
+
+// @Summary Merge Genres
+// @Description To merge duplicated genres into the genre with the given id
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "id of the genre to keep"
+// @Param request body models.MergeGenresRequest true "Merge Genres Request"
+// @Success 200 {object} utils.JsonResponse "Success merge genres"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Genre not found"
+// @Router /api/admin/genre/{id}/merge [post]
+func (c *GenreController) MergeGenres(ctx echo.Context) error {
+	genreID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || genreID <= 0 {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid genre ID")
+	}
+
+	req := new(models.MergeGenresRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.MergeGenres(ctx.Request().Context(), genreID, req.SourceIDs); err != nil {
+		return genreFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Genres merged successfully", nil)
+}
+
+func genreFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "genre is not exists")
+	case errors.Is(err, services.ErrGenreExists):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrGenreMergeSelf),
+		errors.Is(err, services.ErrGenreParent),
+		errors.Is(err, services.ErrGenreHasChildren):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
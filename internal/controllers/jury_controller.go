@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JuryController struct {
+	service services.JuryService
+}
+
+func NewJuryController(service services.JuryService) *JuryController {
+	return &JuryController{service}
+}
+
+// @Summary List Jurors
+// @Description To list the jurors of competition section
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Success 200 {object} utils.JsonResponse "Success list jurors"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Router /api/admin/section/{id}/jurors [get]
+func (c *JuryController) ListJurors(ctx echo.Context) error {
+	jurors, err := c.service.ListJurors(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", jurors)
+}
+
+// @Summary Assign Juror
+// @Description To assign user whose role grants jury:score to the jury of competition section
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param request body models.JurorRequest true "Juror Request"
+// @Success 200 {object} utils.JsonResponse "Success assign juror"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or user not in the jury role"
+// @Failure 404 {object} utils.JsonResponse "Section or user not found"
+// @Router /api/admin/section/{id}/jurors [post]
+func (c *JuryController) AssignJuror(ctx echo.Context) error {
+	req := new(models.JurorRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.AssignJuror(ctx.Request().Context(), ctx.Param("id"), req.UserID); err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Juror assigned successfully", nil)
+}
+
+// @Summary Remove Juror
+// @Description To remove juror from competition section, the scores they gave in the section are removed too
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param user_id path string true "id of the juror"
+// @Success 200 {object} utils.JsonResponse "Success remove juror"
+// @Failure 404 {object} utils.JsonResponse "Section not found or user not a juror"
+// @Router /api/admin/section/{id}/jurors/{user_id} [delete]
+func (c *JuryController) RemoveJuror(ctx echo.Context) error {
+	if err := c.service.RemoveJuror(ctx.Request().Context(), ctx.Param("id"), ctx.Param("user_id")); err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Juror removed successfully", nil)
+}
+
+// @Summary List Scoring Criteria
+// @Description To list the scoring criteria of competition section
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Success 200 {object} utils.JsonResponse "Success list criteria"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Router /api/admin/section/{id}/criteria [get]
+func (c *JuryController) ListCriteria(ctx echo.Context) error {
+	criteria, err := c.service.ListCriteria(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", criteria)
+}
+
+// @Summary Create Scoring Criterion
+// @Description To add weighted scoring criterion to competition section whose scoring is open
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param request body models.ScoringCriterionRequest true "Scoring Criterion Request"
+// @Success 201 {object} utils.JsonResponse "Success create criterion"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Failure 409 {object} utils.JsonResponse "Criterion already exists or scoring closed"
+// @Router /api/admin/section/{id}/criteria [post]
+func (c *JuryController) CreateCriterion(ctx echo.Context) error {
+	req := new(models.ScoringCriterionRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	criterion, err := c.service.CreateCriterion(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Criterion created successfully", criterion)
+}
+
+// @Summary Delete Scoring Criterion
+// @Description To delete scoring criterion of competition section whose scoring is open, with the scores given on it
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the criterion"
+// @Success 200 {object} utils.JsonResponse "Success delete criterion"
+// @Failure 404 {object} utils.JsonResponse "Criterion not found"
+// @Failure 409 {object} utils.JsonResponse "Scoring closed"
+// @Router /api/admin/criterion/{id} [delete]
+func (c *JuryController) DeleteCriterion(ctx echo.Context) error {
+	if err := c.service.DeleteCriterion(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Criterion deleted successfully", nil)
+}
+
+// @Summary Open Scoring
+// @Description To reopen the scoring of competition section, the jurors no longer see each other's scores
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Success 200 {object} utils.JsonResponse "Success open scoring"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Failure 409 {object} utils.JsonResponse "Scoring already open"
+// @Router /api/admin/section/{id}/scoring/open [post]
+func (c *JuryController) OpenScoring(ctx echo.Context) error {
+	if err := c.service.OpenScoring(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Scoring opened successfully", nil)
+}
+
+// @Summary Close Scoring
+// @Description To close the scoring of competition section, the jurors can then see each other's scores
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Success 200 {object} utils.JsonResponse "Success close scoring"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Failure 409 {object} utils.JsonResponse "Scoring already closed"
+// @Router /api/admin/section/{id}/scoring/close [post]
+func (c *JuryController) CloseScoring(ctx echo.Context) error {
+	if err := c.service.CloseScoring(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Scoring closed successfully", nil)
+}
+
+// @Summary Jury Ranking
+// @Description To rank the nominees of competition section by the aggregation of their jury scores
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param aggregation query string false "mean (default), trimmed_mean or median"
+// @Success 200 {object} utils.JsonResponse "Success get ranking"
+// @Failure 400 {object} utils.JsonResponse "Invalid aggregation"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Router /api/admin/section/{id}/ranking [get]
+func (c *JuryController) GetRanking(ctx echo.Context) error {
+	ranking, err := c.service.GetRanking(ctx.Request().Context(), ctx.Param("id"), ctx.QueryParam("aggregation"))
+	if err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", ranking)
+}
+
+// @Summary Jury Sections
+// @Description To list the competition sections the user is a juror of
+// @Tags Jury
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success list sections"
+// @Router /api/jury/sections [get]
+func (c *JuryController) ListJurorSections(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	sections, err := c.service.ListJurorSections(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", sections)
+}
+
+// @Summary Jury Sheet
+// @Description To get competition section with its nominees, criteria and the scores of the juror, every score once scoring is closed
+// @Tags Jury
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Success 200 {object} utils.JsonResponse "Success get jury sheet"
+// @Failure 403 {object} utils.JsonResponse "User not a juror of the section"
+// @Failure 404 {object} utils.JsonResponse "Section not found"
+// @Router /api/jury/section/{id} [get]
+func (c *JuryController) GetJurySheet(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	sheet, err := c.service.GetJurySheet(ctx.Request().Context(), claims.UserID, ctx.Param("id"))
+	if err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", sheet)
+}
+
+// @Summary Submit Jury Scores
+// @Description To score nominee of competition section on its criteria, while scoring is open
+// @Tags Jury
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the section"
+// @Param request body models.JuryScoresRequest true "Jury Scores Request"
+// @Success 200 {object} utils.JsonResponse "Success submit scores"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or unknown criterion"
+// @Failure 403 {object} utils.JsonResponse "User not a juror of the section"
+// @Failure 404 {object} utils.JsonResponse "Section not found or movie not nominated"
+// @Failure 409 {object} utils.JsonResponse "Scoring closed"
+// @Router /api/jury/section/{id}/scores [post]
+func (c *JuryController) SubmitScores(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.JuryScoresRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.SubmitScores(ctx.Request().Context(), claims.UserID, ctx.Param("id"), *req); err != nil {
+		return juryFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Scores submitted successfully", nil)
+}
+
+func juryFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrSectionNotExists),
+		errors.Is(err, services.ErrJurorNotExists),
+		errors.Is(err, services.ErrCriterionNotExists),
+		errors.Is(err, services.ErrJurorNotAssigned),
+		errors.Is(err, services.ErrMovieNotNominated):
+		return utils.FailResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrNotSectionJuror):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrCriterionExists),
+		errors.Is(err, services.ErrScoringClosed),
+		errors.Is(err, services.ErrScoringAlreadyOpen):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrNotJuryMember),
+		errors.Is(err, services.ErrUnknownCriterion),
+		errors.Is(err, services.ErrInvalidJuryAggregation):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
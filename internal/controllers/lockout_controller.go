@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LockoutController struct {
+	service services.LockoutService
+}
+
+func NewLockoutController(service services.LockoutService) *LockoutController {
+	return &LockoutController{service}
+}
+
+// @Summary Unlock Login
+// @Description To lift the login lockout and clear the failed logins of a username and/or a client address
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UnlockLoginRequest true "Unlock Login Request"
+// @Success 200 {object} utils.JsonResponse "Success unlock login"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Router /api/admin/lockouts/unlock [post]
+func (c *LockoutController) Unlock(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.UnlockLoginRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.Unlock(ctx.Request().Context(), claims.UserID, *req); err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Login unlocked successfully", nil)
+}
+
+// @Summary List Lockout Events
+// @Description To list login lockouts and their unlocks, newest first
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param username query string false "Only the events of this username"
+// @Param ip_address query string false "Only the events of this client address"
+// @Param limit query int false "Limit number for pagination"
+// @Param offset query int false "Offset of items per page"
+// @Success 200 {object} utils.JsonResponse "Success list lockout events"
+// @Router /api/admin/lockouts [get]
+func (c *LockoutController) ListEvents(ctx echo.Context) error {
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0 // default offset
+	}
+
+	var scope, subject string
+	if username := ctx.QueryParam("username"); username != "" {
+		scope, subject = models.LockoutScopeUser, username
+	} else if ipAddress := ctx.QueryParam("ip_address"); ipAddress != "" {
+		scope, subject = models.LockoutScopeIP, ipAddress
+	}
+
+	events, err := c.service.ListEvents(ctx.Request().Context(), scope, subject, limit, offset)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", events)
+}
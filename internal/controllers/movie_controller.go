@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/stwrtrio/movie-festival/internal/middlewares"
 	"github.com/stwrtrio/movie-festival/internal/models"
@@ -52,10 +54,7 @@ func (c *MovieController) CreateMovie(ctx echo.Context) error {
 	}
 
 	// Convert request data to a Artist model
-	artists := make([]models.Artist, 0)
-	for _, artistName := range req.Artists {
-		artists = append(artists, models.Artist{Name: string(artistName)})
-	}
+	artists := toArtistCredits(req)
 
 	movie := &models.Movie{
 		Title:       req.Title,
@@ -67,6 +66,9 @@ func (c *MovieController) CreateMovie(ctx echo.Context) error {
 	}
 
 	if err := c.service.CreateMovie(cx, movie); err != nil {
+		if errors.Is(err, services.ErrInvalidArtistRole) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
 		if err == errors.New("service CreateMovie err: movie doesn't have artist") {
 			return utils.SuccessResponse(ctx, http.StatusCreated, "Failed to create movie: movie doesn't have artist", nil)
 		}
@@ -105,11 +107,8 @@ func (c *MovieController) UpdateMovie(ctx echo.Context) error {
 		genres = append(genres, models.Genre{Name: string(genreName)})
 	}
 
-	// Convert request data to a Movie model
-	artists := make([]models.Artist, 0)
-	for _, artistName := range req.Artists {
-		artists = append(artists, models.Artist{Name: string(artistName)})
-	}
+	// Convert request data to a Artist model
+	artists := toArtistCredits(req)
 
 	movie := &models.Movie{
 		ID:          movieID,
@@ -126,6 +125,9 @@ func (c *MovieController) UpdateMovie(ctx echo.Context) error {
 		if err == sql.ErrNoRows {
 			return utils.FailResponse(ctx, http.StatusBadRequest, "movie is not exists")
 		}
+		if errors.Is(err, services.ErrInvalidArtistRole) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
 
 		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
 	}
@@ -133,20 +135,144 @@ func (c *MovieController) UpdateMovie(ctx echo.Context) error {
 	return utils.SuccessResponse(ctx, http.StatusOK, "Movie updated successfully", nil)
 }
 
+// toArtistCredits converts the plain artist names and the role credits of a request into artist models.
+// Plain names are credited as actors, billed in the order they were given.
+func toArtistCredits(req *models.CreateMovieRequest) []models.Artist {
+	artists := make([]models.Artist, 0, len(req.Artists)+len(req.Credits))
+	for i, artistName := range req.Artists {
+		artists = append(artists, models.Artist{
+			Name:         artistName,
+			Role:         models.ArtistRoleActor,
+			BillingOrder: i + 1,
+		})
+	}
+
+	for _, credit := range req.Credits {
+		artists = append(artists, models.Artist{
+			Name:         credit.Name,
+			Role:         credit.Role,
+			BillingOrder: credit.BillingOrder,
+		})
+	}
+
+	return artists
+}
+
+// @Summary Delete Movie
+// @Description To soft-delete movie, hiding it from listings and statistics
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success delete movie"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/admin/movie/{id} [delete]
+func (c *MovieController) DeleteMovie(ctx echo.Context) error {
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	err := c.service.DeleteMovie(ctx.Request().Context(), movieID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie deleted successfully", nil)
+}
+
+// @Summary Restore Movie
+// @Description To restore soft-deleted movie
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success restore movie"
+// @Failure 404 {object} utils.JsonResponse "Deleted movie not found"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/admin/movie/{id}/restore [post]
+func (c *MovieController) RestoreMovie(ctx echo.Context) error {
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	err := c.service.RestoreMovie(ctx.Request().Context(), movieID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.FailResponse(ctx, http.StatusNotFound, "deleted movie is not exists")
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie restored successfully", nil)
+}
+
+// @Summary Purge Movie
+// @Description To permanently remove soft-deleted movie with its genres, artists, views and votes
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success purge movie"
+// @Failure 404 {object} utils.JsonResponse "Deleted movie not found"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/admin/movie/{id}/purge [delete]
+func (c *MovieController) PurgeMovie(ctx echo.Context) error {
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	err := c.service.PurgeMovie(ctx.Request().Context(), movieID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.FailResponse(ctx, http.StatusNotFound, "deleted movie is not exists")
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie purged successfully", nil)
+}
+
 // @Summary Get Most Viewed Movie
 // @Description To get most viewd movie
 // @Tags Admin
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param window query string false "Only count views of the last window, e.g. 24h or 7d"
+// @Param from query string false "Only count views from this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only count views before this time (RFC3339 or YYYY-MM-DD)"
+// @Param edition query string false "Only count the movies entered into this festival edition"
 // @Success 200 {object} utils.JsonResponse "Success get most viewd movie"
 // @Failure 400 {object} utils.JsonResponse "Invalid input"
 // @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Failure 404 {object} utils.JsonResponse "No viewed movie"
 // @Router /api/admin/most-viewed [get]
 func (c *MovieController) GetMostViewedMovie(ctx echo.Context) error {
 	cx := ctx.Request().Context()
-	movie, err := c.service.GetMostViewedMovie(cx)
+	filter, err := parseStatsFilter(ctx)
 	if err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+	filter.EditionID = ctx.QueryParam("edition")
+
+	movie, err := c.service.GetMostViewedMovie(cx, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTimeWindow) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "no movie has been viewed")
+		}
 		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
 	}
 
@@ -163,6 +289,11 @@ func (c *MovieController) GetMostViewedMovie(ctx echo.Context) error {
 // @Param page query int false "Page number for pagination"
 // @Param page_size query int false "Number of items per page"
 // @Param sort_order query string false "Sort order (ASC or DESC), default is DESC"
+// @Param rollup query bool false "Count views of subgenres towards their parent genre"
+// @Param window query string false "Only count views of the last window, e.g. 24h or 7d"
+// @Param from query string false "Only count views from this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only count views before this time (RFC3339 or YYYY-MM-DD)"
+// @Param edition query string false "Only count the movies entered into this festival edition"
 // @Success 200 {object} utils.JsonResponse "Success get most viewd movie"
 // @Failure 400 {object} utils.JsonResponse "Invalid input"
 // @Failure 401 {object} utils.JsonResponse "Unauthorized"
@@ -195,9 +326,21 @@ func (c *MovieController) GetMostViewedGenre(ctx echo.Context) error {
 		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid page size")
 	}
 
+	filter, err := parseStatsFilter(ctx)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	// Roll up subgenres into their parent genre when requested
+	filter.RollupGenres, _ = strconv.ParseBool(ctx.QueryParam("rollup"))
+	filter.EditionID = ctx.QueryParam("edition")
+
 	// Call the service to get the most viewed genres
-	genreViews, err := c.service.GetMostViewedGenre(ctx.Request().Context(), page, pageSize, sortOrder)
+	genreViews, err := c.service.GetMostViewedGenre(ctx.Request().Context(), page, pageSize, sortOrder, filter)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidTimeWindow) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
 		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
 	}
 
@@ -205,6 +348,130 @@ func (c *MovieController) GetMostViewedGenre(ctx echo.Context) error {
 	return utils.SuccessResponse(ctx, http.StatusOK, "", genreViews)
 }
 
+// @Summary Get Movie View Statistics
+// @Description To get hourly or daily views, unique viewers and watch time of a movie
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Param granularity query string false "Bucket size (hour or day), default is day"
+// @Param window query string false "Only count views of the last window, e.g. 24h or 7d"
+// @Param from query string false "Only count views from this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only count views before this time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} utils.JsonResponse "Success get movie view statistics"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/admin/movies/{id}/views [get]
+func (c *MovieController) GetMovieViewStats(ctx echo.Context) error {
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	filter, err := parseStatsFilter(ctx)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	stats, err := c.service.GetMovieViewStats(ctx.Request().Context(), movieID, ctx.QueryParam("granularity"), filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidGranularity) || errors.Is(err, services.ErrInvalidTimeWindow) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", stats)
+}
+
+// @Summary Get Suspected View Inflation
+// @Description To list client addresses with an unusual number of views of a single movie, over the last 24 hours by default
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param min_views query int false "Minimum views of one movie from one address, default is 20"
+// @Param window query string false "Only count views of the last window, e.g. 24h or 7d"
+// @Param from query string false "Only count views from this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only count views before this time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} utils.JsonResponse "Success get suspected view inflation"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/admin/movies/suspicious-views [get]
+func (c *MovieController) GetSuspectedViewInflation(ctx echo.Context) error {
+	minViews, err := strconv.Atoi(ctx.QueryParam("min_views"))
+	if err != nil || minViews <= 0 {
+		minViews = 20 // default threshold
+	}
+
+	filter, err := parseStatsFilter(ctx)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	reports, err := c.service.GetSuspectedViewInflation(ctx.Request().Context(), filter, minViews)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTimeWindow) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", reports)
+}
+
+// parseStatsFilter reads the time window of view statistics from the window, from and to query parameters
+func parseStatsFilter(ctx echo.Context) (models.StatsFilter, error) {
+	var filter models.StatsFilter
+
+	if from := ctx.QueryParam("from"); from != "" {
+		t, err := parseTimeParam(from)
+		if err != nil {
+			return filter, errors.New("invalid from, use RFC3339 or YYYY-MM-DD")
+		}
+		filter.From = &t
+	}
+
+	if to := ctx.QueryParam("to"); to != "" {
+		t, err := parseTimeParam(to)
+		if err != nil {
+			return filter, errors.New("invalid to, use RFC3339 or YYYY-MM-DD")
+		}
+		filter.To = &t
+	}
+
+	if window := ctx.QueryParam("window"); window != "" && filter.From == nil {
+		duration, err := parseWindow(window)
+		if err != nil || duration <= 0 {
+			return filter, errors.New("invalid window, use a duration such as 24h or 7d")
+		}
+		from := time.Now().Add(-duration)
+		filter.From = &from
+	}
+
+	return filter, nil
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// parseWindow parses a Go duration, with d accepted as a number of days (e.g. 7d)
+func parseWindow(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
 // @Summary Get All Movie
 // @Description To get all movie
 // @Tags User
@@ -264,6 +531,8 @@ func (c *MovieController) GetAllMovies(ctx echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param query query string false "Keyword to search movie"
+// @Param artist query string false "Artist name to search movie"
+// @Param role query string false "Credit role of the artist (director, actor, writer, composer)"
 // @Param limit query int false "Limit number for pagination"
 // @Param offset query int false "Offset of items per page"
 // @Success 200 {object} utils.JsonResponse "Success search movie"
@@ -280,8 +549,17 @@ func (c *MovieController) SearchMovies(ctx echo.Context) error {
 		offset = 0 // default offset
 	}
 
-	movies, err := c.service.SearchMovies(ctx.Request().Context(), query, limit, offset)
+	filter := models.MovieSearchFilter{
+		Query:  query,
+		Artist: ctx.QueryParam("artist"),
+		Role:   ctx.QueryParam("role"),
+	}
+
+	movies, err := c.service.SearchMovies(ctx.Request().Context(), filter, limit, offset)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidArtistRole) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
 		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
 	}
 
@@ -292,97 +570,82 @@ func (c *MovieController) SearchMovies(ctx echo.Context) error {
 	return utils.SuccessResponse(ctx, http.StatusOK, "", movies)
 }
 
-// @Summary Track View Movie
-// @Description To track view movie
+// @Summary Get Movie Detail
+// @Description To get movie detail with genres, artists, views and votes
 // @Tags User
 // @Accept json
 // @Produce json
-// @Param id query string true "id of the movie"
-// @Success 200 {object} utils.JsonResponse "Success track movie"
-// @Failure 400 {object} utils.JsonResponse "Invalid input"
-// @Router /api/movies/{id}/view [post]
-func (c *MovieController) TrackMovieView(ctx echo.Context) error {
-	movieID := ctx.Param("id")
-	err := c.service.TrackMovieView(ctx.Request().Context(), movieID)
-	if err != nil {
-		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
-	}
-
-	return utils.SuccessResponse(ctx, http.StatusOK, "Viewership tracked successfully", nil)
-}
-
-// @Summary Vote Movie
-// @Description To vote the movie
-// @Tags User
-// @Accept json
-// @Produce json
-// @Param id query string true "id of the movie"
-// @Success 200 {object} utils.JsonResponse "Success vote movie"
-// @Failure 400 {object} utils.JsonResponse "Invalid input"
-// @Router /api/user/movies/{id}/vote [post]
-func (c *MovieController) VoteMovie(ctx echo.Context) error {
-	cx := ctx.Request().Context()
-	// Get user claims from context
-	claims, ok := middlewares.GetUserFromContext(ctx)
-	if !ok {
-		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
-	}
-	userID := claims.UserID
-
-	// Get movie ID from the URL
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success get movie detail"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Router /api/movies/{id} [get]
+func (c *MovieController) GetMovieDetail(ctx echo.Context) error {
 	movieID := ctx.Param("id")
 	if movieID == "" {
 		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
 	}
 
-	// Call the service to vote for the movie
-	err := c.service.VoteMovie(cx, userID, movieID)
+	movie, err := c.service.GetMovieDetail(ctx.Request().Context(), movieID)
 	if err != nil {
-		if err == errors.New("you have already voted for this movie") {
-			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		if err == sql.ErrNoRows {
+			return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
 		}
-		return utils.FailResponse(ctx, http.StatusInternalServerError, "Failed to vote for movie")
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
 	}
 
-	// Success response
-	return utils.SuccessResponse(ctx, http.StatusOK, "Movie voted successfully", nil)
+	return utils.SuccessResponse(ctx, http.StatusOK, "", movie)
 }
 
-// @Summary Unvote Movie
-// @Description To unvote the movie
+// @Summary Track View Movie
+// @Description To track view movie, attributed to the logged in user when a token is sent
 // @Tags User
 // @Accept json
 // @Produce json
-// @Param id query string true "id of the movie"
-// @Success 200 {object} utils.JsonResponse "Success unvote movie"
+// @Param id path string true "id of the movie"
+// @Param request body models.TrackViewRequest false "Track View Request"
+// @Success 200 {object} utils.JsonResponse "Success track movie"
 // @Failure 400 {object} utils.JsonResponse "Invalid input"
-// @Router /api/user/movies/{id}/unvote [post]
-func (c *MovieController) UnvoteMovie(ctx echo.Context) error {
-	cx := ctx.Request().Context()
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Failure 429 {object} utils.JsonResponse "Too many requests"
+// @Router /api/movies/{id}/view [post]
+func (c *MovieController) TrackMovieView(ctx echo.Context) error {
+	movieID := ctx.Param("id")
 
-	// Get user claims from context
-	claims, ok := middlewares.GetUserFromContext(ctx)
-	if !ok {
-		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	req := new(models.TrackViewRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
 	}
-	userID := claims.UserID
 
-	// Extract the movie ID from the request parameters
-	movieID := ctx.Param("id")
-	if movieID == "" {
-		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	view := models.ViewEvent{
+		MovieID:       movieID,
+		SessionID:     req.SessionID,
+		WatchDuration: req.WatchDuration,
+		IPAddress:     ctx.RealIP(),
+	}
+	if view.SessionID == "" {
+		view.SessionID = ctx.Request().Header.Get("X-Session-ID")
+	}
+
+	// The endpoint is public, a view is attributed to the user only when a valid token was sent
+	if claims, ok := middlewares.GetUserFromContext(ctx); ok {
+		view.UserID = claims.UserID
 	}
 
-	// Call the service to unvote the movie
-	err := c.service.UnvoteMovie(cx, userID, movieID)
+	err := c.service.TrackMovieView(ctx.Request().Context(), view)
 	if err != nil {
-		if err == errors.New("you haven't voted for this movie yet") {
-			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		if errors.Is(err, services.ErrViewAlreadyCounted) {
+			return utils.SuccessResponse(ctx, http.StatusOK, "View already counted", nil)
 		}
-		return utils.FailResponse(ctx, http.StatusInternalServerError, "Failed to unvote for movie")
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
 	}
 
-	return utils.SuccessResponse(ctx, http.StatusOK, "Movie unvoted successfully", nil)
+	return utils.SuccessResponse(ctx, http.StatusOK, "Viewership tracked successfully", nil)
 }
 
 // @Summary Get User Vote
@@ -420,14 +683,30 @@ func (c *MovieController) GetUserVotesController(ctx echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param window query string false "Only count votes of the last window, e.g. 24h or 7d"
+// @Param from query string false "Only count votes from this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only count votes before this time (RFC3339 or YYYY-MM-DD)"
+// @Param edition query string false "Only count the movies entered into this festival edition"
 // @Success 200 {object} utils.JsonResponse "Success vote movie"
 // @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "No voted movie"
 // @Router /api/admin/movies/most-voted [get]
 func (c *MovieController) GetMostVotedMovie(ctx echo.Context) error {
 	cx := ctx.Request().Context()
+	filter, err := parseStatsFilter(ctx)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+	filter.EditionID = ctx.QueryParam("edition")
 
-	votedMovies, err := c.service.GetMostVotedMovie(cx)
+	votedMovies, err := c.service.GetMostVotedMovie(cx, filter)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidTimeWindow) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "no movie has been voted for")
+		}
 		return utils.FailResponse(ctx, http.StatusInternalServerError, "Failed to fetch most voted movies")
 	}
 
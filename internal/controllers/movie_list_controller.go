@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MovieListController struct {
+	service services.MovieListService
+}
+
+func NewMovieListController(service services.MovieListService) *MovieListController {
+	return &MovieListController{service}
+}
+
+// @Summary Get Movie List
+// @Description To get the watchlist or favorites of the user with the movie details
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "watchlist or favorites"
+// @Success 200 {object} utils.JsonResponse "Success get movie list"
+// @Failure 400 {object} utils.JsonResponse "Invalid list"
+// @Router /api/user/lists/{list} [get]
+func (c *MovieListController) GetList(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	items, err := c.service.GetList(ctx.Request().Context(), claims.UserID, ctx.Param("list"))
+	if err != nil {
+		return movieListFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", items)
+}
+
+// @Summary Add Movie To List
+// @Description To add a movie at the end of the watchlist or favorites
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "watchlist or favorites"
+// @Param request body models.AddMovieListItemRequest true "Add Movie Request"
+// @Success 201 {object} utils.JsonResponse "Success add movie"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Failure 409 {object} utils.JsonResponse "Movie already in list"
+// @Router /api/user/lists/{list} [post]
+func (c *MovieListController) AddMovie(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.AddMovieListItemRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.AddMovie(ctx.Request().Context(), claims.UserID, ctx.Param("list"), req.MovieID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
+		}
+		return movieListFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Movie added to the list", nil)
+}
+
+// @Summary Remove Movie From List
+// @Description To remove a movie from the watchlist or favorites
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "watchlist or favorites"
+// @Param movie_id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success remove movie"
+// @Failure 404 {object} utils.JsonResponse "Movie not in list"
+// @Router /api/user/lists/{list}/{movie_id} [delete]
+func (c *MovieListController) RemoveMovie(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.RemoveMovie(ctx.Request().Context(), claims.UserID, ctx.Param("list"), ctx.Param("movie_id")); err != nil {
+		return movieListFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie removed from the list", nil)
+}
+
+// @Summary Reorder Movie List
+// @Description To change the order of the watchlist or favorites
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "watchlist or favorites"
+// @Param request body models.ReorderMovieListRequest true "Reorder Request"
+// @Success 200 {object} utils.JsonResponse "Success reorder list"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Router /api/user/lists/{list}/order [post]
+func (c *MovieListController) Reorder(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.ReorderMovieListRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.Reorder(ctx.Request().Context(), claims.UserID, ctx.Param("list"), req.MovieIDs); err != nil {
+		return movieListFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "List reordered successfully", nil)
+}
+
+// @Summary Mark Movie Watched
+// @Description To mark a movie of the watchlist or favorites as watched or not watched
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "watchlist or favorites"
+// @Param movie_id path string true "id of the movie"
+// @Param request body models.MarkWatchedRequest true "Mark Watched Request"
+// @Success 200 {object} utils.JsonResponse "Success mark movie watched"
+// @Failure 404 {object} utils.JsonResponse "Movie not in list"
+// @Router /api/user/lists/{list}/{movie_id}/watched [post]
+func (c *MovieListController) MarkWatched(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.MarkWatchedRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	err := c.service.MarkWatched(ctx.Request().Context(), claims.UserID, ctx.Param("list"), ctx.Param("movie_id"), req.Watched)
+	if err != nil {
+		return movieListFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie updated successfully", nil)
+}
+
+func movieListFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "movie is not in the list")
+	case errors.Is(err, services.ErrMovieAlreadyInList):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidMovieList), errors.Is(err, services.ErrInvalidMovieListOrder):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
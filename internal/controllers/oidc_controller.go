@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// oidcStateCookie keeps the state in the browser that started the flow until the callback
+const oidcStateCookie = "oidc_state"
+
+type OIDCController struct {
+	service services.OIDCService
+}
+
+func NewOIDCController(service services.OIDCService) *OIDCController {
+	return &OIDCController{service}
+}
+
+// ListProviders godoc
+// @Summary List OIDC Providers
+// @Description List the OpenID Connect providers users can log in with
+// @Tags User
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.JsonResponse "Provider names"
+// @Router /api/user/oidc/providers [get]
+func (c *OIDCController) ListProviders(ctx echo.Context) error {
+	return utils.SuccessResponse(ctx, http.StatusOK, "", c.service.Providers())
+}
+
+// Authorize godoc
+// @Summary Start OIDC Login
+// @Description Start a login with an OpenID Connect provider, send the user to the authorization URL in the same browser, it keeps the state in a cookie. With a bearer token the provider account is linked to the logged in user instead
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param provider path string true "name of the provider"
+// @Success 200 {object} utils.JsonResponse "Authorization URL and state"
+// @Failure 404 {object} utils.JsonResponse "Unknown provider"
+// @Router /api/user/oidc/{provider}/authorize [get]
+func (c *OIDCController) Authorize(ctx echo.Context) error {
+	var linkUserID string
+	if claims, ok := middlewares.GetUserFromContext(ctx); ok {
+		linkUserID = claims.UserID
+	}
+
+	authorization, err := c.service.Authorize(ctx.Request().Context(), ctx.Param("provider"), linkUserID)
+	if err != nil {
+		return oidcFailResponse(ctx, err)
+	}
+
+	ctx.SetCookie(oidcCookie(ctx, authorization.State, 0))
+	return utils.SuccessResponse(ctx, http.StatusOK, "", authorization)
+}
+
+// Callback godoc
+// @Summary Complete OIDC Login
+// @Description Complete a login with the code and the state the provider redirected back with. Users with two-factor authentication get an MFA token to complete the login at /api/user/login/mfa
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param provider path string true "name of the provider"
+// @Param code query string true "authorization code"
+// @Param state query string true "state of the authorization request"
+// @Success 200 {object} utils.JsonResponse "Access granted, includes JWT token"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 401 {object} utils.JsonResponse "Invalid state, flow started in another browser or failed login at the provider"
+// @Failure 403 {object} utils.JsonResponse "Account disabled or waiting for a password reset"
+// @Failure 409 {object} utils.JsonResponse "Provider account or email already linked to another user"
+// @Router /api/user/oidc/{provider}/callback [get]
+func (c *OIDCController) Callback(ctx echo.Context) error {
+	// The user denied the login, or the provider failed it
+	if providerErr := ctx.QueryParam("error"); providerErr != "" {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, services.ErrOIDCLoginFailed.Error()+": "+providerErr)
+	}
+
+	req := new(models.OIDCCallbackRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	// The state cookie is used once, whatever the outcome
+	var browserState string
+	if cookie, err := ctx.Cookie(oidcStateCookie); err == nil {
+		browserState = cookie.Value
+	}
+	ctx.SetCookie(oidcCookie(ctx, "", -1))
+
+	tokens, err := c.service.Callback(ctx.Request().Context(), ctx.Param("provider"), *req, browserState, sessionDevice(ctx))
+	if err != nil {
+		return oidcFailResponse(ctx, err)
+	}
+
+	if tokens.MFARequired {
+		return utils.SuccessResponse(ctx, http.StatusOK, "MFA code required", tokens)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Access granted", tokens)
+}
+
+// ListIdentities godoc
+// @Summary List Linked Providers
+// @Description List the OpenID Connect provider accounts linked to the user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Linked provider accounts"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/user/identities [get]
+func (c *OIDCController) ListIdentities(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	identities, err := c.service.ListIdentities(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", identities)
+}
+
+// oidcCookie builds the state cookie. It is sent back on the top-level redirect from the provider,
+// so SameSite is Lax rather than Strict.
+func oidcCookie(ctx echo.Context, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     "/api/user/oidc",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   ctx.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func oidcFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrUnknownProvider):
+		return utils.FailResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "user is not exists")
+	case errors.Is(err, services.ErrInvalidOIDCState), errors.Is(err, services.ErrOIDCLoginFailed):
+		return utils.FailResponse(ctx, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrIdentityLinked),
+		errors.Is(err, services.ErrProviderAlreadyLinked),
+		errors.Is(err, services.ErrOIDCEmailExists),
+		errors.Is(err, services.ErrUsernameUnavailable):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrAccountDisabled), errors.Is(err, services.ErrPasswordResetRequired):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+}
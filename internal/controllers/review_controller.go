@@ -0,0 +1,377 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewController struct {
+	service services.ReviewService
+}
+
+func NewReviewController(service services.ReviewService) *ReviewController {
+	return &ReviewController{service}
+}
+
+// @Summary List Movie Reviews
+// @Description To list the reviews of a movie with its average rating and rating histogram
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param id path string true "id of the movie"
+// @Param sort query string false "Order of the reviews (newest or helpful), default is newest"
+// @Param limit query int false "Limit number for pagination"
+// @Param offset query int false "Offset of items per page"
+// @Success 200 {object} utils.JsonResponse "Success list reviews"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Router /api/movies/{id}/reviews [get]
+func (c *ReviewController) ListReviews(ctx echo.Context) error {
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0 // default offset
+	}
+
+	page, err := c.service.ListReviews(ctx.Request().Context(), movieID, ctx.QueryParam("sort"), limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", page)
+}
+
+// @Summary Create Review
+// @Description To rate a movie from 1 to 10 with an optional written review
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Param request body models.ReviewRequest true "Review Request"
+// @Success 201 {object} utils.JsonResponse "Success create review"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Failure 409 {object} utils.JsonResponse "Movie already reviewed"
+// @Router /api/user/movies/{id}/review [post]
+func (c *ReviewController) CreateReview(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	req := new(models.ReviewRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	review, err := c.service.CreateReview(ctx.Request().Context(), claims.UserID, movieID, *req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
+		}
+		return reviewFailResponse(ctx, err)
+	}
+
+	if review.Status == models.ReviewStatusPending {
+		return utils.SuccessResponse(ctx, http.StatusCreated, "Review submitted for moderation", review)
+	}
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Review created successfully", review)
+}
+
+// @Summary Update Review
+// @Description To change the rating and text of your own review
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Param request body models.ReviewRequest true "Review Request"
+// @Success 200 {object} utils.JsonResponse "Success update review"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 403 {object} utils.JsonResponse "Not your review"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/user/review/{id} [post]
+func (c *ReviewController) UpdateReview(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	reviewID := ctx.Param("id")
+	if reviewID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Review ID is required")
+	}
+
+	req := new(models.ReviewRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	review, err := c.service.UpdateReview(ctx.Request().Context(), claims.UserID, reviewID, *req)
+	if err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	if review.Status == models.ReviewStatusPending {
+		return utils.SuccessResponse(ctx, http.StatusOK, "Review submitted for moderation", review)
+	}
+	return utils.SuccessResponse(ctx, http.StatusOK, "Review updated successfully", review)
+}
+
+// @Summary Delete Review
+// @Description To delete your own review
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Success 200 {object} utils.JsonResponse "Success delete review"
+// @Failure 403 {object} utils.JsonResponse "Not your review"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/user/review/{id} [delete]
+func (c *ReviewController) DeleteReview(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	reviewID := ctx.Param("id")
+	if reviewID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Review ID is required")
+	}
+
+	if err := c.service.DeleteReview(ctx.Request().Context(), claims.UserID, reviewID); err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Review deleted successfully", nil)
+}
+
+// @Summary Mark Review Helpful
+// @Description To mark the review of another user as helpful
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Success 200 {object} utils.JsonResponse "Success mark review helpful"
+// @Failure 400 {object} utils.JsonResponse "Own review"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/user/review/{id}/helpful [post]
+func (c *ReviewController) MarkHelpful(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.MarkHelpful(ctx.Request().Context(), claims.UserID, ctx.Param("id")); err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Review marked as helpful", nil)
+}
+
+// @Summary Unmark Review Helpful
+// @Description To withdraw a helpful mark on a review
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Success 200 {object} utils.JsonResponse "Success unmark review helpful"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/user/review/{id}/helpful [delete]
+func (c *ReviewController) UnmarkHelpful(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.UnmarkHelpful(ctx.Request().Context(), claims.UserID, ctx.Param("id")); err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Review helpful mark removed", nil)
+}
+
+// @Summary Report Review
+// @Description To report an inappropriate review, which sends it back to the moderation queue
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Param request body models.ReportReviewRequest true "Report Review Request"
+// @Success 200 {object} utils.JsonResponse "Success report review"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/user/review/{id}/report [post]
+func (c *ReviewController) ReportReview(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.ReportReviewRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.ReportReview(ctx.Request().Context(), claims.UserID, ctx.Param("id"), *req); err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Review reported successfully", nil)
+}
+
+// @Summary List Moderation Queue
+// @Description To list reviews by moderation state, oldest first
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Moderation state (pending, approved or rejected), default is pending"
+// @Param limit query int false "Limit number for pagination"
+// @Param offset query int false "Offset of items per page"
+// @Success 200 {object} utils.JsonResponse "Success list moderation queue"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Router /api/admin/reviews [get]
+func (c *ReviewController) ListModerationQueue(ctx echo.Context) error {
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0 // default offset
+	}
+
+	reviews, err := c.service.ListModerationQueue(ctx.Request().Context(), ctx.QueryParam("status"), limit, offset)
+	if err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", reviews)
+}
+
+// @Summary Approve Review
+// @Description To publish a review and resolve its reports
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Param request body models.ModerationRequest false "Moderation Request"
+// @Success 200 {object} utils.JsonResponse "Success approve review"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/admin/review/{id}/approve [post]
+func (c *ReviewController) ApproveReview(ctx echo.Context) error {
+	return c.moderateReview(ctx, models.ModerationActionApprove, "Review approved successfully")
+}
+
+// @Summary Reject Review
+// @Description To hide a review from the public and resolve its reports
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Param request body models.ModerationRequest false "Moderation Request"
+// @Success 200 {object} utils.JsonResponse "Success reject review"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/admin/review/{id}/reject [post]
+func (c *ReviewController) RejectReview(ctx echo.Context) error {
+	return c.moderateReview(ctx, models.ModerationActionReject, "Review rejected successfully")
+}
+
+func (c *ReviewController) moderateReview(ctx echo.Context, action, message string) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.ModerationRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.ModerateReview(ctx.Request().Context(), claims.UserID, ctx.Param("id"), action, *req); err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, message, nil)
+}
+
+// @Summary List Moderation Actions
+// @Description To list which admin approved or rejected a review and when
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the review"
+// @Success 200 {object} utils.JsonResponse "Success list moderation actions"
+// @Failure 404 {object} utils.JsonResponse "Review not found"
+// @Router /api/admin/review/{id}/actions [get]
+func (c *ReviewController) ListModerationActions(ctx echo.Context) error {
+	actions, err := c.service.ListModerationActions(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return reviewFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", actions)
+}
+
+func reviewFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "review is not exists")
+	case errors.Is(err, services.ErrReviewExists):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrReviewNotOwner):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrReviewOwnHelpful),
+		errors.Is(err, services.ErrReviewOwnReport),
+		errors.Is(err, services.ErrInvalidReviewStatus),
+		errors.Is(err, services.ErrInvalidModerationAction):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RoleController struct {
+	service services.RoleService
+}
+
+func NewRoleController(service services.RoleService) *RoleController {
+	return &RoleController{service}
+}
+
+// @Summary List Roles
+// @Description To list all roles with their permissions
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success list roles"
+// @Router /api/admin/roles [get]
+func (c *RoleController) ListRoles(ctx echo.Context) error {
+	roles, err := c.service.ListRoles(ctx.Request().Context())
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", roles)
+}
+
+// @Summary List Permissions
+// @Description To list the permissions a role can grant
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success list permissions"
+// @Router /api/admin/permissions [get]
+func (c *RoleController) ListPermissions(ctx echo.Context) error {
+	return utils.SuccessResponse(ctx, http.StatusOK, "", models.Permissions)
+}
+
+// @Summary Create Role
+// @Description To create role granting a set of permissions
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.RoleRequest true "Role Request"
+// @Success 201 {object} utils.JsonResponse "Success create role"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 403 {object} utils.JsonResponse "Role grants permissions the admin does not have"
+// @Failure 409 {object} utils.JsonResponse "Role already exists"
+// @Router /api/admin/role [post]
+func (c *RoleController) CreateRole(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.RoleRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	role, err := c.service.CreateRole(ctx.Request().Context(), claims, *req)
+	if err != nil {
+		return roleFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Role created successfully", role)
+}
+
+// @Summary Update Role
+// @Description To replace the description and the permissions of a role
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "name of the role"
+// @Param request body models.RoleRequest true "Role Request, the name must match the path"
+// @Success 200 {object} utils.JsonResponse "Success update role"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 403 {object} utils.JsonResponse "Role grants permissions the admin does not have"
+// @Failure 404 {object} utils.JsonResponse "Role not found"
+// @Router /api/admin/role/{name} [post]
+func (c *RoleController) UpdateRole(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	name := ctx.Param("name")
+
+	req := new(models.RoleRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	// Roles are referenced by name, they cannot be renamed
+	if req.Name == "" {
+		req.Name = name
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+	if req.Name != name {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Role cannot be renamed")
+	}
+
+	role, err := c.service.UpdateRole(ctx.Request().Context(), claims, name, *req)
+	if err != nil {
+		return roleFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Role updated successfully", role)
+}
+
+// @Summary Delete Role
+// @Description To delete role that is not assigned to any user, the users having the role have to be given another role first
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "name of the role"
+// @Success 200 {object} utils.JsonResponse "Success delete role"
+// @Failure 403 {object} utils.JsonResponse "Role grants permissions the admin does not have"
+// @Failure 404 {object} utils.JsonResponse "Role not found"
+// @Failure 409 {object} utils.JsonResponse "Role still assigned"
+// @Router /api/admin/role/{name} [delete]
+func (c *RoleController) DeleteRole(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.DeleteRole(ctx.Request().Context(), claims, ctx.Param("name")); err != nil {
+		return roleFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// @Summary Assign Role
+// @Description To change the role of a user, the access tokens of the user stop working and have to be refreshed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the user"
+// @Param request body models.AssignRoleRequest true "Assign Role Request"
+// @Success 200 {object} utils.JsonResponse "Success assign role"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 403 {object} utils.JsonResponse "Role grants permissions the admin does not have"
+// @Failure 404 {object} utils.JsonResponse "User not found"
+// @Router /api/admin/user/{id}/role [post]
+func (c *RoleController) AssignRole(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.AssignRoleRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.AssignRole(ctx.Request().Context(), claims, ctx.Param("id"), req.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "user is not exists")
+		}
+		return roleFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Role assigned successfully", nil)
+}
+
+func roleFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "role is not exists")
+	case errors.Is(err, services.ErrRoleExists), errors.Is(err, services.ErrRoleInUse):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrRoleEscalation), errors.Is(err, services.ErrBuiltinRole):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidRoleName),
+		errors.Is(err, services.ErrUnknownPermission),
+		errors.Is(err, services.ErrUnknownRole),
+		errors.Is(err, services.ErrChangeOwnRole):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
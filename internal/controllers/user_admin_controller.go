@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary List Users
+// @Description To list and search users, newest first
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param query query string false "Part of the username or the email"
+// @Param role query string false "Only users with this role"
+// @Param status query string false "Only active or disabled users"
+// @Param limit query int false "Limit number for pagination"
+// @Param offset query int false "Offset of items per page"
+// @Success 200 {object} utils.JsonResponse "Success list users"
+// @Failure 400 {object} utils.JsonResponse "Invalid status"
+// @Router /api/admin/users [get]
+func (c *UserController) ListUsers(ctx echo.Context) error {
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0 // default offset
+	}
+
+	filter := models.UserFilter{
+		Query:  ctx.QueryParam("query"),
+		Role:   ctx.QueryParam("role"),
+		Status: ctx.QueryParam("status"),
+		Limit:  limit,
+		Offset: offset,
+	}
+	users, err := c.service.ListUsers(ctx.Request().Context(), filter)
+	if err != nil {
+		return userAdminFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", users)
+}
+
+// @Summary Get User
+// @Description To get user with the summary of their votes, reviews, views and sessions
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the user"
+// @Success 200 {object} utils.JsonResponse "Success get user"
+// @Failure 404 {object} utils.JsonResponse "User not found"
+// @Router /api/admin/user/{id} [get]
+func (c *UserController) GetUserDetail(ctx echo.Context) error {
+	user, err := c.service.GetUserDetail(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return userAdminFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", user)
+}
+
+// @Summary List User Votes
+// @Description To list the movies a user voted for, newest vote first
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the user"
+// @Param limit query int false "Limit number for pagination"
+// @Param offset query int false "Offset of items per page"
+// @Success 200 {object} utils.JsonResponse "Success list user votes"
+// @Failure 404 {object} utils.JsonResponse "User not found"
+// @Router /api/admin/user/{id}/votes [get]
+func (c *UserController) ListUserVotes(ctx echo.Context) error {
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0 // default offset
+	}
+
+	votes, err := c.service.ListUserVotes(ctx.Request().Context(), ctx.Param("id"), limit, offset)
+	if err != nil {
+		return userAdminFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", votes)
+}
+
+// @Summary Disable User
+// @Description To block the logins of a user and sign out all their sessions
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the user"
+// @Success 200 {object} utils.JsonResponse "Success disable user"
+// @Failure 400 {object} utils.JsonResponse "Own account"
+// @Failure 403 {object} utils.JsonResponse "User has permissions the admin does not have"
+// @Failure 404 {object} utils.JsonResponse "User not found"
+// @Router /api/admin/user/{id}/disable [post]
+func (c *UserController) DisableUser(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.DisableUser(ctx.Request().Context(), claims, ctx.Param("id")); err != nil {
+		return userAdminFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "User disabled successfully", nil)
+}
+
+// @Summary Enable User
+// @Description To let a disabled user log in again
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the user"
+// @Success 200 {object} utils.JsonResponse "Success enable user"
+// @Failure 404 {object} utils.JsonResponse "User not found"
+// @Router /api/admin/user/{id}/enable [post]
+func (c *UserController) EnableUser(ctx echo.Context) error {
+	if err := c.service.EnableUser(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return userAdminFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "User enabled successfully", nil)
+}
+
+// @Summary Force Password Reset
+// @Description To sign out all sessions of a user and block their logins until they reset the password with the reset token sent to them
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the user"
+// @Success 200 {object} utils.JsonResponse "Success force password reset"
+// @Failure 403 {object} utils.JsonResponse "User has permissions the admin does not have"
+// @Failure 404 {object} utils.JsonResponse "User not found"
+// @Router /api/admin/user/{id}/password-reset [post]
+func (c *UserController) ForcePasswordReset(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.ForcePasswordReset(ctx.Request().Context(), claims, ctx.Param("id")); err != nil {
+		return userAdminFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Password reset token sent to the user", nil)
+}
+
+func userAdminFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "user is not exists")
+	case errors.Is(err, services.ErrDisableSelf), errors.Is(err, services.ErrInvalidUserStatus):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrUserOutranks):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
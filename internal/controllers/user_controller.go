@@ -1,8 +1,13 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/stwrtrio/movie-festival/internal/helpers"
 	"github.com/stwrtrio/movie-festival/internal/middlewares"
 	"github.com/stwrtrio/movie-festival/internal/models"
 	"github.com/stwrtrio/movie-festival/internal/services"
@@ -27,8 +32,8 @@ func NewUserController(service services.UserService) *UserController {
 // @Produce json
 // @Param request body models.RegisterRequest true "Register Request"
 // @Success 200 {object} utils.JsonResponse "Success create user"
-// @Failure 400 {object} utils.JsonResponse "Invalid input"
-// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or password does not meet the password policy"
+// @Failure 409 {object} utils.JsonResponse "Username or email already exists"
 // @Router /api/user/register [post]
 func (c *UserController) Register(ctx echo.Context) error {
 	cx := ctx.Request().Context()
@@ -37,10 +42,17 @@ func (c *UserController) Register(ctx echo.Context) error {
 		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
 	}
 
+	if err := ctx.Validate(&req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
 	if err := c.service.Register(cx, req); err != nil {
-		if err.Error() == "username already exists" {
+		if err.Error() == "username already exists" || errors.Is(err, services.ErrEmailExists) {
 			return utils.FailResponse(ctx, http.StatusConflict, err.Error())
 		}
+		if errors.Is(err, services.ErrWeakPassword) {
+			return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+		}
 		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
 	}
 
@@ -49,7 +61,7 @@ func (c *UserController) Register(ctx echo.Context) error {
 
 // Login godoc
 // @Summary User Login
-// @Description Authenticate a user and return a JWT token
+// @Description Authenticate a user and return a short-lived JWT token with a refresh token. Users with two-factor authentication get an MFA token to complete the login at /api/user/login/mfa instead
 // @Tags User
 // @Accept json
 // @Produce json
@@ -57,6 +69,9 @@ func (c *UserController) Register(ctx echo.Context) error {
 // @Success 200 {object} utils.JsonResponse "Access granted, includes JWT token"
 // @Failure 400 {object} utils.JsonResponse "Invalid input"
 // @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Failure 403 {object} utils.JsonResponse "Account disabled or waiting for a password reset"
+// @Failure 423 {object} utils.JsonResponse "Account temporarily locked after too many failed logins"
+// @Failure 429 {object} utils.JsonResponse "Too many failed logins, retry after the Retry-After header"
 // @Router /api/user/login [post]
 func (c *UserController) Login(ctx echo.Context) error {
 	var userRequest models.LoginRequest
@@ -71,17 +86,59 @@ func (c *UserController) Login(ctx echo.Context) error {
 		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
 	}
 
-	token, err := c.service.Login(cx, userRequest.Username, userRequest.Password)
+	tokens, err := c.service.Login(cx, userRequest.Username, userRequest.Password, sessionDevice(ctx))
 	if err != nil {
+		var blocked *services.LoginBlockedError
+		if errors.As(err, &blocked) {
+			return loginBlockedResponse(ctx, blocked)
+		}
+		if errors.Is(err, services.ErrAccountDisabled) || errors.Is(err, services.ErrPasswordResetRequired) {
+			return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+		}
 		return utils.FailResponse(ctx, http.StatusUnauthorized, "invalid credentials")
 	}
 
-	return utils.SuccessResponse(ctx, http.StatusOK, "Access granted", map[string]string{"token": token})
+	if tokens.MFARequired {
+		return utils.SuccessResponse(ctx, http.StatusOK, "MFA code required", tokens)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Access granted", tokens)
+}
+
+// Refresh godoc
+// @Summary Refresh Token
+// @Description Exchange a refresh token for a new access token and refresh token. A refresh token can be used once, using it again revokes the session
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "Refresh Token Request"
+// @Success 200 {object} utils.JsonResponse "Access granted, includes JWT token"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 401 {object} utils.JsonResponse "Invalid, expired or reused refresh token"
+// @Router /api/user/refresh [post]
+func (c *UserController) Refresh(ctx echo.Context) error {
+	req := new(models.RefreshTokenRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	tokens, err := c.service.Refresh(ctx.Request().Context(), req.RefreshToken, sessionDevice(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+			return utils.FailResponse(ctx, http.StatusUnauthorized, err.Error())
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Access granted", tokens)
 }
 
 // Logout godoc
 // @Summary User Logout
-// @Description Logout an authenticated user by invalidating token.
+// @Description Logout an authenticated user by invalidating token and ending its session.
 // @Tags User
 // @Accept json
 // @Produce json
@@ -105,3 +162,213 @@ func (c *UserController) Logout(ctx echo.Context) error {
 
 	return utils.SuccessResponse(ctx, http.StatusOK, "user logged out successfully", nil)
 }
+
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description The public keys access tokens are signed with, for other services to verify tokens
+// @Tags User
+// @Produce json
+// @Success 200 {object} helpers.JWKSet "Public keys"
+// @Router /.well-known/jwks.json [get]
+func (c *UserController) JWKS(ctx echo.Context) error {
+	jwks, err := helpers.PublicJWKS()
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	// Verifiers cache the keys, a new key is published before it signs any token
+	ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return ctx.JSON(http.StatusOK, jwks)
+}
+
+// @Summary List Sessions
+// @Description To list the active sessions of the user with their device and IP address
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success list sessions"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/user/sessions [get]
+func (c *UserController) ListSessions(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	sessions, err := c.service.ListSessions(ctx.Request().Context(), claims)
+	if err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", sessions)
+}
+
+// @Summary Revoke Session
+// @Description To sign out the device of a session of the user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the session"
+// @Success 200 {object} utils.JsonResponse "Success revoke session"
+// @Failure 404 {object} utils.JsonResponse "Session not found"
+// @Router /api/user/sessions/{id} [delete]
+func (c *UserController) RevokeSession(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.RevokeSession(ctx.Request().Context(), claims.UserID, ctx.Param("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.FailResponse(ctx, http.StatusNotFound, "session is not exists")
+		}
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// @Summary Revoke All Sessions
+// @Description To sign out every device of the user, including the one of the request
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success revoke sessions"
+// @Failure 401 {object} utils.JsonResponse "Unauthorized"
+// @Router /api/user/sessions [delete]
+func (c *UserController) RevokeAllSessions(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.RevokeAllSessions(ctx.Request().Context(), claims.UserID); err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Sessions revoked successfully", nil)
+}
+
+// @Summary Change Password
+// @Description To change the password of the user. Every session is signed out and a new access token and refresh token are returned for the device of the request
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangePasswordRequest true "Change Password Request"
+// @Success 200 {object} utils.JsonResponse "Success change password, includes JWT token"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or password does not meet the password policy"
+// @Failure 401 {object} utils.JsonResponse "Current password is incorrect"
+// @Failure 423 {object} utils.JsonResponse "Account temporarily locked after too many failed logins"
+// @Failure 429 {object} utils.JsonResponse "Too many failed logins, retry after the Retry-After header"
+// @Router /api/user/password [post]
+func (c *UserController) ChangePassword(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.ChangePasswordRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	tokens, err := c.service.ChangePassword(ctx.Request().Context(), claims, *req, sessionDevice(ctx))
+	if err != nil {
+		return passwordFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Password changed successfully", tokens)
+}
+
+// @Summary Forgot Password
+// @Description To send a password reset token to the user through the configured notifier. The response is the same whether the username exists or not
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body models.ForgotPasswordRequest true "Forgot Password Request"
+// @Success 200 {object} utils.JsonResponse "Password reset token sent if the user exists"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 429 {object} utils.JsonResponse "Too many requests from the client address"
+// @Router /api/user/password/forgot [post]
+func (c *UserController) ForgotPassword(ctx echo.Context) error {
+	req := new(models.ForgotPasswordRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.ForgotPassword(ctx.Request().Context(), req.Username); err != nil {
+		return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "If the user exists, a password reset token has been sent", nil)
+}
+
+// @Summary Reset Password
+// @Description To set a new password with a password reset token. The token can be used once and every session of the user is signed out
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordRequest true "Reset Password Request"
+// @Success 200 {object} utils.JsonResponse "Success reset password"
+// @Failure 400 {object} utils.JsonResponse "Invalid or expired token, or password does not meet the password policy"
+// @Router /api/user/password/reset [post]
+func (c *UserController) ResetPassword(ctx echo.Context) error {
+	req := new(models.ResetPasswordRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.ResetPassword(ctx.Request().Context(), *req); err != nil {
+		return passwordFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Password reset successfully", nil)
+}
+
+func passwordFailResponse(ctx echo.Context, err error) error {
+	var blocked *services.LoginBlockedError
+	switch {
+	case errors.As(err, &blocked):
+		return loginBlockedResponse(ctx, blocked)
+	case errors.Is(err, services.ErrInvalidCurrentPassword):
+		return utils.FailResponse(ctx, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrWeakPassword),
+		errors.Is(err, services.ErrSamePassword),
+		errors.Is(err, services.ErrInvalidResetToken):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+}
+
+// loginBlockedResponse answers a login refused after too many failures, telling the client when to retry
+func loginBlockedResponse(ctx echo.Context, blocked *services.LoginBlockedError) error {
+	ctx.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+	if errors.Is(blocked, services.ErrAccountLocked) {
+		return utils.FailResponse(ctx, http.StatusLocked, blocked.Error())
+	}
+	return utils.FailResponse(ctx, http.StatusTooManyRequests, blocked.Error())
+}
+
+// sessionDevice describes the client of the request for the session list
+func sessionDevice(ctx echo.Context) models.SessionDevice {
+	userAgent := ctx.Request().UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	return models.SessionDevice{UserAgent: userAgent, IPAddress: ctx.RealIP()}
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LoginMFA godoc
+// @Summary Complete MFA Login
+// @Description Complete a login with the MFA token of the login and a TOTP code or a recovery code. A user who enrolled during the login gets the recovery codes in the response
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body models.MFALoginRequest true "MFA Login Request"
+// @Success 200 {object} utils.JsonResponse "Access granted, includes JWT token"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 401 {object} utils.JsonResponse "Invalid MFA token or code"
+// @Failure 403 {object} utils.JsonResponse "Account disabled or waiting for a password reset"
+// @Failure 423 {object} utils.JsonResponse "Account temporarily locked after too many failed logins"
+// @Router /api/user/login/mfa [post]
+func (c *UserController) LoginMFA(ctx echo.Context) error {
+	req := new(models.MFALoginRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	tokens, err := c.service.CompleteMFALogin(ctx.Request().Context(), *req, sessionDevice(ctx))
+	if err != nil {
+		return mfaFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Access granted", tokens)
+}
+
+// LoginMFASetup godoc
+// @Summary Enroll MFA During Login
+// @Description Create the TOTP secret of a user who has to enroll before completing the login, confirm it at /api/user/login/mfa
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body models.MFATokenRequest true "MFA Token Request"
+// @Success 200 {object} utils.JsonResponse "TOTP secret and otpauth URI"
+// @Failure 401 {object} utils.JsonResponse "Invalid MFA token"
+// @Failure 409 {object} utils.JsonResponse "Two-factor authentication already enabled"
+// @Router /api/user/login/mfa/setup [post]
+func (c *UserController) LoginMFASetup(ctx echo.Context) error {
+	req := new(models.MFATokenRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	setup, err := c.service.SetupMFALogin(ctx.Request().Context(), req.MFAToken)
+	if err != nil {
+		return mfaFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", setup)
+}
+
+// @Summary Set Up MFA
+// @Description To create a TOTP secret to enroll in an authenticator app. Two-factor authentication is enabled once a code confirms it
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "TOTP secret and otpauth URI"
+// @Failure 409 {object} utils.JsonResponse "Two-factor authentication already enabled"
+// @Router /api/user/mfa/setup [post]
+func (c *UserController) SetupMFA(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	setup, err := c.service.SetupMFA(ctx.Request().Context(), claims)
+	if err != nil {
+		return mfaFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", setup)
+}
+
+// @Summary Enable MFA
+// @Description To confirm the TOTP secret of the setup with a code. The recovery codes of the response are only shown once
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} utils.JsonResponse "Success enable MFA, includes recovery codes"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or MFA not set up"
+// @Failure 401 {object} utils.JsonResponse "Invalid MFA code"
+// @Failure 429 {object} utils.JsonResponse "Too many invalid codes"
+// @Router /api/user/mfa/enable [post]
+func (c *UserController) EnableMFA(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.MFACodeRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	codes, err := c.service.EnableMFA(ctx.Request().Context(), claims, req.Code, ctx.RealIP())
+	if err != nil {
+		return mfaFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Two-factor authentication enabled successfully", models.RecoveryCodes{RecoveryCodes: codes})
+}
+
+// @Summary Disable MFA
+// @Description To turn two-factor authentication off with the password and a TOTP code or a recovery code
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DisableMFARequest true "Disable MFA Request"
+// @Success 200 {object} utils.JsonResponse "Success disable MFA"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or MFA not enabled"
+// @Failure 401 {object} utils.JsonResponse "Invalid password or MFA code"
+// @Failure 403 {object} utils.JsonResponse "Two-factor authentication is required for the account"
+// @Failure 423 {object} utils.JsonResponse "Account temporarily locked after too many failed logins"
+// @Failure 429 {object} utils.JsonResponse "Too many invalid codes"
+// @Router /api/user/mfa/disable [post]
+func (c *UserController) DisableMFA(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.DisableMFARequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	if err := c.service.DisableMFA(ctx.Request().Context(), claims, *req, ctx.RealIP()); err != nil {
+		return mfaFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Two-factor authentication disabled successfully", nil)
+}
+
+// @Summary Regenerate Recovery Codes
+// @Description To replace every recovery code after checking a TOTP code. The new codes are only shown once
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} utils.JsonResponse "New recovery codes"
+// @Failure 400 {object} utils.JsonResponse "Invalid input or MFA not enabled"
+// @Failure 401 {object} utils.JsonResponse "Invalid MFA code"
+// @Failure 429 {object} utils.JsonResponse "Too many invalid codes"
+// @Router /api/user/mfa/recovery-codes [post]
+func (c *UserController) RegenerateRecoveryCodes(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.MFACodeRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	codes, err := c.service.RegenerateRecoveryCodes(ctx.Request().Context(), claims, req.Code, ctx.RealIP())
+	if err != nil {
+		return mfaFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Recovery codes regenerated successfully", models.RecoveryCodes{RecoveryCodes: codes})
+}
+
+func mfaFailResponse(ctx echo.Context, err error) error {
+	var blocked *services.LoginBlockedError
+	switch {
+	case errors.As(err, &blocked):
+		return loginBlockedResponse(ctx, blocked)
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "user is not exists")
+	case errors.Is(err, services.ErrInvalidMFAToken),
+		errors.Is(err, services.ErrInvalidMFACode),
+		errors.Is(err, services.ErrInvalidCurrentPassword):
+		return utils.FailResponse(ctx, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrMFAAlreadyEnabled):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrMFANotSetUp), errors.Is(err, services.ErrMFANotEnabled):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrMFARequired),
+		errors.Is(err, services.ErrAccountDisabled),
+		errors.Is(err, services.ErrPasswordResetRequired):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "internal server error")
+}
@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type VotingController struct {
+	service services.VotingService
+}
+
+func NewVotingController(service services.VotingService) *VotingController {
+	return &VotingController{service}
+}
+
+// @Summary List Voting Periods
+// @Description To list the voting periods of festival edition
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Success 200 {object} utils.JsonResponse "Success list voting periods"
+// @Failure 404 {object} utils.JsonResponse "Edition not found"
+// @Router /api/admin/edition/{id}/voting-periods [get]
+func (c *VotingController) ListPeriods(ctx echo.Context) error {
+	periods, err := c.service.ListPeriods(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", periods)
+}
+
+// @Summary Create Voting Period
+// @Description To add voting period to festival edition that is not closed, for all its entries or for the nominees of one of its sections
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the edition"
+// @Param request body models.VotingPeriodRequest true "Voting Period Request"
+// @Success 201 {object} utils.JsonResponse "Success create voting period"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Edition or section not found"
+// @Failure 409 {object} utils.JsonResponse "Edition closed"
+// @Router /api/admin/edition/{id}/voting-period [post]
+func (c *VotingController) CreatePeriod(ctx echo.Context) error {
+	req := new(models.VotingPeriodRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	period, err := c.service.CreatePeriod(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusCreated, "Voting period created successfully", period)
+}
+
+// @Summary Update Voting Period
+// @Description To move voting period of festival edition that is not closed, change its section, its ballot mode or its vote budget
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the voting period"
+// @Param request body models.VotingPeriodRequest true "Voting Period Request"
+// @Success 200 {object} utils.JsonResponse "Success update voting period"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Voting period or section not found"
+// @Failure 409 {object} utils.JsonResponse "Edition closed or ballot mode locked"
+// @Router /api/admin/voting-period/{id} [post]
+func (c *VotingController) UpdatePeriod(ctx echo.Context) error {
+	req := new(models.VotingPeriodRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	period, err := c.service.UpdatePeriod(ctx.Request().Context(), ctx.Param("id"), *req)
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Voting period updated successfully", period)
+}
+
+// @Summary Delete Voting Period
+// @Description To delete voting period with its ranked ballots, the approval votes cast in it are kept
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the voting period"
+// @Success 200 {object} utils.JsonResponse "Success delete voting period"
+// @Failure 404 {object} utils.JsonResponse "Voting period not found"
+// @Router /api/admin/voting-period/{id} [delete]
+func (c *VotingController) DeletePeriod(ctx echo.Context) error {
+	if err := c.service.DeletePeriod(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Voting period deleted successfully", nil)
+}
+
+// @Summary Tally Voting Period
+// @Description To tally the ballots of voting period round by round, approval periods by their votes and ranked periods by instant-runoff or Borda count
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the voting period"
+// @Param method query string false "approval, instant_runoff (default for ranked periods) or borda"
+// @Success 200 {object} utils.JsonResponse "Success tally voting period"
+// @Failure 400 {object} utils.JsonResponse "Invalid tally method"
+// @Failure 404 {object} utils.JsonResponse "Voting period not found"
+// @Router /api/admin/voting-period/{id}/tally [get]
+func (c *VotingController) TallyPeriod(ctx echo.Context) error {
+	tally, err := c.service.TallyPeriod(ctx.Request().Context(), ctx.Param("id"), ctx.QueryParam("method"))
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", tally)
+}
+
+// @Summary List Open Voting Periods
+// @Description To list the voting periods open now with the votes the user has left in each of them
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.JsonResponse "Success list voting periods"
+// @Router /api/user/voting [get]
+func (c *VotingController) ListUserPeriods(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	periods, err := c.service.ListUserPeriods(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", periods)
+}
+
+// @Summary Vote Movie
+// @Description To vote the movie while a voting period is open for it, within the vote budget of the period
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success vote movie"
+// @Failure 400 {object} utils.JsonResponse "Already voted"
+// @Failure 403 {object} utils.JsonResponse "Voting closed"
+// @Failure 409 {object} utils.JsonResponse "Vote budget exhausted"
+// @Router /api/user/movies/{id}/vote [post]
+func (c *VotingController) VoteMovie(ctx echo.Context) error {
+	// Get user claims from context
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	// Get movie ID from the URL
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	if err := c.service.VoteMovie(ctx.Request().Context(), claims.UserID, movieID); err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie voted successfully", nil)
+}
+
+// @Summary Unvote Movie
+// @Description To unvote the movie while the voting period of the vote is open, the vote goes back to the budget
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success unvote movie"
+// @Failure 400 {object} utils.JsonResponse "Not voted"
+// @Failure 403 {object} utils.JsonResponse "Voting closed"
+// @Router /api/user/movies/{id}/unvote [post]
+func (c *VotingController) UnvoteMovie(ctx echo.Context) error {
+	// Get user claims from context
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	// Extract the movie ID from the request parameters
+	movieID := ctx.Param("id")
+	if movieID == "" {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Movie ID is required")
+	}
+
+	if err := c.service.UnvoteMovie(ctx.Request().Context(), claims.UserID, movieID); err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Movie unvoted successfully", nil)
+}
+
+// @Summary Get Ballot
+// @Description To get the ballot the user cast in ranked voting period
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the voting period"
+// @Success 200 {object} utils.JsonResponse "Success get ballot"
+// @Failure 404 {object} utils.JsonResponse "Voting period or ballot not found"
+// @Router /api/user/voting/{id}/ballot [get]
+func (c *VotingController) GetBallot(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	ballot, err := c.service.GetBallot(ctx.Request().Context(), claims.UserID, ctx.Param("id"))
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", ballot)
+}
+
+// @Summary Cast Ballot
+// @Description To rank movies of open ranked voting period in order of preference, replacing the previous ballot of the user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the voting period"
+// @Param request body models.BallotRequest true "Ballot Request"
+// @Success 200 {object} utils.JsonResponse "Success cast ballot"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 403 {object} utils.JsonResponse "Voting period closed"
+// @Failure 404 {object} utils.JsonResponse "Voting period not found"
+// @Router /api/user/voting/{id}/ballot [post]
+func (c *VotingController) CastBallot(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.BallotRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	ballot, err := c.service.CastBallot(ctx.Request().Context(), claims.UserID, ctx.Param("id"), *req)
+	if err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Ballot cast successfully", ballot)
+}
+
+// @Summary Withdraw Ballot
+// @Description To withdraw the ballot the user cast in ranked voting period while it is open
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the voting period"
+// @Success 200 {object} utils.JsonResponse "Success withdraw ballot"
+// @Failure 403 {object} utils.JsonResponse "Voting period closed"
+// @Failure 404 {object} utils.JsonResponse "Voting period or ballot not found"
+// @Router /api/user/voting/{id}/ballot [delete]
+func (c *VotingController) WithdrawBallot(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := c.service.WithdrawBallot(ctx.Request().Context(), claims.UserID, ctx.Param("id")); err != nil {
+		return votingFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Ballot withdrawn successfully", nil)
+}
+
+func votingFailResponse(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return utils.FailResponse(ctx, http.StatusNotFound, "edition is not exists")
+	case errors.Is(err, services.ErrVotingPeriodNotExists),
+		errors.Is(err, services.ErrSectionNotExists),
+		errors.Is(err, services.ErrBallotNotExists):
+		return utils.FailResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrVotingClosed),
+		errors.Is(err, services.ErrVotingPeriodClosed):
+		return utils.FailResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrVoteBudgetExhausted),
+		errors.Is(err, services.ErrEditionClosed),
+		errors.Is(err, services.ErrBallotModeLocked):
+		return utils.FailResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidVotingPeriod),
+		errors.Is(err, services.ErrInvalidRankedPeriod),
+		errors.Is(err, services.ErrInvalidApprovalPeriod),
+		errors.Is(err, services.ErrAlreadyVoted),
+		errors.Is(err, services.ErrNotVoted),
+		errors.Is(err, services.ErrNotRankedPeriod),
+		errors.Is(err, services.ErrInvalidBallot),
+		errors.Is(err, services.ErrMovieNotEligible),
+		errors.Is(err, services.ErrInvalidTallyMethod):
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
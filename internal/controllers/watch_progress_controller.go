@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WatchProgressController struct {
+	service services.WatchProgressService
+}
+
+func NewWatchProgressController(service services.WatchProgressService) *WatchProgressController {
+	return &WatchProgressController{service}
+}
+
+// @Summary Save Watch Progress
+// @Description To report the playback position of the user in a movie. The movie counts as completed past the configured share of its duration
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Param request body models.WatchProgressRequest true "Watch Progress Request"
+// @Success 200 {object} utils.JsonResponse "Success save progress"
+// @Failure 400 {object} utils.JsonResponse "Invalid input"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Router /api/user/movies/{id}/progress [post]
+func (c *WatchProgressController) SaveProgress(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	req := new(models.WatchProgressRequest)
+	if err := ctx.Bind(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+	if err := ctx.Validate(req); err != nil {
+		return utils.FailResponse(ctx, http.StatusBadRequest, err.Error())
+	}
+
+	progress, err := c.service.SaveProgress(ctx.Request().Context(), claims.UserID, ctx.Param("id"), req.PositionSeconds)
+	if err != nil {
+		return watchProgressFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "Progress saved successfully", progress)
+}
+
+// @Summary Get Watch Progress
+// @Description To get the position to resume a movie from
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "id of the movie"
+// @Success 200 {object} utils.JsonResponse "Success get progress"
+// @Failure 404 {object} utils.JsonResponse "Movie not found"
+// @Router /api/user/movies/{id}/progress [get]
+func (c *WatchProgressController) GetProgress(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	progress, err := c.service.GetProgress(ctx.Request().Context(), claims.UserID, ctx.Param("id"))
+	if err != nil {
+		return watchProgressFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", progress)
+}
+
+// @Summary Continue Watching
+// @Description To list the movies the user started and did not finish, most recently played first
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of movies"
+// @Success 200 {object} utils.JsonResponse "Success list movies"
+// @Router /api/user/continue [get]
+func (c *WatchProgressController) ContinueWatching(ctx echo.Context) error {
+	claims, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		return utils.FailResponse(ctx, http.StatusUnauthorized, "User not authenticated")
+	}
+
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10 // default limit
+	}
+
+	entries, err := c.service.ContinueWatching(ctx.Request().Context(), claims.UserID, limit)
+	if err != nil {
+		return watchProgressFailResponse(ctx, err)
+	}
+
+	return utils.SuccessResponse(ctx, http.StatusOK, "", entries)
+}
+
+func watchProgressFailResponse(ctx echo.Context, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return utils.FailResponse(ctx, http.StatusNotFound, "movie is not exists")
+	}
+
+	return utils.FailResponse(ctx, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"time"
@@ -14,6 +15,8 @@ type Claims struct {
 	Username string `json:"username"`
 	Role     string `json:"role"`
 	JTI      string `json:"jti"`
+	// SessionID is the login session the token was issued for, revoking the session revokes the token
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -27,8 +30,8 @@ func LoadJWTExpiry() time.Duration {
 	return expiry
 }
 
-// GenerateJWTToken creates a JWT token for the user
-func GenerateJWTToken(userID string, username, role string) (string, error) {
+// GenerateJWTToken creates a JWT token for the user in a login session
+func GenerateJWTToken(userID string, username, role, sessionID string) (string, error) {
 	expiry := LoadJWTExpiry()
 	expirationTime := time.Now().Add(expiry)
 	claims := Claims{
@@ -39,20 +42,46 @@ func GenerateJWTToken(userID string, username, role string) (string, error) {
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
-		JTI: uuid.NewString(),
+		JTI:       uuid.NewString(),
+		SessionID: sessionID,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	secretKey := os.Getenv("JWT_SECRET")
-	return token.SignedString([]byte(secretKey))
+	keys, err := loadJWTKeys()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(keys.signing.Method, claims)
+	if keys.signing.ID != "" {
+		token.Header["kid"] = keys.signing.ID
+	}
+	return token.SignedString(keys.signing.SigningKey)
 }
 
-// ValidateJWTToken parses and validates a JWT token
+// ValidateJWTToken parses and validates a JWT token.
+// The token must name a known key in its kid header, or no kid for the JWT_SECRET key, and be signed with the algorithm of that key.
 func ValidateJWTToken(tokenString string) (*Claims, error) {
-	secretKey := os.Getenv("JWT_SECRET")
+	keys, err := loadJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	validMethods := []string{}
+	for _, key := range keys.keys {
+		validMethods = append(validMethods, key.Method.Alg())
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secretKey), nil
-	})
+		kid, _ := token.Header["kid"].(string)
+		key, ok := keys.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %q for key %q", token.Method.Alg(), kid)
+		}
+		return key.VerifyKey, nil
+	}, jwt.WithValidMethods(validMethods))
 	if err != nil {
 		return nil, err
 	}
@@ -64,3 +93,15 @@ func ValidateJWTToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// RoleChangedKey is the Redis key holding when the role of a user last changed,
+// access tokens issued before carry the old role and are rejected until they expire
+func RoleChangedKey(userID string) string {
+	return "role:changed:" + userID
+}
+
+// SignedOutKey is the Redis key holding when every session of a user was last ended,
+// access tokens issued before are rejected until they expire, including the ones without a session
+func SignedOutKey(userID string) string {
+	return "user:signed_out:" + userID
+}
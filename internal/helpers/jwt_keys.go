@@ -0,0 +1,199 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// jwtKey is a key tokens are signed or verified with. The algorithm is bound to the key,
+// a token is only accepted when it was signed with the algorithm of the key its kid points to.
+type jwtKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	SigningKey interface{} // nil for keys that only verify tokens
+	VerifyKey  interface{}
+}
+
+type jwtKeySet struct {
+	signing *jwtKey
+	keys    map[string]*jwtKey // by kid, the legacy JWT_SECRET key has an empty kid
+}
+
+// JWK is a public key in the JSON Web Key format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Modulus   string `json:"n,omitempty"`   // RSA
+	Exponent  string `json:"e,omitempty"`   // RSA
+	Curve     string `json:"crv,omitempty"` // OKP
+	X         string `json:"x,omitempty"`   // OKP
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	jwtKeysMu     sync.Mutex
+	jwtKeys       *jwtKeySet
+	jwtKeysConfig string
+)
+
+// InitJWTKeys loads the JWT keys from JWT_KEYS_DIR and JWT_SECRET, so a broken key setup stops the application at start up
+func InitJWTKeys() error {
+	_, err := loadJWTKeys()
+	return err
+}
+
+// loadJWTKeys returns the loaded keys, reloading them when the key settings changed
+func loadJWTKeys() (*jwtKeySet, error) {
+	jwtKeysMu.Lock()
+	defer jwtKeysMu.Unlock()
+
+	config := strings.Join([]string{os.Getenv("JWT_KEYS_DIR"), os.Getenv("JWT_SIGNING_KEY_ID"), os.Getenv("JWT_SECRET")}, "\x00")
+	if jwtKeys != nil && config == jwtKeysConfig {
+		return jwtKeys, nil
+	}
+
+	keys, err := readJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKeys, jwtKeysConfig = keys, config
+	return keys, nil
+}
+
+// readJWTKeys reads every <kid>.pem file of JWT_KEYS_DIR. Private keys sign and verify, public keys only verify,
+// which lets a new key be rolled out to every instance before it signs and an old key keep verifying after it signed.
+// JWT_SIGNING_KEY_ID selects the signing key, without it tokens are signed with HS256 and JWT_SECRET.
+func readJWTKeys() (*jwtKeySet, error) {
+	keys := &jwtKeySet{keys: map[string]*jwtKey{}}
+
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		keys.keys[""] = &jwtKey{Method: jwt.SigningMethodHS256, SigningKey: []byte(secret), VerifyKey: []byte(secret)}
+	}
+
+	if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			key, err := readJWTKey(file)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWT key %s: %w", file, err)
+			}
+			// The empty kid is the JWT_SECRET key, a key must never replace another one
+			if key.ID == "" {
+				return nil, fmt.Errorf("invalid JWT key %s: the file name is the kid and cannot be empty", file)
+			}
+			if _, ok := keys.keys[key.ID]; ok {
+				return nil, fmt.Errorf("invalid JWT key %s: duplicate kid %q", file, key.ID)
+			}
+			keys.keys[key.ID] = key
+		}
+	}
+
+	signingKeyID := os.Getenv("JWT_SIGNING_KEY_ID")
+	signing, ok := keys.keys[signingKeyID]
+	if !ok || signing.SigningKey == nil {
+		if signingKeyID == "" {
+			return nil, errors.New("no JWT signing key, set JWT_SECRET or JWT_SIGNING_KEY_ID")
+		}
+		return nil, fmt.Errorf("JWT_SIGNING_KEY_ID %q is not a private key in JWT_KEYS_DIR", signingKeyID)
+	}
+	keys.signing = signing
+
+	return keys, nil
+}
+
+func readJWTKey(file string) (*jwtKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &jwtKey{ID: strings.TrimSuffix(filepath.Base(file), ".pem")}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.SigningKey, key.VerifyKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.VerifyKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.SigningKey, key.VerifyKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.VerifyKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsed)
+	}
+
+	return key, nil
+}
+
+// PublicJWKS returns the public keys tokens are verified with, for other services to verify tokens on their own.
+// The HS256 secret is never published.
+func PublicJWKS() (*JWKSet, error) {
+	keys, err := loadJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	set := &JWKSet{Keys: []JWK{}}
+	for _, key := range keys.keys {
+		jwk := JWK{KeyID: key.ID, Algorithm: key.Method.Alg(), Use: "sig"}
+		switch k := key.VerifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Modulus = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+			jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(k)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set, nil
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"time"
+)
+
+const defaultRefreshTokenExpiry = 30 * 24 * time.Hour
+
+// LoadRefreshTokenExpiry load REFRESH_TOKEN_EXPIRY in .env, the time a session stays alive without a refresh
+func LoadRefreshTokenExpiry() time.Duration {
+	expiry, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_EXPIRY"))
+	if err != nil || expiry <= 0 {
+		return defaultRefreshTokenExpiry
+	}
+	return expiry
+}
+
+// GenerateRefreshToken creates an opaque random refresh token
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the SHA-256 hex digest a refresh token is stored as
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RevokedSessionKey is the Redis key flagging a revoked session until its access tokens have expired
+func RevokedSessionKey(sessionID string) string {
+	return "session:revoked:" + sessionID
+}
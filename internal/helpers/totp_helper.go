@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters of RFC 6238, the defaults every authenticator app supports
+const (
+	totpDigits = 6
+	totpPeriod = 30 * time.Second
+	// totpSkew accepts the codes of the previous and the next period for clocks that drift
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret creates a random 160-bit secret, base32 encoded like authenticator apps expect it
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPURI builds the otpauth:// URI an authenticator app enrolls from, usually shown as a QR code
+func TOTPURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// TOTPCode computes the code of the secret for the period containing t
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return totpCode(secret, totpStep(t))
+}
+
+// ValidateTOTP checks a code against the periods around t.
+// It returns the time step the code belongs to, so a caller can refuse to accept the same code twice.
+func ValidateTOTP(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	step := totpStep(t)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		expected, err := totpCode(secret, step+int64(i))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step + int64(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes creates single-use codes that replace a TOTP code when the authenticator is lost
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(b))
+		codes[i] = code[:4] + "-" + code[4:]
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode lets users type recovery codes without the dash and in any case
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod.Seconds())
+}
+
+// totpCode is the HOTP value of RFC 4226 for the time step
+func totpCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
@@ -32,22 +32,15 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
-// AdminAuthMiddleware is a middleware to check if the user has an admin role.
-func AdminAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+// OptionalAuthMiddleware stores the user claims when a valid token is sent, but lets anonymous requests through.
+func OptionalAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		claims, err := validateToken(c)
-		if err != nil {
-			return utils.FailResponse(c, http.StatusUnauthorized, err.Error())
-		}
-
-		// Check if the user role is admin
-		if claims.Role != "admin" {
-			return utils.FailResponse(c, http.StatusForbidden, "Access denied. Admins only.")
+		if c.Request().Header.Get("Authorization") != "" {
+			if claims, err := validateToken(c); err == nil {
+				c.Set("user", claims)
+			}
 		}
 
-		// Store user claims in Echo's context (optional for admin routes)
-		c.Set("user", claims)
-
 		// Proceed to the next handler
 		return next(c)
 	}
@@ -83,5 +76,25 @@ func validateToken(c echo.Context) (*helpers.Claims, error) {
 		return nil, errors.New("token has been revoked")
 	}
 
+	// Check if the session of the token was revoked
+	if claims.SessionID != "" {
+		revoked, err := redisClient.Exists(ctx, helpers.RevokedSessionKey(claims.SessionID)).Result()
+		if err == nil && revoked > 0 {
+			return nil, errors.New("session has been revoked")
+		}
+	}
+
+	// Check if the user was signed out everywhere after the token was issued
+	signedOutAt, err := redisClient.Get(ctx, helpers.SignedOutKey(claims.UserID)).Int64()
+	if err == nil && (claims.IssuedAt == nil || claims.IssuedAt.Unix() < signedOutAt) {
+		return nil, errors.New("token has been revoked")
+	}
+
+	// Check if the role of the user changed after the token was issued
+	changedAt, err := redisClient.Get(ctx, helpers.RoleChangedKey(claims.UserID)).Int64()
+	if err == nil && claims.IssuedAt != nil && claims.IssuedAt.Unix() < changedAt {
+		return nil, errors.New("role has changed, please log in again")
+	}
+
 	return claims, nil
 }
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+)
+
+// RequirePermission lets the request through when the role of the user grants every given permission.
+// It must run after AuthMiddleware.
+func RequirePermission(roles services.RoleService, permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := GetUserFromContext(c)
+			if !ok {
+				return utils.FailResponse(c, http.StatusUnauthorized, "User not authenticated")
+			}
+
+			granted, err := roles.RolePermissions(c.Request().Context(), claims.Role)
+			if err != nil {
+				log.Printf("Error loading permissions of role %s: %v", claims.Role, err)
+				return utils.FailResponse(c, http.StatusInternalServerError, "Unexpected error occurred. Please contact support")
+			}
+
+			for _, permission := range permissions {
+				if !slices.Contains(granted, permission) {
+					return utils.FailResponse(c, http.StatusForbidden, "Access denied. Missing permission "+permission)
+				}
+			}
+
+			// Proceed to the next handler
+			return next(c)
+		}
+	}
+}
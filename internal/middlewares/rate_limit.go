@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/stwrtrio/movie-festival/config"
+	"github.com/stwrtrio/movie-festival/internal/utils"
+)
+
+const (
+	defaultViewRateLimit           = 30
+	defaultPasswordForgotRateLimit = 10
+)
+
+// ViewRateLimitMiddleware limits each client address to VIEW_RATE_LIMIT tracked views per minute.
+func ViewRateLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	limit, err := strconv.Atoi(os.Getenv("VIEW_RATE_LIMIT"))
+	if err != nil || limit <= 0 {
+		limit = defaultViewRateLimit
+	}
+
+	return rateLimit("view", limit, time.Minute)(next)
+}
+
+// PasswordForgotRateLimitMiddleware limits each client address to PASSWORD_FORGOT_RATE_LIMIT password reset requests per hour.
+func PasswordForgotRateLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	limit, err := strconv.Atoi(os.Getenv("PASSWORD_FORGOT_RATE_LIMIT"))
+	if err != nil || limit <= 0 {
+		limit = defaultPasswordForgotRateLimit
+	}
+
+	return rateLimit("password-forgot", limit, time.Hour)(next)
+}
+
+// rateLimit counts the requests of every client address in fixed windows stored in Redis,
+// so the limit holds across several instances of the application.
+func rateLimit(scope string, limit int, window time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			now := time.Now()
+			key := fmt.Sprintf("ratelimit:%s:%s:%d", scope, c.RealIP(), now.Unix()/int64(window.Seconds()))
+
+			count, err := config.RedisClient.Incr(ctx, key).Result()
+			if err != nil {
+				// Do not block clients while Redis is unavailable
+				log.Printf("Error checking rate limit: %v", err)
+				return next(c)
+			}
+			if count == 1 {
+				config.RedisClient.Expire(ctx, key, window)
+			}
+
+			if count > int64(limit) {
+				retryAfter := window - time.Duration(now.UnixNano()%int64(window))
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				return utils.FailResponse(c, http.StatusTooManyRequests, "Too many requests, please try again later")
+			}
+
+			return next(c)
+		}
+	}
+}
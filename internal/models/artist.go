@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type ArtistProfile struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Bio         string            `json:"bio"`
+	BirthDate   *time.Time        `json:"birth_date,omitempty"`
+	PhotoURL    string            `json:"photo_url"`
+	ExternalIDs map[string]string `json:"external_ids"` // e.g. {"imdb": "nm0634240"}
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type ArtistRequest struct {
+	Name        string            `json:"name" validate:"required,max=255"`
+	Bio         string            `json:"bio"`
+	BirthDate   string            `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	PhotoURL    string            `json:"photo_url" validate:"omitempty,url,max=255"`
+	ExternalIDs map[string]string `json:"external_ids" validate:"omitempty,dive,keys,required,max=50,endkeys,required"`
+}
+
+type MergeArtistsRequest struct {
+	SourceIDs []string `json:"source_ids" validate:"min=1,dive,required"` // Artists merged into the target artist
+}
+
+// FilmographyItem is a movie an artist is credited on
+type FilmographyItem struct {
+	MovieID string   `json:"movie_id"`
+	Title   string   `json:"title"`
+	Roles   []string `json:"roles"`
+	Views   int64    `json:"views"`
+	Votes   int64    `json:"votes"`
+}
+
+type ArtistFilmography struct {
+	ArtistProfile
+	Movies     []FilmographyItem `json:"movies"`
+	TotalViews int64             `json:"total_views"`
+	TotalVotes int64             `json:"total_votes"`
+}
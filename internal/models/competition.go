@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// Section is a competition of a festival edition, e.g. the Main Competition, with its own nominees and awards
+type Section struct {
+	ID          string `json:"id"`
+	EditionID   string `json:"edition_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// ScoringClosedAt is set once the jury of the section can no longer change its scores
+	ScoringClosedAt *time.Time      `json:"scoring_closed_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Nominees        []Nominee       `json:"nominees,omitempty"`
+	Categories      []AwardCategory `json:"categories,omitempty"`
+}
+
+// Nominee is a movie competing in a section
+type Nominee struct {
+	MovieID     string    `json:"movie_id"`
+	Title       string    `json:"title"`
+	NominatedAt time.Time `json:"nominated_at"`
+}
+
+// AwardCategory is an award given in a section, e.g. Best Film
+type AwardCategory struct {
+	ID          string    `json:"id"`
+	SectionID   string    `json:"section_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	Winners     []Winner  `json:"winners,omitempty"`
+}
+
+type Winner struct {
+	MovieID   string    `json:"movie_id"`
+	Title     string    `json:"title"`
+	AwardedAt time.Time `json:"awarded_at"`
+}
+
+// AwardWinner is a winner together with its category, section and edition
+type AwardWinner struct {
+	EditionID    string
+	EditionName  string
+	SectionID    string
+	SectionName  string
+	CategoryID   string
+	CategoryName string
+	Winner
+}
+
+// EditionAwards lists the winners of an edition per section
+type EditionAwards struct {
+	EditionID string          `json:"edition_id"`
+	Name      string          `json:"name"`
+	Sections  []SectionAwards `json:"sections"`
+}
+
+type SectionAwards struct {
+	SectionID string  `json:"section_id"`
+	Name      string  `json:"name"`
+	Awards    []Award `json:"awards"`
+}
+
+type Award struct {
+	CategoryID string   `json:"category_id"`
+	Category   string   `json:"category"`
+	Winners    []Winner `json:"winners"`
+}
+
+type SectionRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type AwardCategoryRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type NominationsRequest struct {
+	MovieIDs []string `json:"movie_ids" validate:"min=1,dive,required"`
+}
+
+type WinnerRequest struct {
+	MovieID string `json:"movie_id" validate:"required"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Festival edition statuses
+const (
+	EditionStatusDraft  = "draft" // being prepared, hidden from the audience
+	EditionStatusOpen   = "open"
+	EditionStatusClosed = "closed" // its entries are final
+)
+
+// Edition is one run of the festival, e.g. the 2026 festival, that movies are entered into
+type Edition struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	StartsOn  time.Time  `json:"starts_on"`
+	EndsOn    time.Time  `json:"ends_on"`
+	Status    string     `json:"status"`
+	Movies    int        `json:"movies"` // number of movies entered
+	OpenedAt  *time.Time `json:"opened_at,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// EditionDetail is an edition with the movies entered into it
+type EditionDetail struct {
+	Edition
+	Entries []Movie `json:"entries"`
+}
+
+type EditionRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	StartsOn string `json:"starts_on" validate:"required"` // YYYY-MM-DD
+	EndsOn   string `json:"ends_on" validate:"required"`   // YYYY-MM-DD, the last day of the edition
+}
+
+type EditionMoviesRequest struct {
+	MovieIDs []string `json:"movie_ids" validate:"min=1,dive,required"`
+}
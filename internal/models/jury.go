@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Aggregations of the juror scores of a movie into its final score
+const (
+	JuryAggregationMean        = "mean"
+	JuryAggregationTrimmedMean = "trimmed_mean" // the highest and the lowest juror scores are dropped
+	JuryAggregationMedian      = "median"
+)
+
+// Juror is a user assigned to score the nominees of a section
+type Juror struct {
+	UserID     string    `json:"user_id"`
+	Username   string    `json:"username"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// ScoringCriterion is scored by every juror of a section, e.g. Direction, its weight counts in the score of a movie
+type ScoringCriterion struct {
+	ID        string    `json:"id"`
+	SectionID string    `json:"section_id"`
+	Name      string    `json:"name"`
+	Weight    float64   `json:"weight"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JuryScore is the score a juror gave a movie on a criterion
+type JuryScore struct {
+	CriterionID string    `json:"criterion_id"`
+	MovieID     string    `json:"movie_id"`
+	Title       string    `json:"title"`
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	Score       float64   `json:"score"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// JurySheet is a section as a juror sees it, the scores of the other jurors are hidden until scoring closes
+type JurySheet struct {
+	Section
+	Criteria []ScoringCriterion `json:"criteria"`
+	Scores   []JuryScore        `json:"scores"`
+}
+
+// RankedMovie is the final score of a nominee, from the jurors who scored it on every criterion
+type RankedMovie struct {
+	Rank    int     `json:"rank"`
+	MovieID string  `json:"movie_id"`
+	Title   string  `json:"title"`
+	Score   float64 `json:"score"`
+	Jurors  int     `json:"jurors"`
+}
+
+type SectionRanking struct {
+	SectionID       string        `json:"section_id"`
+	Aggregation     string        `json:"aggregation"`
+	ScoringClosedAt *time.Time    `json:"scoring_closed_at,omitempty"`
+	Ranking         []RankedMovie `json:"ranking"`
+}
+
+type JurorRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type ScoringCriterionRequest struct {
+	Name   string  `json:"name" validate:"required,max=100"`
+	Weight float64 `json:"weight" validate:"gt=0,lte=100"`
+}
+
+// CriterionScore is a score from 0 to 10 on a criterion
+type CriterionScore struct {
+	CriterionID string  `json:"criterion_id" validate:"required"`
+	Score       float64 `json:"score" validate:"gte=0,lte=10"`
+}
+
+type JuryScoresRequest struct {
+	MovieID string           `json:"movie_id" validate:"required"`
+	Scores  []CriterionScore `json:"scores" validate:"min=1,dive"`
+}
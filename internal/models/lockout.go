@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Lockout scopes, failed logins are counted per username and per client address
+const (
+	LockoutScopeUser = "user"
+	LockoutScopeIP   = "ip"
+)
+
+// Lockout event actions
+const (
+	LockoutActionLock   = "lock"
+	LockoutActionUnlock = "unlock"
+)
+
+// LockoutEvent records a login lockout or an admin lifting it
+type LockoutEvent struct {
+	ID             int64      `json:"id"`
+	Scope          string     `json:"scope"`
+	Subject        string     `json:"subject"`
+	Action         string     `json:"action"`
+	IPAddress      string     `json:"ip_address,omitempty"`
+	FailedAttempts int        `json:"failed_attempts,omitempty"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	AdminID        string     `json:"admin_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type UnlockLoginRequest struct {
+	Username  string `json:"username" validate:"required_without=IPAddress,max=255"`
+	IPAddress string `json:"ip_address" validate:"required_without=Username,omitempty,ip"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// LoginResponse is the answer to a login: the tokens of the new session,
+// or for users with two-factor authentication the challenge to complete with a code
+type LoginResponse struct {
+	*TokenPair
+	MFARequired           bool     `json:"mfa_required,omitempty"`
+	MFAToken              string   `json:"mfa_token,omitempty"`               // completes the login at /api/user/login/mfa
+	MFAEnrollmentRequired bool     `json:"mfa_enrollment_required,omitempty"` // the user has to enroll an authenticator first
+	RecoveryCodes         []string `json:"recovery_codes,omitempty"`          // only when the login enrolled the authenticator
+}
+
+// MFAChallenge is a login waiting for its second factor, stored in Redis under the hash of its token
+type MFAChallenge struct {
+	UserID    string `json:"user_id"`
+	UserAgent string `json:"user_agent"`
+	IPAddress string `json:"ip_address"`
+}
+
+// MFASetup is the TOTP secret to enroll in an authenticator app, the URI is usually shown as a QR code
+type MFASetup struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"` // TOTP code or recovery code
+}
+
+type MFATokenRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+type DisableMFARequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"` // TOTP code or recovery code
+}
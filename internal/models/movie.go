@@ -3,36 +3,81 @@ package models
 import "time"
 
 type CreateMovieRequest struct {
-	Title       string   `json:"title" validate:"required,max=150"`
-	Description string   `json:"description" validate:"required"`
-	Duration    int      `json:"duration" validate:"required,min=1"`
-	Genres      []string `json:"genres" validate:"min=1,dive,required"`
-	WatchURL    string   `json:"watch_url" validate:"required,url"`
-	Artists     []string `json:"artists" validate:"min=1,dive,required"` // List of artist names
+	Title       string          `json:"title" validate:"required,max=150"`
+	Description string          `json:"description" validate:"required"`
+	Duration    int             `json:"duration" validate:"required,min=1"`
+	Genres      []string        `json:"genres" validate:"min=1,dive,required"`
+	WatchURL    string          `json:"watch_url" validate:"required,url"`
+	Artists     []string        `json:"artists" validate:"required_without=Credits,dive,required"` // List of artist names, credited as actors
+	Credits     []CreditRequest `json:"credits" validate:"required_without=Artists,dive"`          // List of artists with their role
+}
+
+// CreditRequest credits an artist on a movie with a role and billing order
+type CreditRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Role         string `json:"role" validate:"required,oneof=director actor writer composer"`
+	BillingOrder int    `json:"billing_order" validate:"min=0"`
+}
+
+// MovieSearchFilter holds the criteria used to search movies
+type MovieSearchFilter struct {
+	Query  string // matches title, description, genre or artist name
+	Artist string // matches artist name only
+	Role   string // restricts the artist match to a credit role
 }
 
 type Movie struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Duration    int       `json:"duration"`
-	Genres      []Genre   `json:"genres"`
-	WatchURL    string    `json:"watch_url"`
-	Views       int       `json:"views"`
-	Artists     []Artist  `json:"artists"` // Associated artists
-	Votes       int       `json:"votes"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Duration    int            `json:"duration"`
+	Genres      []Genre        `json:"genres"`
+	WatchURL    string         `json:"watch_url"`
+	Views       int            `json:"views"`
+	Artists     []Artist       `json:"artists"` // Associated artists
+	Votes       int            `json:"votes"`
+	Rating      *RatingSummary `json:"rating,omitempty"` // Set on the movie detail only
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
 type Genre struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id,omitempty"` // Parent genre when this genre is a subgenre
+}
+
+type GenreRequest struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentID *int64 `json:"parent_id" validate:"omitempty,min=1"`
 }
 
+type MergeGenresRequest struct {
+	SourceIDs []int64 `json:"source_ids" validate:"min=1,dive,min=1"` // Genres merged into the target genre
+}
+
+// Artist roles on a movie credit
+const (
+	ArtistRoleDirector = "director"
+	ArtistRoleActor    = "actor"
+	ArtistRoleWriter   = "writer"
+	ArtistRoleComposer = "composer"
+)
+
 type Artist struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Role         string `json:"role,omitempty"`
+	BillingOrder int    `json:"billing_order,omitempty"`
+}
+
+// IsValidArtistRole reports whether role is one of the supported credit roles
+func IsValidArtistRole(role string) bool {
+	switch role {
+	case ArtistRoleDirector, ArtistRoleActor, ArtistRoleWriter, ArtistRoleComposer:
+		return true
+	}
+	return false
 }
 
 type GenreView struct {
@@ -48,8 +93,10 @@ type MovieView struct {
 }
 
 type Vote struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	MovieID   string    `json:"movie_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID      string `json:"id"`
+	UserID  string `json:"user_id"`
+	MovieID string `json:"movie_id"`
+	// VotingPeriodID is the voting period the vote counts against, empty for votes cast before voting periods
+	VotingPeriodID string    `json:"voting_period_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
 }
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Personal movie lists of a user
+const (
+	MovieListWatchlist = "watchlist"
+	MovieListFavorites = "favorites"
+)
+
+// IsValidMovieList reports whether list is one of the personal movie lists
+func IsValidMovieList(list string) bool {
+	return list == MovieListWatchlist || list == MovieListFavorites
+}
+
+type MovieListItem struct {
+	Movie     Movie      `json:"movie"`
+	Position  int        `json:"position"`
+	Watched   bool       `json:"watched"`
+	WatchedAt *time.Time `json:"watched_at,omitempty"`
+	AddedAt   time.Time  `json:"added_at"`
+}
+
+type AddMovieListItemRequest struct {
+	MovieID string `json:"movie_id" validate:"required"`
+}
+
+type ReorderMovieListRequest struct {
+	MovieIDs []string `json:"movie_ids" validate:"min=1,dive,required"` // Every movie of the list in the new order
+}
+
+type MarkWatchedRequest struct {
+	Watched bool `json:"watched"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// UserIdentity links an account of an OpenID Connect provider to a user
+type UserIdentity struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"subject"`
+	Email       string     `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+// OIDCAuthorization is where the client sends the user to log in at the provider
+type OIDCAuthorization struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// OIDCState is kept in Redis between the authorization request and the callback of the provider
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	LinkUserID   string `json:"link_user_id,omitempty"` // set when a logged in user links the provider to their account
+}
+
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" query:"code" validate:"required"`
+	State string `json:"state" query:"state" validate:"required"`
+}
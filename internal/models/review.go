@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+type Review struct {
+	ID               string    `json:"id"`
+	MovieID          string    `json:"movie_id"`
+	UserID           string    `json:"user_id"`
+	Username         string    `json:"username"`
+	Rating           int       `json:"rating"` // 1 to 10
+	Body             string    `json:"body"`
+	HelpfulCount     int       `json:"helpful_count"`
+	Status           string    `json:"status"`
+	ModerationReason string    `json:"moderation_reason,omitempty"` // Why the review waits for moderation
+	ReportCount      int       `json:"report_count,omitempty"`      // Reports not yet resolved by an admin
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=10"`
+	Body   string `json:"body" validate:"max=5000"`
+}
+
+// Review moderation states, only approved reviews are public
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// Moderation actions taken by admins
+const (
+	ModerationActionApprove = "approve"
+	ModerationActionReject  = "reject"
+)
+
+type ReportReviewRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type ModerationRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// ModerationAction is an audit entry of an admin moderating a review
+type ModerationAction struct {
+	ID            int64     `json:"id"`
+	ReviewID      string    `json:"review_id"`
+	AdminID       string    `json:"admin_id"`
+	AdminUsername string    `json:"admin_username"`
+	Action        string    `json:"action"`
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Review list orders
+const (
+	ReviewSortNewest  = "newest"
+	ReviewSortHelpful = "helpful"
+)
+
+// RatingSummary aggregates the ratings of a movie
+type RatingSummary struct {
+	AverageRating float64       `json:"average_rating"`
+	RatingCount   int64         `json:"rating_count"`
+	Histogram     map[int]int64 `json:"histogram"` // number of reviews per rating, from 1 to 10
+}
+
+type ReviewPage struct {
+	Rating  RatingSummary `json:"rating"`
+	Reviews []Review      `json:"reviews"`
+	Limit   int           `json:"limit"`
+	Offset  int           `json:"offset"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Permissions checked by the RequirePermission middleware
+const (
+	PermissionMovieWrite     = "movie:write"     // create, update and delete movies, artists and genres
+	PermissionAnalyticsRead  = "analytics:read"  // view and vote statistics
+	PermissionReviewModerate = "review:moderate" // review moderation queue
+	PermissionUserManage     = "user:manage"     // users, their roles and login lockouts
+	PermissionRoleManage     = "role:manage"     // define roles and their permissions
+	PermissionFestivalManage = "festival:manage" // festival editions and the movies entered into them
+	PermissionJuryScore      = "jury:score"      // score the movies of the sections a juror is assigned to
+)
+
+// Permissions lists every permission a role can grant
+var Permissions = []string{
+	PermissionMovieWrite,
+	PermissionAnalyticsRead,
+	PermissionReviewModerate,
+	PermissionUserManage,
+	PermissionRoleManage,
+	PermissionFestivalManage,
+	PermissionJuryScore,
+}
+
+// Built-in roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Role is a named set of permissions, every user has one role
+type Role struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,max=50"`
+}
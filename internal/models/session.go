@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Session is a login of a user on a device, kept alive by rotating refresh tokens
+type Session struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"-"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	Current    bool       `json:"current"` // the session of the token used for the request
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"-"`
+}
+
+// RefreshToken is a stored refresh token, identified by the hash of the token
+type RefreshToken struct {
+	ID        string
+	SessionID string
+	UsedAt    *time.Time
+	Session   Session
+}
+
+// SessionDevice describes the client a session was opened or refreshed from
+type SessionDevice struct {
+	UserAgent string
+	IPAddress string
+}
+
+type TokenPair struct {
+	Token        string `json:"token"` // short-lived access token
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"` // seconds until the access token expires
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
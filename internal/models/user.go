@@ -5,12 +5,29 @@ import (
 )
 
 type User struct {
-	ID           string    `json:"id"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"-"`
-	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           string     `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email,omitempty"`
+	PasswordHash string     `json:"-"`
+	Role         string     `json:"role"`
+	MFASecret    string     `json:"-"` // TOTP secret, pending until MFAEnabledAt is set
+	MFAEnabledAt *time.Time `json:"-"`
+	// DisabledAt is set while an admin has disabled the account
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	// PasswordResetRequired blocks logins until the password is reset with a reset token
+	PasswordResetRequired bool      `json:"password_reset_required"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// Disabled tells whether an admin has disabled the account
+func (u *User) Disabled() bool {
+	return u.DisabledAt != nil
+}
+
+// MFAEnabled tells whether the user confirmed a TOTP secret and has to give a code to log in
+func (u *User) MFAEnabled() bool {
+	return u.MFAEnabledAt != nil
 }
 
 type LoginRequest struct {
@@ -18,7 +35,31 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// RegisterRequest is checked against the password policy by the user service
 type RegisterRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=50"`
-	Password string `json:"password" validate:"required,min=6,max=50"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"` // Where password reset tokens are sent
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,max=72"`
+}
+
+type ForgotPasswordRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max=72"`
+}
+
+// PasswordResetToken is a stored single-use password reset token, identified by the hash of the token
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
 }
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// User statuses to filter the user list by
+const (
+	UserStatusActive   = "active"
+	UserStatusDisabled = "disabled"
+)
+
+// UserFilter narrows the user list of the admin API
+type UserFilter struct {
+	Query  string // part of the username or the email
+	Role   string
+	Status string // active or disabled
+	Limit  int
+	Offset int
+}
+
+// UserDetail is a user with a summary of their activity
+type UserDetail struct {
+	User
+	MFAEnabled     bool       `json:"mfa_enabled"`
+	Votes          int        `json:"votes"`
+	Reviews        int        `json:"reviews"`
+	Views          int        `json:"views"`
+	ActiveSessions int        `json:"active_sessions"`
+	LastActiveAt   *time.Time `json:"last_active_at"` // last use of a session
+	LastViewedAt   *time.Time `json:"last_viewed_at"`
+}
+
+// UserVote is a movie a user voted for
+type UserVote struct {
+	MovieID string    `json:"movie_id"`
+	Title   string    `json:"title"`
+	VotedAt time.Time `json:"voted_at"`
+}
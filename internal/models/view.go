@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// ViewEvent is a single view of a movie by a viewer
+type ViewEvent struct {
+	MovieID       string    `json:"movie_id"`
+	UserID        string    `json:"user_id,omitempty"`    // Set when the viewer is logged in
+	SessionID     string    `json:"session_id,omitempty"` // Anonymous session or device fingerprint
+	IPAddress     string    `json:"ip_address,omitempty"`
+	WatchDuration int       `json:"watch_duration"` // seconds
+	ViewedAt      time.Time `json:"viewed_at"`
+}
+
+type TrackViewRequest struct {
+	SessionID     string `json:"session_id" validate:"omitempty,max=100"`
+	WatchDuration int    `json:"watch_duration" validate:"min=0"` // seconds
+}
+
+// StatsFilter narrows the view and vote statistics to a time window and a festival edition.
+// A nil bound leaves that side of the window open.
+type StatsFilter struct {
+	From         *time.Time
+	To           *time.Time
+	EditionID    string // only count the movies entered into this edition
+	RollupGenres bool   // count views of subgenres towards their parent genre
+}
+
+// HasWindow reports whether the statistics are limited to a time window
+func (f StatsFilter) HasWindow() bool {
+	return f.From != nil || f.To != nil
+}
+
+// View statistics granularities
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+type ViewStat struct {
+	BucketStart   time.Time `json:"bucket_start"`
+	Views         int64     `json:"views"`
+	UniqueViewers int64     `json:"unique_viewers"`
+	WatchSeconds  int64     `json:"watch_seconds"`
+	Completions   int64     `json:"completions"` // watches that passed the completion threshold
+}
+
+type MovieViewStats struct {
+	MovieID       string     `json:"movie_id"`
+	Granularity   string     `json:"granularity"`
+	From          *time.Time `json:"from,omitempty"`
+	To            *time.Time `json:"to,omitempty"`
+	TotalViews    int64      `json:"total_views"`
+	UniqueViewers int64      `json:"unique_viewers"`
+	Completions   int64      `json:"completions"`
+	Buckets       []ViewStat `json:"buckets"`
+}
+
+// SuspectedViewInflation is a client address that produced an unusual share of the views of a movie
+type SuspectedViewInflation struct {
+	MovieID       string  `json:"movie_id"`
+	Title         string  `json:"title"`
+	IPAddress     string  `json:"ip_address"`
+	Views         int64   `json:"views"`
+	UniqueViewers int64   `json:"unique_viewers"` // distinct users and sessions behind the address
+	TotalViews    int64   `json:"total_views"`    // views of the movie from every address in the window
+	Share         float64 `json:"share"`          // Views / TotalViews
+}
@@ -0,0 +1,107 @@
+package models
+
+import "time"
+
+// Ballot modes of a voting period
+const (
+	BallotModeApproval = "approval" // one vote per movie
+	BallotModeRanked   = "ranked"   // one ballot ordering up to MaxRankings movies
+)
+
+// Methods to tally the ballots of a voting period
+const (
+	TallyMethodApproval      = "approval" // the only method of approval periods
+	TallyMethodInstantRunoff = "instant_runoff"
+	TallyMethodBorda         = "borda"
+)
+
+// VotingPeriod is when the audience can vote for the entries of an edition, or only for the nominees of one of its sections
+type VotingPeriod struct {
+	ID          string    `json:"id"`
+	EditionID   string    `json:"edition_id"`
+	EditionName string    `json:"edition_name"`
+	SectionID   string    `json:"section_id,omitempty"`
+	SectionName string    `json:"section_name,omitempty"`
+	OpensAt     time.Time `json:"opens_at"`
+	ClosesAt    time.Time `json:"closes_at"`
+	BallotMode  string    `json:"ballot_mode"`
+	// MaxRankings is how many movies a ranked ballot can order, 0 for approval periods
+	MaxRankings int `json:"max_rankings,omitempty"`
+	// VoteBudget is how many votes a user can cast in an approval period, nil when unlimited
+	VoteBudget *int      `json:"vote_budget"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Open tells whether the period accepts votes at the given time, it opens at OpensAt and closes at ClosesAt
+func (p *VotingPeriod) Open(at time.Time) bool {
+	return !at.Before(p.OpensAt) && at.Before(p.ClosesAt)
+}
+
+// UserVotingPeriod is an open voting period with the votes or the ballot a user cast in it
+type UserVotingPeriod struct {
+	VotingPeriod
+	VotesUsed int `json:"votes_used"`
+	// VotesLeft is nil when the budget is unlimited
+	VotesLeft *int `json:"votes_left"`
+	// Ballot is the ballot of the user in a ranked period, nil until they cast one
+	Ballot *Ballot `json:"ballot,omitempty"`
+}
+
+// Ballot is the order of preference of a user among the movies of a ranked voting period
+type Ballot struct {
+	ID             string          `json:"id"`
+	VotingPeriodID string          `json:"voting_period_id"`
+	UserID         string          `json:"user_id"`
+	Rankings       []BallotRanking `json:"rankings"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// BallotRanking is a movie of a ballot, position 1 is the first preference
+type BallotRanking struct {
+	Position int    `json:"position"`
+	MovieID  string `json:"movie_id"`
+	Title    string `json:"title"`
+}
+
+// BallotTally is the result of the ballots of a voting period, round by round
+type BallotTally struct {
+	VotingPeriodID string       `json:"voting_period_id"`
+	BallotMode     string       `json:"ballot_mode"`
+	Method         string       `json:"method"`
+	Ballots        int          `json:"ballots"`
+	Rounds         []TallyRound `json:"rounds"`
+	// Winners has more than one movie when they tie
+	Winners []TallyResult `json:"winners"`
+}
+
+// TallyRound counts the ballots among the movies still in the running,
+// approval and Borda count tallies have a single round
+type TallyRound struct {
+	Round   int           `json:"round"`
+	Results []TallyResult `json:"results"`
+	// Exhausted counts the ranked ballots without any movie left in the running
+	Exhausted  int      `json:"exhausted"`
+	Eliminated []string `json:"eliminated,omitempty"`
+}
+
+// TallyResult is the score of a movie in a round, its votes or its Borda points
+type TallyResult struct {
+	MovieID string `json:"movie_id"`
+	Title   string `json:"title"`
+	Score   int    `json:"score"`
+}
+
+type VotingPeriodRequest struct {
+	SectionID   string    `json:"section_id"`
+	OpensAt     time.Time `json:"opens_at" validate:"required"`
+	ClosesAt    time.Time `json:"closes_at" validate:"required"`
+	BallotMode  string    `json:"ballot_mode" validate:"omitempty,oneof=approval ranked"`
+	MaxRankings int       `json:"max_rankings" validate:"omitempty,min=1,max=20"`
+	VoteBudget  *int      `json:"vote_budget" validate:"omitempty,min=1"`
+}
+
+type BallotRequest struct {
+	MovieIDs []string `json:"movie_ids" validate:"required,min=1,dive,required"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// WatchProgress is the playback position of a user in a movie
+type WatchProgress struct {
+	UserID          string     `json:"-"`
+	MovieID         string     `json:"movie_id"`
+	Movie           *Movie     `json:"movie,omitempty"`
+	PositionSeconds int        `json:"position_seconds"`
+	DurationSeconds int        `json:"duration_seconds"`
+	Progress        float64    `json:"progress"` // PositionSeconds / DurationSeconds
+	Completed       bool       `json:"completed"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type WatchProgressRequest struct {
+	PositionSeconds int `json:"position_seconds" validate:"min=0"`
+}
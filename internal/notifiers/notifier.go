@@ -0,0 +1,81 @@
+package notifiers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is a notification for a user
+type Message struct {
+	UserID   string
+	Username string
+	Email    string // empty when the user did not give an address
+	Subject  string
+	Body     string
+}
+
+// Notifier delivers messages to users. Deployments plug in their own delivery, the log and file notifiers are meant for development.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// ErrNotifierNotSet is returned when NOTIFIER does not select a notifier. There is no default,
+// the log notifier writes the password reset tokens to the application log.
+var ErrNotifierNotSet = errors.New(`NOTIFIER must be "log" or "file"`)
+
+// NewNotifier returns the notifier selected by NOTIFIER: "log", or "file", which appends to NOTIFIER_FILE
+func NewNotifier() (Notifier, error) {
+	switch os.Getenv("NOTIFIER") {
+	case "log":
+		return NewLogNotifier(), nil
+	case "file":
+		path := os.Getenv("NOTIFIER_FILE")
+		if path == "" {
+			path = "notifications.log"
+		}
+		return NewFileNotifier(path), nil
+	default:
+		return nil, ErrNotifierNotSet
+	}
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier writes messages to the application log
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Send(ctx context.Context, msg Message) error {
+	log.Printf("Notification for %s <%s>: %s\n%s", msg.Username, msg.Email, msg.Subject, msg.Body)
+	return nil
+}
+
+type fileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNotifier appends messages to a file
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Send(ctx context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\nTo: %s <%s>\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), msg.Username, msg.Email, msg.Subject, msg.Body)
+	return err
+}
@@ -0,0 +1,327 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// jwksRefreshInterval limits how often an unknown key ID makes the provider keys be fetched again
+const jwksRefreshInterval = time.Minute
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	KeyType  string `json:"kty"`
+	KeyID    string `json:"kid"`
+	Use      string `json:"use"`
+	Modulus  string `json:"n"`
+	Exponent string `json:"e"`
+	Curve    string `json:"crv"`
+	X        string `json:"x"`
+	Y        string `json:"y"`
+}
+
+type idTokenClaims struct {
+	Nonce             string    `json:"nonce"`
+	Email             string    `json:"email"`
+	EmailVerified     looseBool `json:"email_verified"`
+	PreferredUsername string    `json:"preferred_username"`
+	Name              string    `json:"name"`
+	AuthorizedParty   string    `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// looseBool accepts the "true" string some providers send for boolean claims
+type looseBool bool
+
+func (b *looseBool) UnmarshalJSON(data []byte) error {
+	*b = looseBool(strings.Trim(string(data), `"`) == "true")
+	return nil
+}
+
+type provider struct {
+	config Config
+	client *http.Client
+
+	mu            sync.Mutex
+	discovery     *discovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewProvider returns a provider that discovers its endpoints from the issuer on first use
+func NewProvider(config Config) Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &provider{config: config, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (p *provider) Name() string {
+	return p.config.Name
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return d.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.config.ClientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenExchange, err)
+	}
+	defer res.Body.Close()
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&token); err != nil {
+		return nil, fmt.Errorf("%w: status %d", ErrTokenExchange, res.StatusCode)
+	}
+	if res.StatusCode != http.StatusOK || token.IDToken == "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrTokenExchange, token.Error, token.ErrorDescription)
+	}
+
+	return p.verifyIDToken(ctx, d, token.IDToken, nonce)
+}
+
+// verifyIDToken checks the signature, the issuer, the audience, the expiry and the nonce of an ID token
+func (p *provider) verifyIDToken(ctx context.Context, d *discovery, rawIDToken, nonce string) (*Identity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.getKey(ctx, d, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	switch {
+	case claims.Issuer != d.Issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	case !claims.VerifyAudience(p.config.ClientID, true):
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidIDToken)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID:
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidIDToken)
+	case claims.ExpiresAt == nil:
+		return nil, fmt.Errorf("%w: no expiry", ErrInvalidIDToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+
+	return &Identity{
+		Provider:          p.config.Name,
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     bool(claims.EmailVerified),
+		PreferredUsername: claims.PreferredUsername,
+		Name:              claims.Name,
+	}, nil
+}
+
+// getDiscovery reads the provider metadata of the issuer, once
+func (p *provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	d := &discovery{}
+	if err := p.getJSON(ctx, p.config.Issuer+"/.well-known/openid-configuration", d); err != nil {
+		return nil, fmt.Errorf("discovery of OIDC provider %s failed: %w", p.config.Name, err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("OIDC provider %s announces issuer %q instead of %q", p.config.Name, d.Issuer, p.config.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC provider %s is missing endpoints in its metadata", p.config.Name)
+	}
+
+	p.discovery = d
+	return d, nil
+}
+
+// getKey returns the signing key of the provider with the given ID, fetching the keys again when the provider rotated them
+func (p *provider) getKey(ctx context.Context, d *discovery, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := findKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := parseJWK(jwk); err == nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+
+	if key := findKey(keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// findKey looks a key up by ID, a token without kid can only use the single key of the provider
+func findKey(keys map[string]interface{}, kid string) interface{} {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+	return keys[kid]
+}
+
+func (p *provider) getJSON(ctx context.Context, url string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, res.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(dest)
+}
+
+func parseJWK(jwk jsonWebKey) (interface{}, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.Exponent)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", jwk.KeyType)
+}
@@ -0,0 +1,31 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// GenerateCodeVerifier returns a random PKCE code verifier, 43 characters of the unreserved set
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallenge derives the S256 PKCE code challenge of a code verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// GenerateNonce returns a random value binding the ID token to the authorization request
+func GenerateNonce() (string, error) {
+	return randomString(16)
+}
+
+func randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
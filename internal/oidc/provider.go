@@ -0,0 +1,79 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	ErrTokenExchange  = errors.New("authorization code exchange failed")
+)
+
+var providerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Identity is the user an OpenID Connect provider authenticated
+type Identity struct {
+	Provider          string
+	Subject           string // stable identifier of the user at the provider
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	Name              string
+}
+
+// Provider signs users in with the authorization code flow and PKCE
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns where to send the user to authenticate, the provider redirects back with the code and the state
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange trades the authorization code for the ID token and returns the identity it asserts
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error)
+}
+
+// Config is the client registration of the application at a provider
+type Config struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string // empty for public clients
+	RedirectURL  string
+	Scopes       []string
+}
+
+// NewProvidersFromEnv loads the providers named in OIDC_PROVIDERS, a comma-separated list.
+// Every provider is configured by OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET,
+// OIDC_<NAME>_REDIRECT_URL and OIDC_<NAME>_SCOPES (default "openid email profile").
+func NewProvidersFromEnv() (map[string]Provider, error) {
+	providers := map[string]Provider{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !providerNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid OIDC provider name %q", name)
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := Config{
+			Name:         name,
+			Issuer:       strings.TrimSuffix(os.Getenv(prefix+"ISSUER"), "/"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if config.Issuer == "" || config.ClientID == "" || config.RedirectURL == "" {
+			return nil, fmt.Errorf("OIDC provider %s needs %sISSUER, %sCLIENT_ID and %sREDIRECT_URL", name, prefix, prefix, prefix)
+		}
+
+		providers[name] = NewProvider(config)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,283 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type ArtistRepository interface {
+	Create(ctx context.Context, artist *models.ArtistProfile) error
+	Update(ctx context.Context, artist *models.ArtistProfile) error
+	Delete(ctx context.Context, artistID string) error
+	FindByID(ctx context.Context, artistID string) (*models.ArtistProfile, error)
+	FindByName(ctx context.Context, name string) (*models.ArtistProfile, error)
+	List(ctx context.Context, query string, limit, offset int) ([]models.ArtistProfile, error)
+	Merge(ctx context.Context, targetID string, sourceIDs []string) error
+	GetFilmography(ctx context.Context, artistID string) ([]models.FilmographyItem, error)
+}
+
+type artistRepository struct {
+	db *sql.DB
+}
+
+func NewArtistRepository(db *sql.DB) ArtistRepository {
+	return &artistRepository{db}
+}
+
+const selectArtistProfile = `SELECT id, name, bio, birth_date, photo_url, external_ids, created_at, updated_at FROM artists`
+
+func (r *artistRepository) Create(ctx context.Context, artist *models.ArtistProfile) error {
+	externalIDs, err := marshalExternalIDs(artist.ExternalIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO artists (id, name, bio, birth_date, photo_url, external_ids) VALUES (?, ?, ?, ?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, artist.ID, artist.Name, artist.Bio, artist.BirthDate, artist.PhotoURL, externalIDs)
+	return err
+}
+
+// Update overwrites the profile of an artist.
+// It returns sql.ErrNoRows when the artist does not exist.
+func (r *artistRepository) Update(ctx context.Context, artist *models.ArtistProfile) error {
+	externalIDs, err := marshalExternalIDs(artist.ExternalIDs)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the artist exists, an update with unchanged values affects no rows
+	if _, err := r.FindByID(ctx, artist.ID); err != nil {
+		return err
+	}
+
+	query := `UPDATE artists SET name = ?, bio = ?, birth_date = ?, photo_url = ?, external_ids = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, artist.Name, artist.Bio, artist.BirthDate, artist.PhotoURL, externalIDs, artist.ID)
+	return err
+}
+
+// Delete removes an artist and all of its movie credits.
+// It returns sql.ErrNoRows when the artist does not exist.
+func (r *artistRepository) Delete(ctx context.Context, artistID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM movie_artists WHERE artist_id = ?", artistID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM artists WHERE id = ?", artistID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *artistRepository) FindByID(ctx context.Context, artistID string) (*models.ArtistProfile, error) {
+	row := r.db.QueryRowContext(ctx, selectArtistProfile+" WHERE id = ?", artistID)
+	return scanArtistProfile(row)
+}
+
+// FindByName returns the artist with the exact given name, or nil when there is none.
+func (r *artistRepository) FindByName(ctx context.Context, name string) (*models.ArtistProfile, error) {
+	row := r.db.QueryRowContext(ctx, selectArtistProfile+" WHERE name = ? LIMIT 1", name)
+	artist, err := scanArtistProfile(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return artist, err
+}
+
+// List retrieves the artists whose name contains query, the LIKE wildcards in query match themselves
+func (r *artistRepository) List(ctx context.Context, query string, limit, offset int) ([]models.ArtistProfile, error) {
+	rows, err := r.db.QueryContext(ctx,
+		selectArtistProfile+" WHERE name LIKE ? ORDER BY name LIMIT ? OFFSET ?",
+		containsPattern(query), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	artists := []models.ArtistProfile{}
+	for rows.Next() {
+		artist, err := scanArtistProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		artists = append(artists, *artist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return artists, nil
+}
+
+// Merge moves every credit of the source artists onto the target artist and removes the sources.
+// Credits the target already holds on the same movie with the same role are dropped.
+func (r *artistRepository) Merge(ctx context.Context, targetID string, sourceIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var id string
+	if err = tx.QueryRowContext(ctx, "SELECT id FROM artists WHERE id = ? FOR UPDATE", targetID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, sourceID := range sourceIDs {
+		_, err = tx.ExecContext(ctx, `
+			INSERT IGNORE INTO movie_artists (movie_id, artist_id, role, billing_order)
+			SELECT movie_id, ?, role, billing_order FROM movie_artists WHERE artist_id = ?`,
+			targetID, sourceID)
+		if err != nil {
+			tx.Rollback()
+			log.Printf("Error re-pointing credits of artist %s: %v", sourceID, err)
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, "DELETE FROM movie_artists WHERE artist_id = ?", sourceID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, "DELETE FROM artists WHERE id = ?", sourceID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err = checkRowsAffected(res); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetFilmography retrieves the active movies an artist is credited on, with their roles, views and votes.
+func (r *artistRepository) GetFilmography(ctx context.Context, artistID string) ([]models.FilmographyItem, error) {
+	query := `
+		SELECT m.id, m.title, ma.role,
+			COALESCE((SELECT SUM(mv.view_count) FROM movie_views mv WHERE mv.movie_id = m.id), 0) AS views,
+			(SELECT COUNT(*) FROM votes v WHERE v.movie_id = m.id) AS votes
+		FROM movie_artists ma
+		JOIN movies m ON m.id = ma.movie_id
+		WHERE ma.artist_id = ? AND m.deleted_at IS NULL
+		ORDER BY m.created_at DESC, m.id, ma.billing_order
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, artistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	// An artist can hold several roles on one movie, group them per movie
+	filmography := []models.FilmographyItem{}
+	for rows.Next() {
+		var item models.FilmographyItem
+		var role string
+		if err := rows.Scan(&item.MovieID, &item.Title, &role, &item.Views, &item.Votes); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		last := len(filmography) - 1
+		if last >= 0 && filmography[last].MovieID == item.MovieID {
+			filmography[last].Roles = append(filmography[last].Roles, role)
+			continue
+		}
+
+		item.Roles = []string{role}
+		filmography = append(filmography, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return filmography, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanArtistProfile(row rowScanner) (*models.ArtistProfile, error) {
+	var artist models.ArtistProfile
+	var bio, photoURL sql.NullString
+	var birthDate sql.NullTime
+	var externalIDs []byte
+
+	err := row.Scan(
+		&artist.ID,
+		&artist.Name,
+		&bio,
+		&birthDate,
+		&photoURL,
+		&externalIDs,
+		&artist.CreatedAt,
+		&artist.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	artist.Bio = bio.String
+	artist.PhotoURL = photoURL.String
+	if birthDate.Valid {
+		artist.BirthDate = &birthDate.Time
+	}
+
+	artist.ExternalIDs = map[string]string{}
+	if len(externalIDs) > 0 {
+		if err := json.Unmarshal(externalIDs, &artist.ExternalIDs); err != nil {
+			return nil, fmt.Errorf("failed to decode external ids: %w", err)
+		}
+	}
+
+	return &artist, nil
+}
+
+func marshalExternalIDs(externalIDs map[string]string) (interface{}, error) {
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(externalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
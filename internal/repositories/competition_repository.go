@@ -0,0 +1,406 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type CompetitionRepository interface {
+	ListSections(ctx context.Context, editionID string) ([]models.Section, error)
+	FindSection(ctx context.Context, sectionID string) (*models.Section, error)
+	FindSectionByName(ctx context.Context, editionID, name string) (*models.Section, error)
+	CreateSection(ctx context.Context, section *models.Section) error
+	UpdateSection(ctx context.Context, section *models.Section) error
+	DeleteSection(ctx context.Context, sectionID string) error
+	ListNominees(ctx context.Context, sectionID string) ([]models.Nominee, error)
+	NominateMovies(ctx context.Context, sectionID string, movieIDs []string) error
+	RemoveNomination(ctx context.Context, sectionID, movieID string) error
+	ListCategories(ctx context.Context, sectionID string) ([]models.AwardCategory, error)
+	FindCategory(ctx context.Context, categoryID string) (*models.AwardCategory, error)
+	FindCategoryByName(ctx context.Context, sectionID, name string) (*models.AwardCategory, error)
+	CreateCategory(ctx context.Context, category *models.AwardCategory) error
+	UpdateCategory(ctx context.Context, category *models.AwardCategory) error
+	DeleteCategory(ctx context.Context, categoryID string) error
+	AddWinner(ctx context.Context, categoryID, movieID string) error
+	RemoveWinner(ctx context.Context, categoryID, movieID string) error
+	ListWinners(ctx context.Context, editionID string, statuses ...string) ([]models.AwardWinner, error)
+}
+
+type competitionRepository struct {
+	db *sql.DB
+}
+
+func NewCompetitionRepository(db *sql.DB) CompetitionRepository {
+	return &competitionRepository{db}
+}
+
+const selectSection = "SELECT s.id, s.edition_id, s.name, s.description, s.scoring_closed_at, s.created_at FROM competition_sections s"
+
+const selectCategory = "SELECT id, section_id, name, description, created_at FROM award_categories"
+
+// ListSections lists the sections of an edition in the order they were created.
+func (r *competitionRepository) ListSections(ctx context.Context, editionID string) ([]models.Section, error) {
+	rows, err := r.db.QueryContext(ctx, selectSection+" WHERE s.edition_id = ? ORDER BY s.created_at, s.name", editionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	sections := []models.Section{}
+	for rows.Next() {
+		section, err := scanSection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		sections = append(sections, *section)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return sections, nil
+}
+
+func (r *competitionRepository) FindSection(ctx context.Context, sectionID string) (*models.Section, error) {
+	row := r.db.QueryRowContext(ctx, selectSection+" WHERE s.id = ?", sectionID)
+	return scanSection(row)
+}
+
+// FindSectionByName returns the section of the edition with the given name, or nil when there is none.
+func (r *competitionRepository) FindSectionByName(ctx context.Context, editionID, name string) (*models.Section, error) {
+	row := r.db.QueryRowContext(ctx, selectSection+" WHERE s.edition_id = ? AND s.name = ? LIMIT 1", editionID, name)
+	section, err := scanSection(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return section, err
+}
+
+func (r *competitionRepository) CreateSection(ctx context.Context, section *models.Section) error {
+	query := "INSERT INTO competition_sections (id, edition_id, name, description) VALUES (?, ?, ?, ?)"
+	_, err := r.db.ExecContext(ctx, query, section.ID, section.EditionID, section.Name, nullString(section.Description))
+	return err
+}
+
+// UpdateSection renames a section and changes its description.
+// It returns sql.ErrNoRows when the section does not exist.
+func (r *competitionRepository) UpdateSection(ctx context.Context, section *models.Section) error {
+	// Make sure the section exists, an update with unchanged values affects no rows
+	if _, err := r.FindSection(ctx, section.ID); err != nil {
+		return err
+	}
+
+	query := "UPDATE competition_sections SET name = ?, description = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, section.Name, nullString(section.Description), section.ID)
+	return err
+}
+
+// DeleteSection deletes a section, its nominations, award categories and winners go with it.
+// It returns sql.ErrNoRows when the section does not exist.
+func (r *competitionRepository) DeleteSection(ctx context.Context, sectionID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM competition_sections WHERE id = ?", sectionID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// ListNominees lists the movies nominated in a section by title.
+func (r *competitionRepository) ListNominees(ctx context.Context, sectionID string) ([]models.Nominee, error) {
+	query := `
+		SELECT m.id, m.title, n.nominated_at
+		FROM section_nominations n
+		JOIN movies m ON n.movie_id = m.id
+		WHERE n.section_id = ? AND m.deleted_at IS NULL
+		ORDER BY m.title
+	`
+	rows, err := r.db.QueryContext(ctx, query, sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	nominees := []models.Nominee{}
+	for rows.Next() {
+		var nominee models.Nominee
+		if err := rows.Scan(&nominee.MovieID, &nominee.Title, &nominee.NominatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		nominees = append(nominees, nominee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return nominees, nil
+}
+
+// NominateMovies nominates movies in a section, movies already nominated are kept.
+// It returns sql.ErrNoRows when one of the movies is not entered into the edition of the section,
+// without nominating any of them.
+func (r *competitionRepository) NominateMovies(ctx context.Context, sectionID string, movieIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		SELECT em.movie_id
+		FROM competition_sections s
+		JOIN edition_movies em ON s.edition_id = em.edition_id
+		JOIN movies m ON em.movie_id = m.id
+		WHERE s.id = ? AND em.movie_id = ? AND m.deleted_at IS NULL
+	`
+	for _, movieID := range movieIDs {
+		var id string
+		if err = tx.QueryRowContext(ctx, query, sectionID, movieID).Scan(&id); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, "INSERT IGNORE INTO section_nominations (section_id, movie_id) VALUES (?, ?)", sectionID, movieID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RemoveNomination removes a movie from a section together with the awards it won in the section.
+// It returns sql.ErrNoRows when the movie is not nominated in the section.
+func (r *competitionRepository) RemoveNomination(ctx context.Context, sectionID, movieID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM section_nominations WHERE section_id = ? AND movie_id = ?", sectionID, movieID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := "DELETE FROM award_winners WHERE movie_id = ? AND category_id IN (SELECT id FROM award_categories WHERE section_id = ?)"
+	if _, err = tx.ExecContext(ctx, query, movieID, sectionID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListCategories lists the award categories of a section in the order they were created.
+func (r *competitionRepository) ListCategories(ctx context.Context, sectionID string) ([]models.AwardCategory, error) {
+	rows, err := r.db.QueryContext(ctx, selectCategory+" WHERE section_id = ? ORDER BY created_at, name", sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []models.AwardCategory{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		categories = append(categories, *category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *competitionRepository) FindCategory(ctx context.Context, categoryID string) (*models.AwardCategory, error) {
+	row := r.db.QueryRowContext(ctx, selectCategory+" WHERE id = ?", categoryID)
+	return scanCategory(row)
+}
+
+// FindCategoryByName returns the award category of the section with the given name, or nil when there is none.
+func (r *competitionRepository) FindCategoryByName(ctx context.Context, sectionID, name string) (*models.AwardCategory, error) {
+	row := r.db.QueryRowContext(ctx, selectCategory+" WHERE section_id = ? AND name = ? LIMIT 1", sectionID, name)
+	category, err := scanCategory(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return category, err
+}
+
+func (r *competitionRepository) CreateCategory(ctx context.Context, category *models.AwardCategory) error {
+	query := "INSERT INTO award_categories (id, section_id, name, description) VALUES (?, ?, ?, ?)"
+	_, err := r.db.ExecContext(ctx, query, category.ID, category.SectionID, category.Name, nullString(category.Description))
+	return err
+}
+
+// UpdateCategory renames an award category and changes its description.
+// It returns sql.ErrNoRows when the category does not exist.
+func (r *competitionRepository) UpdateCategory(ctx context.Context, category *models.AwardCategory) error {
+	// Make sure the category exists, an update with unchanged values affects no rows
+	if _, err := r.FindCategory(ctx, category.ID); err != nil {
+		return err
+	}
+
+	query := "UPDATE award_categories SET name = ?, description = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, category.Name, nullString(category.Description), category.ID)
+	return err
+}
+
+// DeleteCategory deletes an award category and its winners.
+// It returns sql.ErrNoRows when the category does not exist.
+func (r *competitionRepository) DeleteCategory(ctx context.Context, categoryID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM award_categories WHERE id = ?", categoryID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// AddWinner records a movie as a winner of an award category, recording it twice changes nothing.
+// It returns sql.ErrNoRows when the movie is not nominated in the section of the category.
+func (r *competitionRepository) AddWinner(ctx context.Context, categoryID, movieID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		SELECT n.movie_id
+		FROM award_categories c
+		JOIN section_nominations n ON c.section_id = n.section_id
+		WHERE c.id = ? AND n.movie_id = ?
+	`
+	var id string
+	if err = tx.QueryRowContext(ctx, query, categoryID, movieID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "INSERT IGNORE INTO award_winners (category_id, movie_id) VALUES (?, ?)", categoryID, movieID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveWinner removes a movie from the winners of an award category.
+// It returns sql.ErrNoRows when the movie did not win the award.
+func (r *competitionRepository) RemoveWinner(ctx context.Context, categoryID, movieID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM award_winners WHERE category_id = ? AND movie_id = ?", categoryID, movieID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// ListWinners lists the award winners of an edition, or of every edition when editionID is empty,
+// optionally only of editions with one of the given statuses.
+// Winners are ordered by edition, the latest first, then by section, category and title.
+func (r *competitionRepository) ListWinners(ctx context.Context, editionID string, statuses ...string) ([]models.AwardWinner, error) {
+	query := `
+		SELECT e.id, e.name, s.id, s.name, c.id, c.name, m.id, m.title, w.awarded_at
+		FROM award_winners w
+		JOIN award_categories c ON w.category_id = c.id
+		JOIN competition_sections s ON c.section_id = s.id
+		JOIN festival_editions e ON s.edition_id = e.id
+		JOIN movies m ON w.movie_id = m.id
+		WHERE m.deleted_at IS NULL`
+	args := make([]interface{}, 0, len(statuses)+1)
+	if editionID != "" {
+		query += " AND e.id = ?"
+		args = append(args, editionID)
+	}
+	if len(statuses) > 0 {
+		query += " AND e.status IN (?" + strings.Repeat(", ?", len(statuses)-1) + ")"
+		for _, status := range statuses {
+			args = append(args, status)
+		}
+	}
+	query += " ORDER BY e.starts_on DESC, e.name, s.created_at, s.name, c.created_at, c.name, m.title"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	winners := []models.AwardWinner{}
+	for rows.Next() {
+		var winner models.AwardWinner
+		err := rows.Scan(&winner.EditionID, &winner.EditionName, &winner.SectionID, &winner.SectionName,
+			&winner.CategoryID, &winner.CategoryName, &winner.MovieID, &winner.Title, &winner.AwardedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		winners = append(winners, winner)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return winners, nil
+}
+
+func scanSection(row rowScanner) (*models.Section, error) {
+	var section models.Section
+	var description sql.NullString
+	var scoringClosedAt sql.NullTime
+	err := row.Scan(&section.ID, &section.EditionID, &section.Name, &description, &scoringClosedAt, &section.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	section.Description = description.String
+	if scoringClosedAt.Valid {
+		section.ScoringClosedAt = &scoringClosedAt.Time
+	}
+	return &section, nil
+}
+
+func scanCategory(row rowScanner) (*models.AwardCategory, error) {
+	var category models.AwardCategory
+	var description sql.NullString
+	if err := row.Scan(&category.ID, &category.SectionID, &category.Name, &description, &category.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	category.Description = description.String
+	return &category, nil
+}
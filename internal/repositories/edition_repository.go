@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type EditionRepository interface {
+	List(ctx context.Context, statuses ...string) ([]models.Edition, error)
+	FindByID(ctx context.Context, editionID string) (*models.Edition, error)
+	FindByName(ctx context.Context, name string) (*models.Edition, error)
+	Create(ctx context.Context, edition *models.Edition) error
+	Update(ctx context.Context, edition *models.Edition) error
+	SetStatus(ctx context.Context, editionID, status string) error
+	ListEntries(ctx context.Context, editionID string) ([]models.Movie, error)
+	EnterMovies(ctx context.Context, editionID string, movieIDs []string) error
+	WithdrawMovie(ctx context.Context, editionID, movieID string) error
+}
+
+type editionRepository struct {
+	db *sql.DB
+}
+
+func NewEditionRepository(db *sql.DB) EditionRepository {
+	return &editionRepository{db}
+}
+
+const selectEdition = `
+	SELECT e.id, e.name, e.starts_on, e.ends_on, e.status, e.opened_at, e.closed_at, e.created_at, e.updated_at,
+		(SELECT COUNT(*) FROM edition_movies em JOIN movies m ON em.movie_id = m.id
+			WHERE em.edition_id = e.id AND m.deleted_at IS NULL) AS movies
+	FROM festival_editions e`
+
+// List lists the editions with one of the given statuses, or every edition, the latest first.
+func (r *editionRepository) List(ctx context.Context, statuses ...string) ([]models.Edition, error) {
+	query := selectEdition
+	args := make([]interface{}, 0, len(statuses))
+	if len(statuses) > 0 {
+		query += " WHERE e.status IN (?" + strings.Repeat(", ?", len(statuses)-1) + ")"
+		for _, status := range statuses {
+			args = append(args, status)
+		}
+	}
+	query += " ORDER BY e.starts_on DESC, e.name"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	editions := []models.Edition{}
+	for rows.Next() {
+		edition, err := scanEdition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		editions = append(editions, *edition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return editions, nil
+}
+
+func (r *editionRepository) FindByID(ctx context.Context, editionID string) (*models.Edition, error) {
+	row := r.db.QueryRowContext(ctx, selectEdition+" WHERE e.id = ?", editionID)
+	return scanEdition(row)
+}
+
+// FindByName returns the edition with the given name, or nil when there is none.
+func (r *editionRepository) FindByName(ctx context.Context, name string) (*models.Edition, error) {
+	row := r.db.QueryRowContext(ctx, selectEdition+" WHERE e.name = ? LIMIT 1", name)
+	edition, err := scanEdition(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return edition, err
+}
+
+func (r *editionRepository) Create(ctx context.Context, edition *models.Edition) error {
+	query := "INSERT INTO festival_editions (id, name, starts_on, ends_on, status) VALUES (?, ?, ?, ?, ?)"
+	_, err := r.db.ExecContext(ctx, query, edition.ID, edition.Name, edition.StartsOn, edition.EndsOn, edition.Status)
+	return err
+}
+
+// Update renames an edition and moves its dates.
+// It returns sql.ErrNoRows when the edition does not exist.
+func (r *editionRepository) Update(ctx context.Context, edition *models.Edition) error {
+	// Make sure the edition exists, an update with unchanged values affects no rows
+	if _, err := r.FindByID(ctx, edition.ID); err != nil {
+		return err
+	}
+
+	query := "UPDATE festival_editions SET name = ?, starts_on = ?, ends_on = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, edition.Name, edition.StartsOn, edition.EndsOn, edition.ID)
+	return err
+}
+
+// SetStatus opens or closes an edition and records when.
+// It returns sql.ErrNoRows when the edition does not exist.
+func (r *editionRepository) SetStatus(ctx context.Context, editionID, status string) error {
+	var query string
+	switch status {
+	case models.EditionStatusOpen:
+		query = "UPDATE festival_editions SET status = ?, opened_at = NOW(), closed_at = NULL WHERE id = ?"
+	case models.EditionStatusClosed:
+		query = "UPDATE festival_editions SET status = ?, closed_at = NOW() WHERE id = ?"
+	default:
+		query = "UPDATE festival_editions SET status = ? WHERE id = ?"
+	}
+
+	res, err := r.db.ExecContext(ctx, query, status, editionID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// ListEntries lists the movies entered into an edition with their views and votes.
+func (r *editionRepository) ListEntries(ctx context.Context, editionID string) ([]models.Movie, error) {
+	query := `
+		SELECT m.id, m.title, m.description, m.duration, m.watch_url,
+			COALESCE(mv.view_count, 0) AS views,
+			(SELECT COUNT(*) FROM votes v WHERE v.movie_id = m.id) AS votes,
+			m.created_at, m.updated_at
+		FROM edition_movies em
+		JOIN movies m ON em.movie_id = m.id
+		LEFT JOIN movie_views mv ON m.id = mv.movie_id
+		WHERE em.edition_id = ? AND m.deleted_at IS NULL
+		ORDER BY m.title
+	`
+	rows, err := r.db.QueryContext(ctx, query, editionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	movies := []models.Movie{}
+	for rows.Next() {
+		var movie models.Movie
+		err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.WatchURL,
+			&movie.Views, &movie.Votes, &movie.CreatedAt, &movie.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		movies = append(movies, movie)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return movies, nil
+}
+
+// EnterMovies enters movies into an edition, movies already entered are kept.
+// It returns sql.ErrNoRows when one of the movies does not exist, without entering any of them.
+func (r *editionRepository) EnterMovies(ctx context.Context, editionID string, movieIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, movieID := range movieIDs {
+		var id string
+		err = tx.QueryRowContext(ctx, "SELECT id FROM movies WHERE id = ? AND deleted_at IS NULL", movieID).Scan(&id)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, "INSERT IGNORE INTO edition_movies (edition_id, movie_id) VALUES (?, ?)", editionID, movieID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// WithdrawMovie removes a movie from an edition, together with its nominations and awards in the edition.
+// It returns sql.ErrNoRows when the movie is not entered into the edition.
+func (r *editionRepository) WithdrawMovie(ctx context.Context, editionID, movieID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM edition_movies WHERE edition_id = ? AND movie_id = ?", editionID, movieID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	queries := []string{
+		`DELETE w FROM award_winners w
+			JOIN award_categories c ON w.category_id = c.id
+			JOIN competition_sections s ON c.section_id = s.id
+			WHERE s.edition_id = ? AND w.movie_id = ?`,
+		`DELETE n FROM section_nominations n
+			JOIN competition_sections s ON n.section_id = s.id
+			WHERE s.edition_id = ? AND n.movie_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query, editionID, movieID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func scanEdition(row rowScanner) (*models.Edition, error) {
+	var edition models.Edition
+	var openedAt, closedAt sql.NullTime
+	err := row.Scan(&edition.ID, &edition.Name, &edition.StartsOn, &edition.EndsOn, &edition.Status,
+		&openedAt, &closedAt, &edition.CreatedAt, &edition.UpdatedAt, &edition.Movies)
+	if err != nil {
+		return nil, err
+	}
+
+	if openedAt.Valid {
+		edition.OpenedAt = &openedAt.Time
+	}
+	if closedAt.Valid {
+		edition.ClosedAt = &closedAt.Time
+	}
+	return &edition, nil
+}
@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type GenreRepository interface {
+	List(ctx context.Context) ([]models.Genre, error)
+	FindByID(ctx context.Context, genreID int64) (*models.Genre, error)
+	FindByName(ctx context.Context, name string) (*models.Genre, error)
+	CountChildren(ctx context.Context, genreID int64) (int, error)
+	Create(ctx context.Context, genre *models.Genre) error
+	Update(ctx context.Context, genre *models.Genre) error
+	Delete(ctx context.Context, genreID int64) error
+	Merge(ctx context.Context, targetID int64, sourceIDs []int64) error
+}
+
+type genreRepository struct {
+	db *sql.DB
+}
+
+func NewGenreRepository(db *sql.DB) GenreRepository {
+	return &genreRepository{db}
+}
+
+func (r *genreRepository) List(ctx context.Context) ([]models.Genre, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, parent_id FROM genres ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	genres := []models.Genre{}
+	for rows.Next() {
+		genre, err := scanGenre(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		genres = append(genres, *genre)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return genres, nil
+}
+
+func (r *genreRepository) FindByID(ctx context.Context, genreID int64) (*models.Genre, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT id, name, parent_id FROM genres WHERE id = ?", genreID)
+	return scanGenre(row)
+}
+
+// FindByName returns the genre with the given name, or nil when there is none.
+func (r *genreRepository) FindByName(ctx context.Context, name string) (*models.Genre, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT id, name, parent_id FROM genres WHERE name = ? LIMIT 1", name)
+	genre, err := scanGenre(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return genre, err
+}
+
+// CountChildren counts the subgenres of a genre.
+func (r *genreRepository) CountChildren(ctx context.Context, genreID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM genres WHERE parent_id = ?", genreID).Scan(&count)
+	return count, err
+}
+
+func (r *genreRepository) Create(ctx context.Context, genre *models.Genre) error {
+	res, err := r.db.ExecContext(ctx, "INSERT INTO genres (name, parent_id) VALUES (?, ?)", genre.Name, genre.ParentID)
+	if err != nil {
+		return err
+	}
+
+	genre.ID, err = res.LastInsertId()
+	return err
+}
+
+// Update renames a genre and sets its parent.
+// It returns sql.ErrNoRows when the genre does not exist.
+func (r *genreRepository) Update(ctx context.Context, genre *models.Genre) error {
+	// Make sure the genre exists, an update with unchanged values affects no rows
+	if _, err := r.FindByID(ctx, genre.ID); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, "UPDATE genres SET name = ?, parent_id = ? WHERE id = ?", genre.Name, genre.ParentID, genre.ID)
+	return err
+}
+
+// Delete removes a genre and unlinks it from its movies. Its subgenres become top-level genres.
+// It returns sql.ErrNoRows when the genre does not exist.
+func (r *genreRepository) Delete(ctx context.Context, genreID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	queries := []string{
+		"DELETE FROM movie_genres WHERE genre_id = ?",
+		"UPDATE genres SET parent_id = NULL WHERE parent_id = ?",
+	}
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query, genreID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM genres WHERE id = ?", genreID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Merge re-points the movies and subgenres of the source genres to the target genre and removes the sources.
+func (r *genreRepository) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var id int64
+	if err = tx.QueryRowContext(ctx, "SELECT id FROM genres WHERE id = ? FOR UPDATE", targetID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, sourceID := range sourceIDs {
+		_, err = tx.ExecContext(ctx, `
+			INSERT IGNORE INTO movie_genres (movie_id, genre_id)
+			SELECT movie_id, ? FROM movie_genres WHERE genre_id = ?`,
+			targetID, sourceID)
+		if err != nil {
+			tx.Rollback()
+			log.Printf("Error re-pointing movies of genre %d: %v", sourceID, err)
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, "DELETE FROM movie_genres WHERE genre_id = ?", sourceID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		// Subgenres of the source now belong to the target
+		if _, err = tx.ExecContext(ctx, "UPDATE genres SET parent_id = ? WHERE parent_id = ?", targetID, sourceID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, "DELETE FROM genres WHERE id = ?", sourceID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err = checkRowsAffected(res); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// The target must not end up as its own parent after taking over the subgenres
+	if _, err = tx.ExecContext(ctx, "UPDATE genres SET parent_id = NULL WHERE id = ? AND parent_id = id", targetID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func scanGenre(row rowScanner) (*models.Genre, error) {
+	var genre models.Genre
+	var parentID sql.NullInt64
+	if err := row.Scan(&genre.ID, &genre.Name, &parentID); err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		genre.ParentID = &parentID.Int64
+	}
+
+	return &genre, nil
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type IdentityRepository interface {
+	FindIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error)
+	LinkIdentity(ctx context.Context, identity *models.UserIdentity) error
+	CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error
+	TouchIdentity(ctx context.Context, identityID, email string) error
+}
+
+type identityRepository struct {
+	db *sql.DB
+}
+
+func NewIdentityRepository(db *sql.DB) IdentityRepository {
+	return &identityRepository{db}
+}
+
+const selectIdentity = "SELECT id, user_id, provider, subject, email, created_at, last_login_at FROM user_identities"
+
+// FindIdentity returns the identity of a provider account.
+// It returns sql.ErrNoRows when the account is not linked to a user.
+func (r *identityRepository) FindIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	row := r.db.QueryRowContext(ctx, selectIdentity+" WHERE provider = ? AND subject = ?", provider, subject)
+	return scanIdentity(row)
+}
+
+// ListIdentities lists the provider accounts linked to a user.
+func (r *identityRepository) ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error) {
+	rows, err := r.db.QueryContext(ctx, selectIdentity+" WHERE user_id = ? ORDER BY provider", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []models.UserIdentity{}
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		identities = append(identities, *identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return identities, nil
+}
+
+// LinkIdentity links a provider account to an existing user.
+func (r *identityRepository) LinkIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	return insertIdentity(ctx, r.db, identity)
+}
+
+// CreateUserWithIdentity provisions a user for a provider account on its first login.
+func (r *identityRepository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.PasswordHash), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := "INSERT INTO users (id, username, email, password_hash, role) VALUES (?, ?, ?, ?, ?)"
+	if _, err = tx.ExecContext(ctx, query, user.ID, user.Username, nullString(user.Email), hashedPassword, user.Role); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = insertIdentity(ctx, tx, identity); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TouchIdentity records a login with a provider account and the email address the provider gave.
+func (r *identityRepository) TouchIdentity(ctx context.Context, identityID, email string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE user_identities SET last_login_at = NOW(), email = ? WHERE id = ?", nullString(email), identityID)
+	return err
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertIdentity(ctx context.Context, db execer, identity *models.UserIdentity) error {
+	query := "INSERT INTO user_identities (id, user_id, provider, subject, email, last_login_at) VALUES (?, ?, ?, ?, ?, NOW())"
+	_, err := db.ExecContext(ctx, query, identity.ID, identity.UserID, identity.Provider, identity.Subject, nullString(identity.Email))
+	return err
+}
+
+func scanIdentity(row rowScanner) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	var email sql.NullString
+	var lastLoginAt sql.NullTime
+	if err := row.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject, &email, &identity.CreatedAt, &lastLoginAt); err != nil {
+		return nil, err
+	}
+
+	identity.Email = email.String
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+	return &identity, nil
+}
@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type JuryRepository interface {
+	ListJurors(ctx context.Context, sectionID string) ([]models.Juror, error)
+	IsJuror(ctx context.Context, sectionID, userID string) (bool, error)
+	AssignJuror(ctx context.Context, sectionID, userID string) error
+	RemoveJuror(ctx context.Context, sectionID, userID string) error
+	ListJurorSections(ctx context.Context, userID string) ([]models.Section, error)
+	SetScoringClosed(ctx context.Context, sectionID string, closed bool) error
+	ListCriteria(ctx context.Context, sectionID string) ([]models.ScoringCriterion, error)
+	FindCriterion(ctx context.Context, criterionID string) (*models.ScoringCriterion, error)
+	FindCriterionByName(ctx context.Context, sectionID, name string) (*models.ScoringCriterion, error)
+	CreateCriterion(ctx context.Context, criterion *models.ScoringCriterion) error
+	DeleteCriterion(ctx context.Context, criterionID string) error
+	SaveScores(ctx context.Context, userID, movieID string, scores []models.CriterionScore) error
+	ListScores(ctx context.Context, sectionID, userID string) ([]models.JuryScore, error)
+}
+
+type juryRepository struct {
+	db *sql.DB
+}
+
+func NewJuryRepository(db *sql.DB) JuryRepository {
+	return &juryRepository{db}
+}
+
+const selectCriterion = "SELECT id, section_id, name, weight, created_at FROM scoring_criteria"
+
+// ListJurors lists the jurors of a section by username.
+func (r *juryRepository) ListJurors(ctx context.Context, sectionID string) ([]models.Juror, error) {
+	query := `
+		SELECT u.id, u.username, j.assigned_at
+		FROM section_jurors j
+		JOIN users u ON j.user_id = u.id
+		WHERE j.section_id = ?
+		ORDER BY u.username
+	`
+	rows, err := r.db.QueryContext(ctx, query, sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	jurors := []models.Juror{}
+	for rows.Next() {
+		var juror models.Juror
+		if err := rows.Scan(&juror.UserID, &juror.Username, &juror.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		jurors = append(jurors, juror)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return jurors, nil
+}
+
+func (r *juryRepository) IsJuror(ctx context.Context, sectionID, userID string) (bool, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM section_jurors WHERE section_id = ? AND user_id = ?"
+	if err := r.db.QueryRowContext(ctx, query, sectionID, userID).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// AssignJuror assigns a user to the jury of a section, assigning it twice changes nothing.
+func (r *juryRepository) AssignJuror(ctx context.Context, sectionID, userID string) error {
+	_, err := r.db.ExecContext(ctx, "INSERT IGNORE INTO section_jurors (section_id, user_id) VALUES (?, ?)", sectionID, userID)
+	return err
+}
+
+// RemoveJuror removes a user from the jury of a section together with the scores they gave in the section.
+// It returns sql.ErrNoRows when the user is not a juror of the section.
+func (r *juryRepository) RemoveJuror(ctx context.Context, sectionID, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM section_jurors WHERE section_id = ? AND user_id = ?", sectionID, userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := "DELETE FROM jury_scores WHERE user_id = ? AND criterion_id IN (SELECT id FROM scoring_criteria WHERE section_id = ?)"
+	if _, err = tx.ExecContext(ctx, query, userID, sectionID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListJurorSections lists the sections a user is a juror of, the latest edition first.
+func (r *juryRepository) ListJurorSections(ctx context.Context, userID string) ([]models.Section, error) {
+	query := selectSection + `
+		JOIN section_jurors j ON s.id = j.section_id
+		JOIN festival_editions e ON s.edition_id = e.id
+		WHERE j.user_id = ?
+		ORDER BY e.starts_on DESC, s.created_at, s.name`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	sections := []models.Section{}
+	for rows.Next() {
+		section, err := scanSection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		sections = append(sections, *section)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return sections, nil
+}
+
+// SetScoringClosed closes or reopens the scoring of a section.
+// It returns sql.ErrNoRows when the section does not exist.
+func (r *juryRepository) SetScoringClosed(ctx context.Context, sectionID string, closed bool) error {
+	query := "UPDATE competition_sections SET scoring_closed_at = NULL WHERE id = ?"
+	if closed {
+		query = "UPDATE competition_sections SET scoring_closed_at = NOW() WHERE id = ?"
+	}
+
+	res, err := r.db.ExecContext(ctx, query, sectionID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// ListCriteria lists the scoring criteria of a section in the order they were created.
+func (r *juryRepository) ListCriteria(ctx context.Context, sectionID string) ([]models.ScoringCriterion, error) {
+	rows, err := r.db.QueryContext(ctx, selectCriterion+" WHERE section_id = ? ORDER BY created_at, name", sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	criteria := []models.ScoringCriterion{}
+	for rows.Next() {
+		criterion, err := scanCriterion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		criteria = append(criteria, *criterion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return criteria, nil
+}
+
+func (r *juryRepository) FindCriterion(ctx context.Context, criterionID string) (*models.ScoringCriterion, error) {
+	row := r.db.QueryRowContext(ctx, selectCriterion+" WHERE id = ?", criterionID)
+	return scanCriterion(row)
+}
+
+// FindCriterionByName returns the criterion of the section with the given name, or nil when there is none.
+func (r *juryRepository) FindCriterionByName(ctx context.Context, sectionID, name string) (*models.ScoringCriterion, error) {
+	row := r.db.QueryRowContext(ctx, selectCriterion+" WHERE section_id = ? AND name = ? LIMIT 1", sectionID, name)
+	criterion, err := scanCriterion(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return criterion, err
+}
+
+func (r *juryRepository) CreateCriterion(ctx context.Context, criterion *models.ScoringCriterion) error {
+	query := "INSERT INTO scoring_criteria (id, section_id, name, weight) VALUES (?, ?, ?, ?)"
+	_, err := r.db.ExecContext(ctx, query, criterion.ID, criterion.SectionID, criterion.Name, criterion.Weight)
+	return err
+}
+
+// DeleteCriterion deletes a criterion and the scores given on it.
+// It returns sql.ErrNoRows when the criterion does not exist.
+func (r *juryRepository) DeleteCriterion(ctx context.Context, criterionID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM scoring_criteria WHERE id = ?", criterionID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// SaveScores records the scores a juror gives a movie, replacing the scores they gave before on the same criteria.
+func (r *juryRepository) SaveScores(ctx context.Context, userID, movieID string, scores []models.CriterionScore) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO jury_scores (criterion_id, movie_id, user_id, score) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE score = VALUES(score)
+	`
+	for _, score := range scores {
+		if _, err = tx.ExecContext(ctx, query, score.CriterionID, movieID, userID, score.Score); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListScores lists the scores given on the nominees of a section, only the scores of one juror when userID is set.
+func (r *juryRepository) ListScores(ctx context.Context, sectionID, userID string) ([]models.JuryScore, error) {
+	query := `
+		SELECT js.criterion_id, m.id, m.title, u.id, u.username, js.score, js.updated_at
+		FROM jury_scores js
+		JOIN scoring_criteria c ON js.criterion_id = c.id
+		JOIN section_nominations n ON c.section_id = n.section_id AND js.movie_id = n.movie_id
+		JOIN movies m ON js.movie_id = m.id
+		JOIN users u ON js.user_id = u.id
+		WHERE c.section_id = ? AND m.deleted_at IS NULL`
+	args := []interface{}{sectionID}
+	if userID != "" {
+		query += " AND js.user_id = ?"
+		args = append(args, userID)
+	}
+	query += " ORDER BY m.title, u.username, c.created_at"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	scores := []models.JuryScore{}
+	for rows.Next() {
+		var score models.JuryScore
+		err := rows.Scan(&score.CriterionID, &score.MovieID, &score.Title, &score.UserID, &score.Username, &score.Score, &score.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return scores, nil
+}
+
+func scanCriterion(row rowScanner) (*models.ScoringCriterion, error) {
+	var criterion models.ScoringCriterion
+	if err := row.Scan(&criterion.ID, &criterion.SectionID, &criterion.Name, &criterion.Weight, &criterion.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &criterion, nil
+}
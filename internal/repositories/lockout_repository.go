@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type LockoutRepository interface {
+	RecordEvent(ctx context.Context, event *models.LockoutEvent) error
+	ListEvents(ctx context.Context, scope, subject string, limit, offset int) ([]models.LockoutEvent, error)
+}
+
+type lockoutRepository struct {
+	db *sql.DB
+}
+
+func NewLockoutRepository(db *sql.DB) LockoutRepository {
+	return &lockoutRepository{db}
+}
+
+func (r *lockoutRepository) RecordEvent(ctx context.Context, event *models.LockoutEvent) error {
+	var failedAttempts sql.NullInt64
+	if event.FailedAttempts > 0 {
+		failedAttempts = sql.NullInt64{Int64: int64(event.FailedAttempts), Valid: true}
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO login_lockout_events (scope, subject, action, ip_address, failed_attempts, locked_until, admin_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		event.Scope, event.Subject, event.Action, nullString(event.IPAddress), failedAttempts, event.LockedUntil, nullString(event.AdminID))
+	if err != nil {
+		return err
+	}
+
+	event.ID, err = res.LastInsertId()
+	return err
+}
+
+// ListEvents retrieves lockout events, newest first. An empty scope or subject matches every event.
+func (r *lockoutRepository) ListEvents(ctx context.Context, scope, subject string, limit, offset int) ([]models.LockoutEvent, error) {
+	query := `
+		SELECT id, scope, subject, action, ip_address, failed_attempts, locked_until, admin_id, created_at
+		FROM login_lockout_events
+		WHERE 1 = 1`
+	args := []interface{}{}
+	if scope != "" {
+		query += " AND scope = ?"
+		args = append(args, scope)
+	}
+	if subject != "" {
+		query += " AND subject = ?"
+		args = append(args, subject)
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.LockoutEvent{}
+	for rows.Next() {
+		var event models.LockoutEvent
+		var ipAddress, adminID sql.NullString
+		var failedAttempts sql.NullInt64
+		var lockedUntil sql.NullTime
+		err := rows.Scan(&event.ID, &event.Scope, &event.Subject, &event.Action, &ipAddress, &failedAttempts, &lockedUntil, &adminID, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		event.IPAddress = ipAddress.String
+		event.FailedAttempts = int(failedAttempts.Int64)
+		event.AdminID = adminID.String
+		if lockedUntil.Valid {
+			event.LockedUntil = &lockedUntil.Time
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return events, nil
+}
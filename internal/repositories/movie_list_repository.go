@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type MovieListRepository interface {
+	ListItems(ctx context.Context, userID, list string) ([]models.MovieListItem, error)
+	FindItem(ctx context.Context, userID, list, movieID string) (*models.MovieListItem, error)
+	AddItem(ctx context.Context, userID, list, movieID string) error
+	RemoveItem(ctx context.Context, userID, list, movieID string) error
+	Reorder(ctx context.Context, userID, list string, movieIDs []string) error
+	SetWatched(ctx context.Context, userID, list, movieID string, watched bool) error
+}
+
+type movieListRepository struct {
+	db *sql.DB
+}
+
+func NewMovieListRepository(db *sql.DB) MovieListRepository {
+	return &movieListRepository{db}
+}
+
+const selectMovieListItem = `SELECT movie_id, position, watched_at, added_at FROM user_movie_lists`
+
+// ListItems retrieves the items of a list in the order chosen by the user. Only the movie ID of each item is set.
+func (r *movieListRepository) ListItems(ctx context.Context, userID, list string) ([]models.MovieListItem, error) {
+	query := selectMovieListItem + " WHERE user_id = ? AND list = ? ORDER BY position, added_at"
+	rows, err := r.db.QueryContext(ctx, query, userID, list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.MovieListItem{}
+	for rows.Next() {
+		item, err := scanMovieListItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// FindItem returns a movie of a list, or nil when the movie is not in the list.
+func (r *movieListRepository) FindItem(ctx context.Context, userID, list, movieID string) (*models.MovieListItem, error) {
+	row := r.db.QueryRowContext(ctx, selectMovieListItem+" WHERE user_id = ? AND list = ? AND movie_id = ?", userID, list, movieID)
+	item, err := scanMovieListItem(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return item, err
+}
+
+// AddItem appends a movie at the end of a list.
+func (r *movieListRepository) AddItem(ctx context.Context, userID, list, movieID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the user so concurrent additions cannot get the same position
+	var id string
+	if err = tx.QueryRowContext(ctx, "SELECT id FROM users WHERE id = ? FOR UPDATE", userID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := `
+		INSERT INTO user_movie_lists (user_id, list, movie_id, position)
+		SELECT ?, ?, ?, COALESCE(MAX(position), 0) + 1
+		FROM user_movie_lists
+		WHERE user_id = ? AND list = ?
+	`
+	if _, err = tx.ExecContext(ctx, query, userID, list, movieID, userID, list); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveItem removes a movie from a list.
+// It returns sql.ErrNoRows when the movie is not in the list.
+func (r *movieListRepository) RemoveItem(ctx context.Context, userID, list, movieID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM user_movie_lists WHERE user_id = ? AND list = ? AND movie_id = ?", userID, list, movieID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// Reorder sets the position of every movie of a list to its index in movieIDs.
+func (r *movieListRepository) Reorder(ctx context.Context, userID, list string, movieIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for i, movieID := range movieIDs {
+		_, err = tx.ExecContext(ctx, "UPDATE user_movie_lists SET position = ? WHERE user_id = ? AND list = ? AND movie_id = ?",
+			i+1, userID, list, movieID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetWatched marks a movie of a list as watched now, or as not watched.
+// It returns sql.ErrNoRows when the movie is not in the list.
+func (r *movieListRepository) SetWatched(ctx context.Context, userID, list, movieID string, watched bool) error {
+	// Make sure the item exists, marking it twice affects no rows
+	item, err := r.FindItem(ctx, userID, list, movieID)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return sql.ErrNoRows
+	}
+
+	query := "UPDATE user_movie_lists SET watched_at = NULL WHERE user_id = ? AND list = ? AND movie_id = ?"
+	if watched {
+		// Keep the first time the movie was marked as watched
+		query = "UPDATE user_movie_lists SET watched_at = COALESCE(watched_at, NOW()) WHERE user_id = ? AND list = ? AND movie_id = ?"
+	}
+
+	_, err = r.db.ExecContext(ctx, query, userID, list, movieID)
+	return err
+}
+
+func scanMovieListItem(row rowScanner) (*models.MovieListItem, error) {
+	var item models.MovieListItem
+	var watchedAt sql.NullTime
+	if err := row.Scan(&item.Movie.ID, &item.Position, &watchedAt, &item.AddedAt); err != nil {
+		return nil, err
+	}
+
+	if watchedAt.Valid {
+		item.Watched = true
+		item.WatchedAt = &watchedAt.Time
+	}
+
+	return &item, nil
+}
@@ -6,30 +6,42 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/stwrtrio/movie-festival/internal/models"
 )
 
 type MovieRepository interface {
 	Create(ctx context.Context, movie *models.Movie) error
 	Update(ctx context.Context, movie *models.Movie) error
-	GetMostViewedMovie(ctx context.Context) (*models.Movie, error)
-	GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string) ([]models.GenreView, error)
+	SoftDelete(ctx context.Context, movieID string) error
+	Restore(ctx context.Context, movieID string) error
+	Purge(ctx context.Context, movieID string) error
+	GetMostViewedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error)
+	GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string, filter models.StatsFilter) ([]models.GenreView, error)
 	GetAllMovies(ctx context.Context, limit, offset int) ([]models.Movie, error)
-	SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.Movie, error)
-	TrackMovieView(ctx context.Context, movieID string) error
+	SearchMovies(ctx context.Context, filter models.MovieSearchFilter, limit, offset int) ([]models.Movie, error)
+	TrackMovieView(ctx context.Context, view models.ViewEvent) error
+	AddViewCounts(ctx context.Context, batchID string, counts map[string]int64) error
+	RollupViewStats(ctx context.Context, since time.Time) error
+	GetMovieViewStats(ctx context.Context, movieID, granularity string, filter models.StatsFilter) (*models.MovieViewStats, error)
+	GetSuspectedViewInflation(ctx context.Context, filter models.StatsFilter, minViews int) ([]models.SuspectedViewInflation, error)
 	FindMovieByID(ctx context.Context, movieID string) (models.Movie, error)
-	FindGenreByMovieID(ctx context.Context, movieID string) (models.Genre, error)
-	FindArtistByMovieID(ctx context.Context, movieID string) (models.Artist, error)
+	FindGenresByMovieID(ctx context.Context, movieID string) ([]models.Genre, error)
+	FindArtistsByMovieID(ctx context.Context, movieID string) ([]models.Artist, error)
+	GetMovieDetail(ctx context.Context, movieID string) (*models.Movie, error)
 	GetVoteByUserAndMovie(ctx context.Context, userID, movieID string) (*models.Vote, error)
-	CreateVote(ctx context.Context, userID, movieID string) error
 	DeleteVote(ctx context.Context, voteID string) error
 	GetMoviesByIDs(ctx context.Context, movieIDs []string) ([]models.Movie, error)
 	GetUserVotedMovieIDs(ctx context.Context, userID string) ([]string, error)
-	GetMostVotedMovie(ctx context.Context) (*models.Movie, error)
+	GetMostVotedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error)
 }
 
+// linkMovieArtistQuery credits an artist on a movie with a role and billing order
+const linkMovieArtistQuery = `INSERT INTO movie_artists (movie_id, artist_id, role, billing_order)
+	VALUES (?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE billing_order = VALUES(billing_order)`
+
 type movieRepository struct {
 	db *sql.DB
 }
@@ -40,7 +52,7 @@ func NewMovieRepository(db *sql.DB) MovieRepository {
 
 func (r *movieRepository) FindMovieByID(ctx context.Context, movieID string) (models.Movie, error) {
 	var movie models.Movie
-	query := `SELECT id, title, description, duration, watch_url FROM movies WHERE id = ?`
+	query := `SELECT id, title, description, duration, watch_url FROM movies WHERE id = ? AND deleted_at IS NULL`
 	err := r.db.QueryRowContext(ctx, query, movieID).Scan(
 		&movie.ID,
 		&movie.Title,
@@ -54,40 +66,66 @@ func (r *movieRepository) FindMovieByID(ctx context.Context, movieID string) (mo
 	return movie, nil
 }
 
-func (r *movieRepository) FindGenreByMovieID(ctx context.Context, movieID string) (models.Genre, error) {
-	var genre models.Genre
-	query := `SELECT g.id, g.name
-				FROM genres g
-				JOIN movie_genres mg ON g.id = mg.genre_id
-				WHERE mg.movie_id = ?`
+// FindGenresByMovieID retrieves all genres linked to a movie.
+func (r *movieRepository) FindGenresByMovieID(ctx context.Context, movieID string) ([]models.Genre, error) {
+	return r.getGenresByMovieID(ctx, movieID)
+}
+
+// FindArtistsByMovieID retrieves all artists linked to a movie.
+func (r *movieRepository) FindArtistsByMovieID(ctx context.Context, movieID string) ([]models.Artist, error) {
+	return r.getArtistsByMovieID(ctx, movieID)
+}
 
-	err := r.db.QueryRowContext(ctx, query).Scan(
-		movieID,
-		&genre.ID,
-		&genre.Name)
+// GetMovieDetail retrieves a movie together with its genres, artists, view count and vote count.
+func (r *movieRepository) GetMovieDetail(ctx context.Context, movieID string) (*models.Movie, error) {
+	query := `
+		SELECT m.id, m.title, m.description, m.duration, m.watch_url,
+			COALESCE((SELECT SUM(mv.view_count) FROM movie_views mv WHERE mv.movie_id = m.id), 0) AS views,
+			(SELECT COUNT(*) FROM votes v WHERE v.movie_id = m.id) AS votes,
+			m.created_at, m.updated_at
+		FROM movies m
+		WHERE m.id = ? AND m.deleted_at IS NULL
+	`
+	var movie models.Movie
+	err := r.db.QueryRowContext(ctx, query, movieID).Scan(
+		&movie.ID,
+		&movie.Title,
+		&movie.Description,
+		&movie.Duration,
+		&movie.WatchURL,
+		&movie.Views,
+		&movie.Votes,
+		&movie.CreatedAt,
+		&movie.UpdatedAt)
 	if err != nil {
-		return genre, err
+		return nil, err
 	}
 
-	return genre, nil
-}
+	genres, err := r.getGenresByMovieID(ctx, movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	if genres == nil {
+		genres = []models.Genre{}
+	}
+	movie.Genres = genres
 
-func (r *movieRepository) FindArtistByMovieID(ctx context.Context, movieID string) (models.Artist, error) {
-	var artist models.Artist
-	query := `SELECT a.id, a.name
-				FROM artists a
-				JOIN movie_artists ma ON a.id = ma.artist_id
-				WHERE ma.movie_id = ?`
+	artists, err := r.getArtistsByMovieID(ctx, movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	if artists == nil {
+		artists = []models.Artist{}
+	}
+	movie.Artists = artists
 
-	err := r.db.QueryRowContext(ctx, query).Scan(
-		movieID,
-		&artist.ID,
-		&artist.Name)
+	rating, err := getRatingSummary(ctx, r.db, movie.ID)
 	if err != nil {
-		return artist, err
+		return nil, err
 	}
+	movie.Rating = rating
 
-	return artist, nil
+	return &movie, nil
 }
 
 func (r *movieRepository) Create(ctx context.Context, movie *models.Movie) error {
@@ -149,9 +187,7 @@ func (r *movieRepository) Create(ctx context.Context, movie *models.Movie) error
 			return err
 		}
 
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO movie_artists (movie_id, artist_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE movie_id=movie_id, artist_id=artist_id",
-			movie.ID, artistID)
+		_, err = tx.ExecContext(ctx, linkMovieArtistQuery, movie.ID, artistID, artist.Role, artist.BillingOrder)
 		if err != nil {
 			tx.Rollback()
 			log.Printf("Error link movie artists: %v", err)
@@ -229,9 +265,7 @@ func (r *movieRepository) Update(ctx context.Context, movie *models.Movie) error
 			return err
 		}
 
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO movie_artists (movie_id, artist_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE movie_id=movie_id, artist_id=artist_id",
-			movie.ID, artistID)
+		_, err = tx.ExecContext(ctx, linkMovieArtistQuery, movie.ID, artistID, artist.Role, artist.BillingOrder)
 		if err != nil {
 			tx.Rollback()
 			return err
@@ -245,6 +279,97 @@ func (r *movieRepository) Update(ctx context.Context, movie *models.Movie) error
 	return nil
 }
 
+// SoftDelete hides a movie from listings by setting its deleted_at timestamp.
+// It returns sql.ErrNoRows when there is no active movie with the given ID.
+func (r *movieRepository) SoftDelete(ctx context.Context, movieID string) error {
+	query := "UPDATE movies SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL"
+	res, err := r.db.ExecContext(ctx, query, movieID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// Restore makes a soft-deleted movie visible again.
+// It returns sql.ErrNoRows when there is no soft-deleted movie with the given ID.
+func (r *movieRepository) Restore(ctx context.Context, movieID string) error {
+	query := "UPDATE movies SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL"
+	res, err := r.db.ExecContext(ctx, query, movieID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// Purge permanently removes a soft-deleted movie together with its genres,
+// artists, views and votes. Active movies have to be soft-deleted first.
+func (r *movieRepository) Purge(ctx context.Context, movieID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var id string
+	err = tx.QueryRowContext(ctx, "SELECT id FROM movies WHERE id = ? AND deleted_at IS NOT NULL FOR UPDATE", movieID).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	cascadeQueries := []string{
+		"DELETE FROM movie_genres WHERE movie_id = ?",
+		"DELETE FROM movie_artists WHERE movie_id = ?",
+		"DELETE FROM movie_views WHERE movie_id = ?",
+		"DELETE FROM movie_view_events WHERE movie_id = ?",
+		"DELETE FROM movie_view_stats_hourly WHERE movie_id = ?",
+		"DELETE FROM movie_view_stats_daily WHERE movie_id = ?",
+		"DELETE FROM review_helpful_votes WHERE review_id IN (SELECT id FROM reviews WHERE movie_id = ?)",
+		"DELETE FROM review_reports WHERE review_id IN (SELECT id FROM reviews WHERE movie_id = ?)",
+		"DELETE FROM review_moderation_actions WHERE review_id IN (SELECT id FROM reviews WHERE movie_id = ?)",
+		"DELETE FROM reviews WHERE movie_id = ?",
+		"DELETE FROM user_movie_lists WHERE movie_id = ?",
+		"DELETE FROM watch_progress WHERE movie_id = ?",
+		"DELETE FROM movie_completions WHERE movie_id = ?",
+		"DELETE FROM votes WHERE movie_id = ?",
+		"DELETE FROM ballot_rankings WHERE movie_id = ?",
+		"DELETE FROM jury_scores WHERE movie_id = ?",
+		"DELETE FROM award_winners WHERE movie_id = ?",
+		"DELETE FROM section_nominations WHERE movie_id = ?",
+		"DELETE FROM edition_movies WHERE movie_id = ?",
+		"DELETE FROM movies WHERE id = ?",
+	}
+	for _, query := range cascadeQueries {
+		if _, err = tx.ExecContext(ctx, query, movieID); err != nil {
+			tx.Rollback()
+			log.Printf("Error purging movie %s: %v", movieID, err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkRowsAffected returns sql.ErrNoRows when a statement did not touch any row.
+func checkRowsAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 func (r *movieRepository) createMovieGenre(tx *sql.Tx, genre models.Genre) (int64, *sql.Tx, error) {
 	var genreID int64
 	err := tx.QueryRow(`SELECT id FROM genres WHERE name = ?`, genre.Name).Scan(&genreID)
@@ -294,16 +419,20 @@ func (r *movieRepository) createArtist(tx *sql.Tx, artist models.Artist) (string
 	return artistID, tx, nil
 }
 
-func (r *movieRepository) GetMostViewedMovie(ctx context.Context) (*models.Movie, error) {
-	query := `
+func (r *movieRepository) GetMostViewedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error) {
+	viewSource, args := viewCountSource(filter)
+	edition, editionArgs := editionCondition("m.id", filter)
+	query := fmt.Sprintf(`
 		SELECT m.id, m.title, m.description, m.duration, m.watch_url, mv.view_count, m.created_at, m.updated_at
 		FROM movies m
-		JOIN movie_views mv ON m.id = mv.movie_id
+		JOIN (%s) mv ON m.id = mv.movie_id
+		WHERE m.deleted_at IS NULL%s
 		ORDER BY mv.view_count DESC
 		LIMIT 1
-	`
+	`, viewSource, edition)
+	args = append(args, editionArgs...)
 	var movie models.Movie
-	err := r.db.QueryRowContext(ctx, query).Scan(
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&movie.ID,
 		&movie.Title,
 		&movie.Description,
@@ -326,22 +455,39 @@ func (r *movieRepository) GetMostViewedMovie(ctx context.Context) (*models.Movie
 	return &movie, nil
 }
 
-func (r *movieRepository) GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string) ([]models.GenreView, error) {
+func (r *movieRepository) GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string, filter models.StatsFilter) ([]models.GenreView, error) {
 	// Calculate the offset for pagination
 	offset := (page - 1) * pageSize
 
+	// When rolling up, views of a subgenre are counted towards its parent genre.
+	// A movie is counted once per genre even if it is tagged with both parent and subgenre.
+	genreColumn := "sg.id"
+	if filter.RollupGenres {
+		genreColumn = "COALESCE(sg.parent_id, sg.id)"
+	}
+	viewSource, args := viewCountSource(filter)
+	edition, editionArgs := editionCondition("m.id", filter)
+
 	// Updated query with pagination and sorting by total_views
 	query := fmt.Sprintf(`
 		SELECT g.name, SUM(mv.view_count) AS total_views
-		FROM movie_genres mg
-		JOIN genres g ON mg.genre_id = g.id
-		JOIN movie_views mv ON mg.movie_id = mv.movie_id
+		FROM (
+			SELECT DISTINCT %s AS genre_id, mg.movie_id
+			FROM movie_genres mg
+			JOIN genres sg ON mg.genre_id = sg.id
+		) gm
+		JOIN genres g ON gm.genre_id = g.id
+		JOIN (%s) mv ON gm.movie_id = mv.movie_id
+		JOIN movies m ON gm.movie_id = m.id
+		WHERE m.deleted_at IS NULL%s
 		GROUP BY g.id
 		ORDER BY total_views %s, g.name
 		LIMIT ? OFFSET ?
-	`, sortOrder)
+	`, genreColumn, viewSource, edition, sortOrder)
+	args = append(args, editionArgs...)
+	args = append(args, pageSize, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -373,6 +519,7 @@ func (r *movieRepository) GetAllMovies(ctx context.Context, limit, offset int) (
 	query := `
 		SELECT m.id, m.title, m.description, m.duration, m.watch_url, m.created_at, m.updated_at
 		FROM movies m
+		WHERE m.deleted_at IS NULL
 		LIMIT ? OFFSET ?
 	`
 	rows, err := r.db.QueryContext(ctx, query, limit, offset)
@@ -403,28 +550,50 @@ func (r *movieRepository) GetAllMovies(ctx context.Context, limit, offset int) (
 			log.Fatalf("Error fetching genres: %v", err)
 		}
 		movie.Genres = genreRows
+
+		// Fetch credited artists for the movie
+		artistRows, err := r.getArtistsByMovieID(ctx, movie.ID)
+		if err != nil {
+			return nil, err
+		}
+		movie.Artists = artistRows
 		movies = append(movies, movie)
 	}
 
 	return movies, nil
 }
 
-func (r *movieRepository) SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.Movie, error) {
-	query = "%" + query + "%"
-	queryString := `
+func (r *movieRepository) SearchMovies(ctx context.Context, filter models.MovieSearchFilter, limit, offset int) ([]models.Movie, error) {
+	conditions := []string{"m.deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if filter.Query != "" {
+		query := containsPattern(filter.Query)
+		conditions = append(conditions, "(m.title LIKE ? OR m.description LIKE ? OR g.name LIKE ? OR a.name LIKE ?)")
+		args = append(args, query, query, query, query)
+	}
+	if filter.Artist != "" {
+		conditions = append(conditions, "a.name LIKE ?")
+		args = append(args, containsPattern(filter.Artist))
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, "ma.role = ?")
+		args = append(args, filter.Role)
+	}
+
+	queryString := fmt.Sprintf(`
 		SELECT DISTINCT m.id, m.title, m.description, m.duration, m.watch_url, m.created_at, m.updated_at 
 		FROM movies m
 		LEFT JOIN movie_genres mg ON m.id = mg.movie_id
 		LEFT JOIN genres g ON mg.genre_id = g.id
 		LEFT JOIN movie_artists ma ON m.id = ma.movie_id
 		LEFT JOIN artists a ON ma.artist_id = a.id
-		WHERE m.title LIKE ? OR m.description LIKE ? OR g.name LIKE ? OR a.name LIKE ?
+		WHERE %s
 		LIMIT ? OFFSET ?
-	`
+	`, strings.Join(conditions, " AND "))
+	args = append(args, limit, offset)
 
-	// log.Printf("Executing query: %s\nWith parameters: %v, %v, %v, %v, %d, %d", queryString, query, query, query, query, limit, offset)
-
-	rows, err := r.db.QueryContext(ctx, queryString, query, query, query, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, queryString, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -450,6 +619,13 @@ func (r *movieRepository) SearchMovies(ctx context.Context, query string, limit,
 			log.Fatalf("Error fetching genres: %v", err)
 		}
 		movie.Genres = genreRows
+
+		// Fetch credited artists for the movie
+		artistRows, err := r.getArtistsByMovieID(ctx, movie.ID)
+		if err != nil {
+			return nil, err
+		}
+		movie.Artists = artistRows
 		movies = append(movies, movie)
 	}
 
@@ -487,49 +663,342 @@ func (r *movieRepository) getGenresByMovieID(ctx context.Context, movieID string
 	return genres, nil
 }
 
-func (r *movieRepository) TrackMovieView(ctx context.Context, movieID string) error {
+// getArtistsByMovieID retrieves artists associated with a given movie ID.
+func (r *movieRepository) getArtistsByMovieID(ctx context.Context, movieID string) ([]models.Artist, error) {
+	query := `
+		SELECT a.id, a.name, ma.role, ma.billing_order
+		FROM artists a
+		JOIN movie_artists ma ON a.id = ma.artist_id
+		WHERE ma.movie_id = ?
+		ORDER BY ma.billing_order, a.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var artists []models.Artist
+	for rows.Next() {
+		var artist models.Artist
+		if err := rows.Scan(&artist.ID, &artist.Name, &artist.Role, &artist.BillingOrder); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		artists = append(artists, artist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return artists, nil
+}
+
+// TrackMovieView records a view event. The all-time counter in movie_views is updated in batches by AddViewCounts.
+func (r *movieRepository) TrackMovieView(ctx context.Context, view models.ViewEvent) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO movie_view_events (movie_id, user_id, session_id, ip_address, watch_duration, viewed_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		view.MovieID, nullString(view.UserID), nullString(view.SessionID), nullString(view.IPAddress), view.WatchDuration, view.ViewedAt)
+	return err
+}
+
+// AddViewCounts adds a batch of view counts per movie to the all-time counters in one transaction.
+// A batch is applied at most once, applying the same batchID again is a no-op.
+func (r *movieRepository) AddViewCounts(ctx context.Context, batchID string, counts map[string]int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "INSERT IGNORE INTO movie_view_flushes (batch_id) VALUES (?)", batchID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		// Already applied before a crash or by another instance
+		tx.Rollback()
+		return err
+	}
+
+	// Counts of movies purged in the meantime are dropped
 	query := `
 		INSERT INTO movie_views (movie_id, view_count, last_viewed_at)
-		VALUES (?, 1, NOW())
+		SELECT id, ?, NOW() FROM movies WHERE id = ?
 		ON DUPLICATE KEY UPDATE 
-			view_count = view_count + 1, 
-			last_viewed_at = NOW()
+			view_count = view_count + VALUES(view_count), 
+			last_viewed_at = VALUES(last_viewed_at)
 	`
-	_, err := r.db.ExecContext(ctx, query, movieID)
-	if err != nil {
+	for movieID, count := range counts {
+		if _, err = tx.ExecContext(ctx, query, count, movieID); err != nil {
+			tx.Rollback()
+			log.Printf("Error adding view count of movie %s: %v", movieID, err)
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM movie_view_flushes WHERE flushed_at < NOW() - INTERVAL 7 DAY"); err != nil {
+		tx.Rollback()
 		return err
 	}
+
+	return tx.Commit()
+}
+
+// viewerKey identifies a viewer in the view event log: logged in users first, then anonymous sessions,
+// then the client address of anonymous views without a session.
+const viewerKey = "COALESCE(CONCAT('u:', user_id), CONCAT('s:', session_id), CONCAT('ip:', ip_address))"
+
+// RollupViewStats recomputes the hourly and daily view and completion aggregates of every bucket starting from since.
+// Buckets are recomputed in full, so running it several times over the same period is safe.
+func (r *movieRepository) RollupViewStats(ctx context.Context, since time.Time) error {
+	hourStart := since.Truncate(time.Hour)
+	dayStart := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, since.Location())
+
+	hourlyQuery := `
+		INSERT INTO movie_view_stats_hourly (movie_id, bucket_start, view_count, unique_viewers, watch_seconds)
+		SELECT movie_id, DATE_FORMAT(viewed_at, '%Y-%m-%d %H:00:00') AS bucket,
+			COUNT(*), COUNT(DISTINCT ` + viewerKey + `), COALESCE(SUM(watch_duration), 0)
+		FROM movie_view_events
+		WHERE viewed_at >= ?
+		GROUP BY movie_id, bucket
+		ON DUPLICATE KEY UPDATE
+			view_count = VALUES(view_count),
+			unique_viewers = VALUES(unique_viewers),
+			watch_seconds = VALUES(watch_seconds)
+	`
+	if _, err := r.db.ExecContext(ctx, hourlyQuery, hourStart); err != nil {
+		return fmt.Errorf("failed to roll up hourly views: %w", err)
+	}
+
+	dailyQuery := `
+		INSERT INTO movie_view_stats_daily (movie_id, bucket_start, view_count, unique_viewers, watch_seconds)
+		SELECT movie_id, DATE(viewed_at) AS bucket,
+			COUNT(*), COUNT(DISTINCT ` + viewerKey + `), COALESCE(SUM(watch_duration), 0)
+		FROM movie_view_events
+		WHERE viewed_at >= ?
+		GROUP BY movie_id, bucket
+		ON DUPLICATE KEY UPDATE
+			view_count = VALUES(view_count),
+			unique_viewers = VALUES(unique_viewers),
+			watch_seconds = VALUES(watch_seconds)
+	`
+	if _, err := r.db.ExecContext(ctx, dailyQuery, dayStart); err != nil {
+		return fmt.Errorf("failed to roll up daily views: %w", err)
+	}
+
+	hourlyCompletionsQuery := `
+		INSERT INTO movie_view_stats_hourly (movie_id, bucket_start, completions)
+		SELECT movie_id, DATE_FORMAT(completed_at, '%Y-%m-%d %H:00:00') AS bucket, COUNT(*)
+		FROM movie_completions
+		WHERE completed_at >= ?
+		GROUP BY movie_id, bucket
+		ON DUPLICATE KEY UPDATE completions = VALUES(completions)
+	`
+	if _, err := r.db.ExecContext(ctx, hourlyCompletionsQuery, hourStart); err != nil {
+		return fmt.Errorf("failed to roll up hourly completions: %w", err)
+	}
+
+	dailyCompletionsQuery := `
+		INSERT INTO movie_view_stats_daily (movie_id, bucket_start, completions)
+		SELECT movie_id, DATE(completed_at) AS bucket, COUNT(*)
+		FROM movie_completions
+		WHERE completed_at >= ?
+		GROUP BY movie_id, bucket
+		ON DUPLICATE KEY UPDATE completions = VALUES(completions)
+	`
+	if _, err := r.db.ExecContext(ctx, dailyCompletionsQuery, dayStart); err != nil {
+		return fmt.Errorf("failed to roll up daily completions: %w", err)
+	}
+
 	return nil
 }
 
-// GetVoteByUserAndMovie checks if a user has already voted for a specific movie
+// GetMovieViewStats retrieves the view time series of a movie from the hourly or daily aggregates,
+// together with the total views and unique viewers over the whole window.
+func (r *movieRepository) GetMovieViewStats(ctx context.Context, movieID, granularity string, filter models.StatsFilter) (*models.MovieViewStats, error) {
+	table := "movie_view_stats_hourly"
+	if granularity == models.GranularityDay {
+		table = "movie_view_stats_daily"
+	}
+
+	conditions, args := windowConditions("bucket_start", filter)
+	query := fmt.Sprintf(`
+		SELECT bucket_start, view_count, unique_viewers, watch_seconds, completions
+		FROM %s
+		WHERE movie_id = ?%s
+		ORDER BY bucket_start
+	`, table, conditions)
+
+	rows, err := r.db.QueryContext(ctx, query, append([]interface{}{movieID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &models.MovieViewStats{
+		MovieID:     movieID,
+		Granularity: granularity,
+		From:        filter.From,
+		To:          filter.To,
+		Buckets:     []models.ViewStat{},
+	}
+	for rows.Next() {
+		var stat models.ViewStat
+		if err := rows.Scan(&stat.BucketStart, &stat.Views, &stat.UniqueViewers, &stat.WatchSeconds, &stat.Completions); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		stats.TotalViews += stat.Views
+		stats.Completions += stat.Completions
+		stats.Buckets = append(stats.Buckets, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// Unique viewers cannot be summed across buckets, count them from the event log
+	conditions, args = windowConditions("viewed_at", filter)
+	query = fmt.Sprintf(`SELECT COUNT(DISTINCT %s) FROM movie_view_events WHERE movie_id = ?%s`, viewerKey, conditions)
+	err = r.db.QueryRowContext(ctx, query, append([]interface{}{movieID}, args...)...).Scan(&stats.UniqueViewers)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetSuspectedViewInflation lists the client addresses with at least minViews views of a single movie inside the window,
+// which is the pattern left by scripts rotating sessions to get past the view deduplication.
+func (r *movieRepository) GetSuspectedViewInflation(ctx context.Context, filter models.StatsFilter, minViews int) ([]models.SuspectedViewInflation, error) {
+	conditions, args := windowConditions("viewed_at", filter)
+	eventConditions, _ := windowConditions("e.viewed_at", filter)
+	query := fmt.Sprintf(`
+		SELECT e.movie_id, m.title, e.ip_address, COUNT(*) AS views, COUNT(DISTINCT %[1]s), t.total_views
+		FROM movie_view_events e
+		JOIN movies m ON m.id = e.movie_id
+		JOIN (
+			SELECT movie_id, COUNT(*) AS total_views
+			FROM movie_view_events
+			WHERE 1 = 1%[2]s
+			GROUP BY movie_id
+		) t ON t.movie_id = e.movie_id
+		WHERE e.ip_address IS NOT NULL%[3]s
+		GROUP BY e.movie_id, m.title, e.ip_address, t.total_views
+		HAVING views >= ?
+		ORDER BY views DESC
+		LIMIT 100
+	`, viewerKey, conditions, eventConditions)
+
+	queryArgs := append(append(append([]interface{}{}, args...), args...), minViews)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.SuspectedViewInflation{}
+	for rows.Next() {
+		var report models.SuspectedViewInflation
+		err := rows.Scan(&report.MovieID, &report.Title, &report.IPAddress, &report.Views, &report.UniqueViewers, &report.TotalViews)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if report.TotalViews > 0 {
+			report.Share = float64(report.Views) / float64(report.TotalViews)
+		}
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return reports, nil
+}
+
+// viewCountSource returns a subquery yielding (movie_id, view_count) rows.
+// Without a time window it reads the all-time counters, otherwise it sums the hourly aggregates inside the window.
+func viewCountSource(filter models.StatsFilter) (string, []interface{}) {
+	if !filter.HasWindow() {
+		return "SELECT movie_id, view_count FROM movie_views", nil
+	}
+
+	conditions, args := windowConditions("bucket_start", filter)
+	return fmt.Sprintf(`SELECT movie_id, SUM(view_count) AS view_count
+		FROM movie_view_stats_hourly
+		WHERE 1 = 1%s
+		GROUP BY movie_id`, conditions), args
+}
+
+// windowConditions builds the AND conditions limiting column to the time window of the filter.
+func windowConditions(column string, filter models.StatsFilter) (string, []interface{}) {
+	var conditions string
+	var args []interface{}
+	if filter.From != nil {
+		conditions += fmt.Sprintf(" AND %s >= ?", column)
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions += fmt.Sprintf(" AND %s < ?", column)
+		args = append(args, *filter.To)
+	}
+
+	return conditions, args
+}
+
+// editionCondition builds the AND condition limiting column to the movies entered into the edition of the filter.
+func editionCondition(column string, filter models.StatsFilter) (string, []interface{}) {
+	if filter.EditionID == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" AND %s IN (SELECT movie_id FROM edition_movies WHERE edition_id = ?)", column), []interface{}{filter.EditionID}
+}
+
+// nullString stores empty strings as NULL.
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullInt stores zeros as NULL.
+func nullInt(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
+
+// GetVoteByUserAndMovie checks if a user has already voted for a specific movie,
+// preferring the vote cast before voting periods existed, then the latest one
 func (r *movieRepository) GetVoteByUserAndMovie(ctx context.Context, userID, movieID string) (*models.Vote, error) {
 	var vote models.Vote
-	query := "SELECT id, user_id, movie_id FROM votes WHERE user_id = ? AND movie_id = ?"
-	err := r.db.QueryRowContext(ctx, query, userID, movieID).Scan(&vote.ID, &vote.UserID, &vote.MovieID)
+	var votingPeriodID sql.NullString
+	query := `SELECT id, user_id, movie_id, voting_period_id FROM votes WHERE user_id = ? AND movie_id = ?
+		ORDER BY voting_period_id IS NOT NULL, created_at DESC LIMIT 1`
+	err := r.db.QueryRowContext(ctx, query, userID, movieID).Scan(&vote.ID, &vote.UserID, &vote.MovieID, &votingPeriodID)
 	if err == sql.ErrNoRows {
 		return &vote, nil
 	}
 	if err != nil {
 		return &vote, err
 	}
+	vote.VotingPeriodID = votingPeriodID.String
 	return &vote, nil
 }
 
-// CreateVote inserts a new vote into the database
-func (r *movieRepository) CreateVote(ctx context.Context, userID, movieID string) error {
-	query := "INSERT INTO votes (id, user_id, movie_id) VALUES (?, ?, ?)"
-	_, err := r.db.ExecContext(ctx, query, uuid.NewString(), userID, movieID)
-	return err
-}
-
 func (r *movieRepository) DeleteVote(ctx context.Context, voteID string) error {
 	query := "DELETE FROM votes WHERE id = ?"
 	_, err := r.db.ExecContext(ctx, query, voteID)
 	return err
 }
 
-// GetMoviesByIDs retrieves the details of movies by their IDs.
+// GetMoviesByIDs retrieves the details of movies by their IDs, with their genres and artists.
 func (r *movieRepository) GetMoviesByIDs(ctx context.Context, movieIDs []string) ([]models.Movie, error) {
 	// Construct the placeholders for the query
 	placeholders := make([]string, len(movieIDs))
@@ -540,7 +1009,10 @@ func (r *movieRepository) GetMoviesByIDs(ctx context.Context, movieIDs []string)
 	}
 
 	// Build the query dynamically
-	query := fmt.Sprintf("SELECT id, title FROM movies WHERE id IN (%s)", strings.Join(placeholders, ","))
+	query := fmt.Sprintf(`
+		SELECT id, title, description, duration, watch_url, created_at, updated_at
+		FROM movies
+		WHERE id IN (%s) AND deleted_at IS NULL`, strings.Join(placeholders, ","))
 
 	// Prepare and execute the query
 	rows, err := r.db.QueryContext(ctx, query, args...)
@@ -553,7 +1025,8 @@ func (r *movieRepository) GetMoviesByIDs(ctx context.Context, movieIDs []string)
 	var movies []models.Movie
 	for rows.Next() {
 		var movie models.Movie
-		if err := rows.Scan(&movie.ID, &movie.Title); err != nil {
+		err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Duration, &movie.WatchURL, &movie.CreatedAt, &movie.UpdatedAt)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		movies = append(movies, movie)
@@ -564,6 +1037,16 @@ func (r *movieRepository) GetMoviesByIDs(ctx context.Context, movieIDs []string)
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
+	// Hydrate genres and artists once all rows are read, which releases the connection
+	for i := range movies {
+		if movies[i].Genres, err = r.getGenresByMovieID(ctx, movies[i].ID); err != nil {
+			return nil, err
+		}
+		if movies[i].Artists, err = r.getArtistsByMovieID(ctx, movies[i].ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return movies, nil
 }
 
@@ -588,19 +1071,28 @@ func (r *movieRepository) GetUserVotedMovieIDs(ctx context.Context, userID strin
 	return movieIDs, nil
 }
 
-func (r *movieRepository) GetMostVotedMovie(ctx context.Context) (*models.Movie, error) {
-	query := `
+func (r *movieRepository) GetMostVotedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error) {
+	window, args := windowConditions("v.created_at", filter)
+	edition, editionArgs := editionCondition("m.id", filter)
+	// A movie entered into several editions only counts the votes of the voting periods of the edition
+	if filter.EditionID != "" {
+		edition += " AND v.voting_period_id IN (SELECT id FROM voting_periods WHERE edition_id = ?)"
+		editionArgs = append(editionArgs, filter.EditionID)
+	}
+	query := fmt.Sprintf(`
 		SELECT m.id, m.title, COUNT(v.movie_id) AS votes
 		FROM movies m
 		JOIN votes v ON m.id = v.movie_id
+		WHERE m.deleted_at IS NULL%s%s
 		GROUP BY m.id, m.title
 		ORDER BY votes DESC
 		LIMIT 1
-	`
+	`, window, edition)
+	args = append(args, editionArgs...)
 
 	movie := &models.Movie{}
-	err := r.db.QueryRowContext(ctx, query).Scan(&movie.ID, &movie.Title, &movie.Votes)
-	if err != sql.ErrNoRows {
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&movie.ID, &movie.Title, &movie.Votes)
+	if err != nil {
 		return nil, err
 	}
 
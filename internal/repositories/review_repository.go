@@ -0,0 +1,390 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.Review) error
+	Update(ctx context.Context, review *models.Review) error
+	Delete(ctx context.Context, reviewID string) error
+	FindByID(ctx context.Context, reviewID string) (*models.Review, error)
+	FindByMovieAndUser(ctx context.Context, movieID, userID string) (*models.Review, error)
+	ListByMovie(ctx context.Context, movieID, sort string, limit, offset int) ([]models.Review, error)
+	GetRatingSummary(ctx context.Context, movieID string) (*models.RatingSummary, error)
+	MarkHelpful(ctx context.Context, reviewID, userID string) error
+	UnmarkHelpful(ctx context.Context, reviewID, userID string) error
+	Report(ctx context.Context, reviewID, userID, reason string, threshold int) error
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]models.Review, error)
+	Moderate(ctx context.Context, reviewID, adminID, action, reason string) error
+	ListModerationActions(ctx context.Context, reviewID string) ([]models.ModerationAction, error)
+}
+
+type reviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) ReviewRepository {
+	return &reviewRepository{db}
+}
+
+const selectReview = `
+	SELECT r.id, r.movie_id, r.user_id, u.username, r.rating, r.body, r.helpful_count, r.status, r.moderation_reason,
+		(SELECT COUNT(*) FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL) AS report_count,
+		r.created_at, r.updated_at
+	FROM reviews r
+	JOIN users u ON u.id = r.user_id`
+
+func (r *reviewRepository) Create(ctx context.Context, review *models.Review) error {
+	query := `INSERT INTO reviews (id, movie_id, user_id, rating, body, status, moderation_reason) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, review.ID, review.MovieID, review.UserID, review.Rating, nullString(review.Body),
+		review.Status, nullString(review.ModerationReason))
+	return err
+}
+
+// Update changes the rating, text and moderation state of a review.
+// It returns sql.ErrNoRows when the review does not exist.
+func (r *reviewRepository) Update(ctx context.Context, review *models.Review) error {
+	// Make sure the review exists, an update with unchanged values affects no rows
+	if _, err := r.FindByID(ctx, review.ID); err != nil {
+		return err
+	}
+
+	query := `UPDATE reviews SET rating = ?, body = ?, status = ?, moderation_reason = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, review.Rating, nullString(review.Body), review.Status, nullString(review.ModerationReason), review.ID)
+	return err
+}
+
+// Delete removes a review and its helpful votes.
+// It returns sql.ErrNoRows when the review does not exist.
+func (r *reviewRepository) Delete(ctx context.Context, reviewID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	queries := []string{
+		"DELETE FROM review_helpful_votes WHERE review_id = ?",
+		"DELETE FROM review_reports WHERE review_id = ?",
+	}
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query, reviewID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM reviews WHERE id = ?", reviewID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *reviewRepository) FindByID(ctx context.Context, reviewID string) (*models.Review, error) {
+	row := r.db.QueryRowContext(ctx, selectReview+" WHERE r.id = ?", reviewID)
+	return scanReview(row)
+}
+
+// FindByMovieAndUser returns the review of a user on a movie, or nil when there is none.
+func (r *reviewRepository) FindByMovieAndUser(ctx context.Context, movieID, userID string) (*models.Review, error) {
+	row := r.db.QueryRowContext(ctx, selectReview+" WHERE r.movie_id = ? AND r.user_id = ?", movieID, userID)
+	review, err := scanReview(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return review, err
+}
+
+// ListByMovie retrieves a page of the approved reviews of a movie, newest first or most helpful first.
+func (r *reviewRepository) ListByMovie(ctx context.Context, movieID, sort string, limit, offset int) ([]models.Review, error) {
+	orderBy := "r.created_at DESC, r.id"
+	if sort == models.ReviewSortHelpful {
+		orderBy = "r.helpful_count DESC, r.created_at DESC, r.id"
+	}
+
+	query := fmt.Sprintf("%s WHERE r.movie_id = ? AND r.status = ? ORDER BY %s LIMIT ? OFFSET ?", selectReview, orderBy)
+	return r.queryReviews(ctx, query, movieID, models.ReviewStatusApproved, limit, offset)
+}
+
+// ListByStatus retrieves a page of the reviews in a moderation state, oldest first so the queue is worked in order.
+func (r *reviewRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]models.Review, error) {
+	query := selectReview + " WHERE r.status = ? ORDER BY r.updated_at, r.id LIMIT ? OFFSET ?"
+	return r.queryReviews(ctx, query, status, limit, offset)
+}
+
+func (r *reviewRepository) queryReviews(ctx context.Context, query string, args ...interface{}) ([]models.Review, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *reviewRepository) GetRatingSummary(ctx context.Context, movieID string) (*models.RatingSummary, error) {
+	return getRatingSummary(ctx, r.db, movieID)
+}
+
+// MarkHelpful records that a user found a review helpful. Marking the same review twice counts once.
+// It returns sql.ErrNoRows when the review does not exist.
+func (r *reviewRepository) MarkHelpful(ctx context.Context, reviewID, userID string) error {
+	return r.setHelpful(ctx, reviewID, userID, true)
+}
+
+// UnmarkHelpful withdraws a helpful mark of a user.
+// It returns sql.ErrNoRows when the review does not exist.
+func (r *reviewRepository) UnmarkHelpful(ctx context.Context, reviewID, userID string) error {
+	return r.setHelpful(ctx, reviewID, userID, false)
+}
+
+func (r *reviewRepository) setHelpful(ctx context.Context, reviewID, userID string, helpful bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var id string
+	if err = tx.QueryRowContext(ctx, "SELECT id FROM reviews WHERE id = ? FOR UPDATE", reviewID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query, delta := "INSERT IGNORE INTO review_helpful_votes (review_id, user_id) VALUES (?, ?)", 1
+	if !helpful {
+		query, delta = "DELETE FROM review_helpful_votes WHERE review_id = ? AND user_id = ?", -1
+	}
+
+	res, err := tx.ExecContext(ctx, query, reviewID, userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Only move the counter when the mark actually changed
+	if affected, _ := res.RowsAffected(); affected > 0 {
+		_, err = tx.ExecContext(ctx, "UPDATE reviews SET helpful_count = helpful_count + ?, updated_at = updated_at WHERE id = ?", delta, reviewID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Report records a report of a user on a review. Once the review has threshold open reports it goes back to
+// the moderation queue. Reporting the same review twice counts once.
+// It returns sql.ErrNoRows when the review does not exist.
+func (r *reviewRepository) Report(ctx context.Context, reviewID, userID, reason string, threshold int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var status string
+	if err = tx.QueryRowContext(ctx, "SELECT status FROM reviews WHERE id = ? FOR UPDATE", reviewID).Scan(&status); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO review_reports (review_id, user_id, reason) VALUES (?, ?, ?)", reviewID, userID, reason)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var reports int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM review_reports WHERE review_id = ? AND resolved_at IS NULL", reviewID).Scan(&reports)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if status == models.ReviewStatusApproved && reports >= threshold {
+		_, err = tx.ExecContext(ctx, "UPDATE reviews SET status = ?, moderation_reason = ? WHERE id = ?",
+			models.ReviewStatusPending, "reported by users", reviewID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Moderate approves or rejects a review, resolves its open reports and records the action in the audit trail.
+// It returns sql.ErrNoRows when the review does not exist.
+func (r *reviewRepository) Moderate(ctx context.Context, reviewID, adminID, action, reason string) error {
+	status := models.ReviewStatusApproved
+	if action == models.ModerationActionReject {
+		status = models.ReviewStatusRejected
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var id string
+	if err = tx.QueryRowContext(ctx, "SELECT id FROM reviews WHERE id = ? FOR UPDATE", reviewID).Scan(&id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE reviews SET status = ?, moderation_reason = NULL, updated_at = updated_at WHERE id = ?", status, reviewID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE review_reports SET resolved_at = NOW() WHERE review_id = ? AND resolved_at IS NULL", reviewID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO review_moderation_actions (review_id, admin_id, action, reason) VALUES (?, ?, ?, ?)",
+		reviewID, adminID, action, nullString(reason))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListModerationActions retrieves the audit trail of a review, oldest first.
+func (r *reviewRepository) ListModerationActions(ctx context.Context, reviewID string) ([]models.ModerationAction, error) {
+	query := `
+		SELECT a.id, a.review_id, a.admin_id, u.username, a.action, a.reason, a.created_at
+		FROM review_moderation_actions a
+		JOIN users u ON u.id = a.admin_id
+		WHERE a.review_id = ?
+		ORDER BY a.created_at, a.id
+	`
+	rows, err := r.db.QueryContext(ctx, query, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	actions := []models.ModerationAction{}
+	for rows.Next() {
+		var action models.ModerationAction
+		var reason sql.NullString
+		err := rows.Scan(&action.ID, &action.ReviewID, &action.AdminID, &action.AdminUsername, &action.Action, &reason, &action.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		action.Reason = reason.String
+		actions = append(actions, action)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return actions, nil
+}
+
+// getRatingSummary computes the average rating and the rating histogram of the approved reviews of a movie.
+func getRatingSummary(ctx context.Context, db *sql.DB, movieID string) (*models.RatingSummary, error) {
+	rows, err := db.QueryContext(ctx, "SELECT rating, COUNT(*) FROM reviews WHERE movie_id = ? AND status = ? GROUP BY rating",
+		movieID, models.ReviewStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &models.RatingSummary{Histogram: map[int]int64{}}
+	for rating := 1; rating <= 10; rating++ {
+		summary.Histogram[rating] = 0
+	}
+
+	var total int64
+	for rows.Next() {
+		var rating int
+		var count int64
+		if err := rows.Scan(&rating, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		summary.Histogram[rating] = count
+		summary.RatingCount += count
+		total += int64(rating) * count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if summary.RatingCount > 0 {
+		summary.AverageRating = float64(total) / float64(summary.RatingCount)
+	}
+
+	return summary, nil
+}
+
+func scanReview(row rowScanner) (*models.Review, error) {
+	var review models.Review
+	var body, moderationReason sql.NullString
+	err := row.Scan(&review.ID, &review.MovieID, &review.UserID, &review.Username, &review.Rating, &body,
+		&review.HelpfulCount, &review.Status, &moderationReason, &review.ReportCount, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	review.Body = body.String
+	review.ModerationReason = moderationReason.String
+
+	return &review, nil
+}
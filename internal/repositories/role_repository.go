@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type RoleRepository interface {
+	List(ctx context.Context) ([]models.Role, error)
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	Create(ctx context.Context, role *models.Role) error
+	Update(ctx context.Context, role *models.Role) error
+	Delete(ctx context.Context, name string) error
+	CountUsers(ctx context.Context, name string) (int, error)
+	AssignToUser(ctx context.Context, userID, name string) error
+	FindUserRole(ctx context.Context, userID string) (string, error)
+}
+
+// ErrRoleAssigned is returned by Delete when users still have the role
+var ErrRoleAssigned = errors.New("role is assigned to users")
+
+// mysqlRowReferenced is the MySQL error number of a row still referenced by a foreign key
+const mysqlRowReferenced = 1451
+
+type roleRepository struct {
+	db *sql.DB
+}
+
+func NewRoleRepository(db *sql.DB) RoleRepository {
+	return &roleRepository{db}
+}
+
+// List retrieves every role with its permissions, ordered by name.
+func (r *roleRepository) List(ctx context.Context) ([]models.Role, error) {
+	query := `
+		SELECT r.name, r.description, r.created_at, p.permission
+		FROM roles r
+		LEFT JOIN role_permissions p ON p.role = r.name
+		ORDER BY r.name, p.permission
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		var description, permission sql.NullString
+		if err := rows.Scan(&role.Name, &description, &role.CreatedAt, &permission); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// One row per permission, consecutive for a role
+		if last := len(roles) - 1; last < 0 || roles[last].Name != role.Name {
+			role.Description = description.String
+			role.Permissions = []string{}
+			roles = append(roles, role)
+		}
+		if permission.Valid {
+			last := len(roles) - 1
+			roles[last].Permissions = append(roles[last].Permissions, permission.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return roles, nil
+}
+
+// FindByName retrieves a role with its permissions.
+// It returns sql.ErrNoRows when the role does not exist.
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	var description sql.NullString
+	err := r.db.QueryRowContext(ctx, "SELECT name, description, created_at FROM roles WHERE name = ?", name).
+		Scan(&role.Name, &description, &role.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	role.Description = description.String
+
+	rows, err := r.db.QueryContext(ctx, "SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	role.Permissions = []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		role.Permissions = append(role.Permissions, permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return &role, nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "INSERT INTO roles (name, description) VALUES (?, ?)", role.Name, nullString(role.Description)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = insertRolePermissions(ctx, tx, role); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update replaces the description and the permissions of a role.
+// It returns sql.ErrNoRows when the role does not exist.
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var name string
+	if err = tx.QueryRowContext(ctx, "SELECT name FROM roles WHERE name = ? FOR UPDATE", role.Name).Scan(&name); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE roles SET description = ? WHERE name = ?", nullString(role.Description), role.Name); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE role = ?", role.Name); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = insertRolePermissions(ctx, tx, role); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes a role and its permissions.
+// It returns sql.ErrNoRows when the role does not exist, and ErrRoleAssigned when users still have it.
+func (r *roleRepository) Delete(ctx context.Context, name string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM roles WHERE name = ?", name)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlRowReferenced {
+			return ErrRoleAssigned
+		}
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// CountUsers counts the users having the role.
+func (r *roleRepository) CountUsers(ctx context.Context, name string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE role = ?", name).Scan(&count)
+	return count, err
+}
+
+// AssignToUser sets the role of a user.
+// It returns sql.ErrNoRows when the user does not exist.
+func (r *roleRepository) AssignToUser(ctx context.Context, userID, name string) error {
+	var id string
+	if err := r.db.QueryRowContext(ctx, "SELECT id FROM users WHERE id = ?", userID).Scan(&id); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, "UPDATE users SET role = ? WHERE id = ?", name, userID)
+	return err
+}
+
+// FindUserRole returns the role of a user, sql.ErrNoRows when the user does not exist
+func (r *roleRepository) FindUserRole(ctx context.Context, userID string) (string, error) {
+	var role string
+	if err := r.db.QueryRowContext(ctx, "SELECT role FROM users WHERE id = ?", userID).Scan(&role); err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+func insertRolePermissions(ctx context.Context, tx *sql.Tx, role *models.Role) error {
+	for _, permission := range role.Permissions {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO role_permissions (role, permission) VALUES (?, ?)", role.Name, permission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
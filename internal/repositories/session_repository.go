@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *models.Session, tokenHash string) error
+	FindRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, token *models.RefreshToken, newTokenHash string, device models.SessionDevice, expiresAt time.Time) error
+	ListActiveSessions(ctx context.Context, userID string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) ([]string, error)
+}
+
+type sessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) SessionRepository {
+	return &sessionRepository{db}
+}
+
+// CreateSession opens a session together with its first refresh token.
+func (r *sessionRepository) CreateSession(ctx context.Context, session *models.Session, tokenHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO user_sessions (id, user_id, user_agent, ip_address, last_used_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(ctx, query, session.ID, session.UserID, nullString(session.UserAgent), nullString(session.IPAddress),
+		session.LastUsedAt, session.ExpiresAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO refresh_tokens (id, session_id, token_hash) VALUES (?, ?, ?)",
+		uuid.NewString(), session.ID, tokenHash)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindRefreshToken retrieves a refresh token with its session.
+// It returns sql.ErrNoRows when no token has the given hash.
+func (r *sessionRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT t.id, t.session_id, t.used_at,
+			s.id, s.user_id, s.user_agent, s.ip_address, s.created_at, s.last_used_at, s.expires_at, s.revoked_at
+		FROM refresh_tokens t
+		JOIN user_sessions s ON s.id = t.session_id
+		WHERE t.token_hash = ?
+	`
+	var token models.RefreshToken
+	var usedAt sql.NullTime
+	session, err := scanSession(r.db.QueryRowContext(ctx, query, tokenHash), &token.ID, &token.SessionID, &usedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	token.Session = *session
+
+	return &token, nil
+}
+
+// RotateRefreshToken marks a refresh token as used and issues the next token of its session.
+// It returns sql.ErrNoRows when the token was already used, which happens when two refreshes race.
+func (r *sessionRepository) RotateRefreshToken(ctx context.Context, token *models.RefreshToken, newTokenHash string, device models.SessionDevice, expiresAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "UPDATE refresh_tokens SET used_at = NOW() WHERE id = ? AND used_at IS NULL", token.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO refresh_tokens (id, session_id, token_hash) VALUES (?, ?, ?)",
+		uuid.NewString(), token.SessionID, newTokenHash)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := `
+		UPDATE user_sessions
+		SET user_agent = COALESCE(?, user_agent), ip_address = COALESCE(?, ip_address), last_used_at = NOW(), expires_at = ?
+		WHERE id = ?
+	`
+	_, err = tx.ExecContext(ctx, query, nullString(device.UserAgent), nullString(device.IPAddress), expiresAt, token.SessionID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListActiveSessions retrieves the sessions of a user that are neither revoked nor expired, most recently used first.
+func (r *sessionRepository) ListActiveSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, created_at, last_used_at, expires_at, revoked_at
+		FROM user_sessions
+		WHERE user_id = ? AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends a session of a user, its refresh tokens can no longer be used.
+// It returns sql.ErrNoRows when the user has no such active session.
+func (r *sessionRepository) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE user_sessions SET revoked_at = NOW() WHERE id = ? AND user_id = ? AND revoked_at IS NULL",
+		sessionID, userID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// RevokeAllSessions ends every active session of a user and returns their IDs.
+func (r *sessionRepository) RevokeAllSessions(ctx context.Context, userID string) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM user_sessions WHERE user_id = ? AND revoked_at IS NULL FOR UPDATE", userID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	sessionIDs := []string{}
+	for rows.Next() {
+		var sessionID string
+		if err = rows.Scan(&sessionID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = ? AND revoked_at IS NULL", userID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return sessionIDs, tx.Commit()
+}
+
+// scanSession scans the session columns, after the given leading columns
+func scanSession(row rowScanner, dest ...interface{}) (*models.Session, error) {
+	var session models.Session
+	var userAgent, ipAddress sql.NullString
+	var revokedAt sql.NullTime
+	dest = append(dest, &session.ID, &session.UserID, &userAgent, &ipAddress, &session.CreatedAt, &session.LastUsedAt,
+		&session.ExpiresAt, &revokedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	session.UserAgent = userAgent.String
+	session.IPAddress = ipAddress.String
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return &session, nil
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/stwrtrio/movie-festival/internal/models"
 	"golang.org/x/crypto/bcrypt"
@@ -11,6 +13,22 @@ import (
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID, password string) error
+	CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken, tokenHash string) error
+	FindPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, token *models.PasswordResetToken, password string) error
+	SetMFASecret(ctx context.Context, userID, secret string) error
+	EnableMFA(ctx context.Context, userID string, codeHashes []string) error
+	DisableMFA(ctx context.Context, userID string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) error
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	GetUserDetail(ctx context.Context, userID string) (*models.UserDetail, error)
+	ListUserVotes(ctx context.Context, userID string, limit, offset int) ([]models.UserVote, error)
+	SetDisabled(ctx context.Context, userID string, disabled bool) error
+	RequirePasswordReset(ctx context.Context, userID string) error
 }
 
 type userRepository struct {
@@ -27,20 +45,425 @@ func (r *userRepository) CreateUser(ctx context.Context, user *models.User) erro
 		return err
 	}
 
-	query := "INSERT INTO users (id, username, password_hash, role) VALUES (?, ?, ?, ?)"
-	_, err = r.db.ExecContext(ctx, query, user.ID, user.Username, hashedPassword, user.Role)
+	query := "INSERT INTO users (id, username, email, password_hash, role) VALUES (?, ?, ?, ?, ?)"
+	_, err = r.db.ExecContext(ctx, query, user.ID, user.Username, nullString(user.Email), hashedPassword, user.Role)
 	return err
 }
 
+const selectUser = `SELECT id, username, email, password_hash, role, mfa_secret, mfa_enabled_at, disabled_at, password_reset_required,
+	created_at, updated_at FROM users`
+
 func (r *userRepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
-	var user models.User
-	query := "SELECT id, username, password_hash, role FROM users WHERE username = ?"
-	err := r.db.QueryRowContext(ctx, query, username).Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Role)
+	query := selectUser + " WHERE username = ?"
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, username))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+// GetUserByID returns the user with the given ID, or nil when there is none.
+func (r *userRepository) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	query := selectUser + " WHERE id = ?"
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+// GetUserByEmail returns the user with the given email address, or nil when there is none.
+func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := selectUser + " WHERE email = ?"
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+// UpdatePassword replaces the password of a user.
+// It returns sql.ErrNoRows when the user does not exist.
+func (r *userRepository) UpdatePassword(ctx context.Context, userID, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.ExecContext(ctx, "UPDATE users SET password_hash = ? WHERE id = ?", hashedPassword, userID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// CreatePasswordResetToken stores a reset token, the earlier unused tokens of the user stop working.
+func (r *userRepository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken, tokenHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
 		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = ? AND used_at IS NULL", token.UserID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at) VALUES (?, ?, ?, ?)",
+		token.ID, token.UserID, tokenHash, token.ExpiresAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindPasswordResetToken retrieves a reset token.
+// It returns sql.ErrNoRows when no token has the given hash.
+func (r *userRepository) FindPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	var usedAt sql.NullTime
+	query := "SELECT id, user_id, expires_at, used_at FROM password_reset_tokens WHERE token_hash = ?"
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.ExpiresAt, &usedAt)
+	if err != nil {
 		return nil, err
 	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
+
+// ResetPassword uses a reset token to replace the password of its user.
+// It returns sql.ErrNoRows when the token was already used or has expired.
+func (r *userRepository) ResetPassword(ctx context.Context, token *models.PasswordResetToken, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Only one request can use the token
+	res, err := tx.ExecContext(ctx, "UPDATE password_reset_tokens SET used_at = NOW() WHERE id = ? AND used_at IS NULL AND expires_at > NOW()", token.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE users SET password_hash = ?, password_reset_required = FALSE WHERE id = ?", hashedPassword, token.UserID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetMFASecret stores the TOTP secret of an enrollment, replacing an unconfirmed one.
+// It returns sql.ErrNoRows when the user does not exist or already enabled two-factor authentication.
+func (r *userRepository) SetMFASecret(ctx context.Context, userID, secret string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE users SET mfa_secret = ? WHERE id = ? AND mfa_enabled_at IS NULL", secret, userID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// EnableMFA confirms the stored TOTP secret of a user and replaces the recovery codes.
+// It returns sql.ErrNoRows when the user has no secret to confirm.
+func (r *userRepository) EnableMFA(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "UPDATE users SET mfa_enabled_at = NOW() WHERE id = ? AND mfa_secret IS NOT NULL AND mfa_enabled_at IS NULL", userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = checkRowsAffected(res); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DisableMFA removes the TOTP secret and the recovery codes of a user.
+func (r *userRepository) DisableMFA(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "UPDATE users SET mfa_secret = NULL, mfa_enabled_at = NULL WHERE id = ?", userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = ?", userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceRecoveryCodes replaces every recovery code of a user, used or not.
+func (r *userRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode marks a recovery code of the user as used.
+// It returns sql.ErrNoRows when the user has no unused code with the given hash.
+func (r *userRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE mfa_recovery_codes SET used_at = NOW() WHERE user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES (?, ?)", userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListUsers lists users matching the filter, newest first.
+func (r *userRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	query := selectUser + " WHERE 1 = 1"
+	args := []interface{}{}
+
+	if filter.Query != "" {
+		query += " AND (username LIKE ? OR email LIKE ?)"
+		pattern := containsPattern(filter.Query)
+		args = append(args, pattern, pattern)
+	}
+	if filter.Role != "" {
+		query += " AND role = ?"
+		args = append(args, filter.Role)
+	}
+	switch filter.Status {
+	case models.UserStatusActive:
+		query += " AND disabled_at IS NULL"
+	case models.UserStatusDisabled:
+		query += " AND disabled_at IS NOT NULL"
+	}
+
+	query += " ORDER BY created_at DESC, id LIMIT ? OFFSET ?"
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		users = append(users, *user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetUserDetail returns a user with the summary of their votes, reviews, views and sessions.
+// It returns sql.ErrNoRows when the user does not exist.
+func (r *userRepository) GetUserDetail(ctx context.Context, userID string) (*models.UserDetail, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, selectUser+" WHERE id = ?", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM votes WHERE user_id = ?),
+			(SELECT COUNT(*) FROM reviews WHERE user_id = ?),
+			(SELECT COUNT(*) FROM movie_view_events WHERE user_id = ?),
+			(SELECT COUNT(*) FROM user_sessions WHERE user_id = ? AND revoked_at IS NULL AND expires_at > NOW()),
+			(SELECT MAX(last_used_at) FROM user_sessions WHERE user_id = ?),
+			(SELECT MAX(viewed_at) FROM movie_view_events WHERE user_id = ?)
+	`
+	detail := &models.UserDetail{User: *user, MFAEnabled: user.MFAEnabled()}
+	var lastActiveAt, lastViewedAt sql.NullTime
+	err = r.db.QueryRowContext(ctx, query, userID, userID, userID, userID, userID, userID).
+		Scan(&detail.Votes, &detail.Reviews, &detail.Views, &detail.ActiveSessions, &lastActiveAt, &lastViewedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastActiveAt.Valid {
+		detail.LastActiveAt = &lastActiveAt.Time
+	}
+	if lastViewedAt.Valid {
+		detail.LastViewedAt = &lastViewedAt.Time
+	}
+
+	return detail, nil
+}
+
+// ListUserVotes lists the movies a user voted for, newest vote first.
+func (r *userRepository) ListUserVotes(ctx context.Context, userID string, limit, offset int) ([]models.UserVote, error) {
+	query := `
+		SELECT v.movie_id, m.title, v.created_at
+		FROM votes v
+		JOIN movies m ON m.id = v.movie_id
+		WHERE v.user_id = ?
+		ORDER BY v.created_at DESC, v.movie_id
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	votes := []models.UserVote{}
+	for rows.Next() {
+		var vote models.UserVote
+		if err := rows.Scan(&vote.MovieID, &vote.Title, &vote.VotedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		votes = append(votes, vote)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return votes, nil
+}
+
+// SetDisabled disables or enables the account of a user.
+// It returns sql.ErrNoRows when the user does not exist or already has that status.
+func (r *userRepository) SetDisabled(ctx context.Context, userID string, disabled bool) error {
+	query := "UPDATE users SET disabled_at = NOW() WHERE id = ? AND disabled_at IS NULL"
+	if !disabled {
+		query = "UPDATE users SET disabled_at = NULL WHERE id = ? AND disabled_at IS NOT NULL"
+	}
+
+	res, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// RequirePasswordReset blocks the logins of a user until they reset their password.
+// It returns sql.ErrNoRows when the user does not exist.
+func (r *userRepository) RequirePasswordReset(ctx context.Context, userID string) error {
+	var id string
+	if err := r.db.QueryRowContext(ctx, "SELECT id FROM users WHERE id = ?", userID).Scan(&id); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, "UPDATE users SET password_reset_required = TRUE WHERE id = ?", userID)
+	return err
+}
+
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	var email, mfaSecret sql.NullString
+	var mfaEnabledAt, disabledAt sql.NullTime
+	if err := row.Scan(&user.ID, &user.Username, &email, &user.PasswordHash, &user.Role, &mfaSecret, &mfaEnabledAt,
+		&disabledAt, &user.PasswordResetRequired, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	user.Email = email.String
+	user.MFASecret = mfaSecret.String
+	if mfaEnabledAt.Valid {
+		user.MFAEnabledAt = &mfaEnabledAt.Time
+	}
+	if disabledAt.Valid {
+		user.DisabledAt = &disabledAt.Time
+	}
 	return &user, nil
 }
+
+// likeEscaper escapes the LIKE wildcards, so they match themselves
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns the LIKE pattern matching the values containing text
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
@@ -0,0 +1,446 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type VotingRepository interface {
+	ListPeriods(ctx context.Context, editionID string) ([]models.VotingPeriod, error)
+	ListOpenPeriods(ctx context.Context, at time.Time) ([]models.VotingPeriod, error)
+	ListOpenPeriodsForMovie(ctx context.Context, movieID string, at time.Time) ([]models.VotingPeriod, error)
+	FindPeriod(ctx context.Context, periodID string) (*models.VotingPeriod, error)
+	CreatePeriod(ctx context.Context, period *models.VotingPeriod) error
+	UpdatePeriod(ctx context.Context, period *models.VotingPeriod) error
+	DeletePeriod(ctx context.Context, periodID string) error
+	CountVotes(ctx context.Context, periodID, userID string) (int, error)
+	FindVote(ctx context.Context, userID, movieID string, periodIDs []string) (*models.Vote, error)
+	CastVote(ctx context.Context, userID, movieID string, period *models.VotingPeriod) (bool, error)
+	HasBallots(ctx context.Context, periodID string) (bool, error)
+	CountEligibleMovies(ctx context.Context, periodID string, movieIDs []string) (int, error)
+	FindBallot(ctx context.Context, periodID, userID string) (*models.Ballot, error)
+	SaveBallot(ctx context.Context, ballot *models.Ballot) error
+	DeleteBallot(ctx context.Context, periodID, userID string) error
+	ListBallots(ctx context.Context, periodID string) ([]models.Ballot, error)
+	ListApprovals(ctx context.Context, periodID string) ([]models.TallyResult, error)
+}
+
+// ErrVoteExists is returned by CastVote when the user already voted for the movie in the voting period
+var ErrVoteExists = errors.New("vote already exists")
+
+// mysqlDuplicateEntry is the MySQL error number of a duplicate key
+const mysqlDuplicateEntry = 1062
+
+type votingRepository struct {
+	db *sql.DB
+}
+
+func NewVotingRepository(db *sql.DB) VotingRepository {
+	return &votingRepository{db}
+}
+
+const selectVotingPeriod = `
+	SELECT p.id, p.edition_id, e.name, p.section_id, s.name, p.opens_at, p.closes_at, p.ballot_mode, p.max_rankings,
+		p.vote_budget, p.created_at, p.updated_at
+	FROM voting_periods p
+	JOIN festival_editions e ON p.edition_id = e.id
+	LEFT JOIN competition_sections s ON p.section_id = s.id`
+
+// votingPeriodCovers matches the movies m a voting period p is for, the entries of its edition or the nominees of its section
+const votingPeriodCovers = `(
+	(p.section_id IS NULL AND EXISTS (
+		SELECT 1 FROM edition_movies em WHERE em.edition_id = p.edition_id AND em.movie_id = m.id))
+	OR (p.section_id IS NOT NULL AND EXISTS (
+		SELECT 1 FROM section_nominations n WHERE n.section_id = p.section_id AND n.movie_id = m.id))
+)`
+
+// ListPeriods lists the voting periods of an edition by opening time.
+func (r *votingRepository) ListPeriods(ctx context.Context, editionID string) ([]models.VotingPeriod, error) {
+	return r.listPeriods(ctx, selectVotingPeriod+" WHERE p.edition_id = ? ORDER BY p.opens_at, p.closes_at", editionID)
+}
+
+// ListOpenPeriods lists the voting periods of open editions that accept votes at the given time, closing first.
+func (r *votingRepository) ListOpenPeriods(ctx context.Context, at time.Time) ([]models.VotingPeriod, error) {
+	query := selectVotingPeriod + `
+		WHERE e.status = ? AND p.opens_at <= ? AND p.closes_at > ?
+		ORDER BY p.closes_at, p.opens_at`
+	return r.listPeriods(ctx, query, models.EditionStatusOpen, at, at)
+}
+
+// ListOpenPeriodsForMovie lists the approval voting periods of open editions that accept votes for a movie at the given time.
+// Periods of a section the movie is nominated in come before the periods of a whole edition, then the periods closing first.
+func (r *votingRepository) ListOpenPeriodsForMovie(ctx context.Context, movieID string, at time.Time) ([]models.VotingPeriod, error) {
+	query := selectVotingPeriod + `
+		JOIN movies m ON m.id = ? AND m.deleted_at IS NULL
+		WHERE e.status = ? AND p.ballot_mode = ? AND p.opens_at <= ? AND p.closes_at > ? AND ` + votingPeriodCovers + `
+		ORDER BY p.section_id IS NULL, p.closes_at, p.id`
+	return r.listPeriods(ctx, query, movieID, models.EditionStatusOpen, models.BallotModeApproval, at, at)
+}
+
+func (r *votingRepository) FindPeriod(ctx context.Context, periodID string) (*models.VotingPeriod, error) {
+	row := r.db.QueryRowContext(ctx, selectVotingPeriod+" WHERE p.id = ?", periodID)
+	return scanVotingPeriod(row)
+}
+
+func (r *votingRepository) CreatePeriod(ctx context.Context, period *models.VotingPeriod) error {
+	query := `INSERT INTO voting_periods (id, edition_id, section_id, opens_at, closes_at, ballot_mode, max_rankings, vote_budget)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, period.ID, period.EditionID, nullString(period.SectionID),
+		period.OpensAt, period.ClosesAt, period.BallotMode, nullInt(period.MaxRankings), period.VoteBudget)
+	return err
+}
+
+// UpdatePeriod moves a voting period, changes its section, its ballot mode or its vote budget.
+// It returns sql.ErrNoRows when the period does not exist.
+func (r *votingRepository) UpdatePeriod(ctx context.Context, period *models.VotingPeriod) error {
+	// Make sure the period exists, an update with unchanged values affects no rows
+	if _, err := r.FindPeriod(ctx, period.ID); err != nil {
+		return err
+	}
+
+	query := `UPDATE voting_periods SET section_id = ?, opens_at = ?, closes_at = ?, ballot_mode = ?, max_rankings = ?, vote_budget = ?
+		WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, nullString(period.SectionID), period.OpensAt, period.ClosesAt,
+		period.BallotMode, nullInt(period.MaxRankings), period.VoteBudget, period.ID)
+	return err
+}
+
+// DeletePeriod deletes a voting period with its ranked ballots, the approval votes cast in it are kept.
+// It returns sql.ErrNoRows when the period does not exist.
+func (r *votingRepository) DeletePeriod(ctx context.Context, periodID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM voting_periods WHERE id = ?", periodID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// CountVotes counts the votes a user cast in a voting period.
+func (r *votingRepository) CountVotes(ctx context.Context, periodID, userID string) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM votes WHERE voting_period_id = ? AND user_id = ?"
+	err := r.db.QueryRowContext(ctx, query, periodID, userID).Scan(&count)
+	return count, err
+}
+
+// FindVote retrieves the vote of a user for a movie cast in one of the voting periods, sql.ErrNoRows when there is none.
+func (r *votingRepository) FindVote(ctx context.Context, userID, movieID string, periodIDs []string) (*models.Vote, error) {
+	if len(periodIDs) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	args := []interface{}{userID, movieID}
+	for _, id := range periodIDs {
+		args = append(args, id)
+	}
+	query := `SELECT id, user_id, movie_id, voting_period_id FROM votes
+		WHERE user_id = ? AND movie_id = ? AND voting_period_id IN (?` + strings.Repeat(", ?", len(periodIDs)-1) + `)
+		LIMIT 1`
+
+	vote := &models.Vote{}
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&vote.ID, &vote.UserID, &vote.MovieID, &vote.VotingPeriodID); err != nil {
+		return nil, err
+	}
+
+	return vote, nil
+}
+
+// CastVote records the vote of a user for a movie against a voting period.
+// It returns false without recording the vote when the user has no vote left in the period,
+// and ErrVoteExists when the user already voted for the movie in the period.
+func (r *votingRepository) CastVote(ctx context.Context, userID, movieID string, period *models.VotingPeriod) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the user so concurrent votes cannot overspend the budget
+	var id string
+	if err = tx.QueryRowContext(ctx, "SELECT id FROM users WHERE id = ? FOR UPDATE", userID).Scan(&id); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if period.VoteBudget != nil {
+		var used int
+		query := "SELECT COUNT(*) FROM votes WHERE voting_period_id = ? AND user_id = ?"
+		if err = tx.QueryRowContext(ctx, query, period.ID, userID).Scan(&used); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		if used >= *period.VoteBudget {
+			tx.Rollback()
+			return false, nil
+		}
+	}
+
+	query := "INSERT INTO votes (id, user_id, movie_id, voting_period_id) VALUES (?, ?, ?, ?)"
+	if _, err = tx.ExecContext(ctx, query, uuid.NewString(), userID, movieID, period.ID); err != nil {
+		tx.Rollback()
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return false, ErrVoteExists
+		}
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// HasBallots tells whether any vote or ranked ballot was cast in a voting period.
+func (r *votingRepository) HasBallots(ctx context.Context, periodID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM votes WHERE voting_period_id = ?)
+		OR EXISTS (SELECT 1 FROM ranked_ballots WHERE voting_period_id = ?)`
+	err := r.db.QueryRowContext(ctx, query, periodID, periodID).Scan(&exists)
+	return exists, err
+}
+
+// CountEligibleMovies counts the movies among the given ones that can be voted for in a voting period.
+func (r *votingRepository) CountEligibleMovies(ctx context.Context, periodID string, movieIDs []string) (int, error) {
+	if len(movieIDs) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, 0, len(movieIDs)+1)
+	for _, id := range movieIDs {
+		args = append(args, id)
+	}
+	args = append(args, periodID)
+
+	var count int
+	query := `
+		SELECT COUNT(DISTINCT m.id)
+		FROM voting_periods p
+		JOIN movies m ON m.id IN (?` + strings.Repeat(", ?", len(movieIDs)-1) + `) AND m.deleted_at IS NULL
+		WHERE p.id = ? AND ` + votingPeriodCovers
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// FindBallot returns the ranked ballot of a user in a voting period, or sql.ErrNoRows when they did not cast one.
+func (r *votingRepository) FindBallot(ctx context.Context, periodID, userID string) (*models.Ballot, error) {
+	var ballot models.Ballot
+	query := "SELECT id, voting_period_id, user_id, created_at, updated_at FROM ranked_ballots WHERE voting_period_id = ? AND user_id = ?"
+	err := r.db.QueryRowContext(ctx, query, periodID, userID).
+		Scan(&ballot.ID, &ballot.VotingPeriodID, &ballot.UserID, &ballot.CreatedAt, &ballot.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT br.position, br.movie_id, m.title
+		FROM ballot_rankings br
+		JOIN movies m ON br.movie_id = m.id
+		WHERE br.ballot_id = ?
+		ORDER BY br.position`
+	rows, err := r.db.QueryContext(ctx, query, ballot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	ballot.Rankings = []models.BallotRanking{}
+	for rows.Next() {
+		var ranking models.BallotRanking
+		if err := rows.Scan(&ranking.Position, &ranking.MovieID, &ranking.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ballot.Rankings = append(ballot.Rankings, ranking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return &ballot, nil
+}
+
+// SaveBallot casts the ranked ballot of a user in a voting period, replacing the rankings of the ballot they already cast.
+// The movies are ranked in the order of ballot.Rankings, whatever their positions.
+func (r *votingRepository) SaveBallot(ctx context.Context, ballot *models.Ballot) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := "SELECT id FROM ranked_ballots WHERE voting_period_id = ? AND user_id = ? FOR UPDATE"
+	err = tx.QueryRowContext(ctx, query, ballot.VotingPeriodID, ballot.UserID).Scan(&ballot.ID)
+	switch {
+	case err == sql.ErrNoRows:
+		ballot.ID = uuid.NewString()
+		query = "INSERT INTO ranked_ballots (id, voting_period_id, user_id) VALUES (?, ?, ?)"
+		_, err = tx.ExecContext(ctx, query, ballot.ID, ballot.VotingPeriodID, ballot.UserID)
+	case err == nil:
+		// Touch the ballot so updated_at tells when it was last changed
+		_, err = tx.ExecContext(ctx, "UPDATE ranked_ballots SET updated_at = CURRENT_TIMESTAMP WHERE id = ?", ballot.ID)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM ballot_rankings WHERE ballot_id = ?", ballot.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range ballot.Rankings {
+		ballot.Rankings[i].Position = i + 1
+		query = "INSERT INTO ballot_rankings (ballot_id, position, movie_id) VALUES (?, ?, ?)"
+		if _, err = tx.ExecContext(ctx, query, ballot.ID, ballot.Rankings[i].Position, ballot.Rankings[i].MovieID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteBallot withdraws the ranked ballot of a user from a voting period.
+// It returns sql.ErrNoRows when they did not cast one.
+func (r *votingRepository) DeleteBallot(ctx context.Context, periodID, userID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM ranked_ballots WHERE voting_period_id = ? AND user_id = ?", periodID, userID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+// ListBallots lists the ranked ballots of a voting period to tally them. The rankings only keep the movies
+// that can still be voted for in the period, a withdrawn or deleted movie no longer counts.
+func (r *votingRepository) ListBallots(ctx context.Context, periodID string) ([]models.Ballot, error) {
+	query := `
+		SELECT b.id, b.user_id, br.position, br.movie_id, m.title
+		FROM ranked_ballots b
+		JOIN voting_periods p ON b.voting_period_id = p.id
+		JOIN ballot_rankings br ON br.ballot_id = b.id
+		JOIN movies m ON br.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE b.voting_period_id = ? AND ` + votingPeriodCovers + `
+		ORDER BY b.created_at, b.id, br.position`
+	rows, err := r.db.QueryContext(ctx, query, periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	ballots := []models.Ballot{}
+	for rows.Next() {
+		var ballotID, userID string
+		var ranking models.BallotRanking
+		if err := rows.Scan(&ballotID, &userID, &ranking.Position, &ranking.MovieID, &ranking.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if len(ballots) == 0 || ballots[len(ballots)-1].ID != ballotID {
+			ballots = append(ballots, models.Ballot{ID: ballotID, VotingPeriodID: periodID, UserID: userID})
+		}
+		last := &ballots[len(ballots)-1]
+		last.Rankings = append(last.Rankings, ranking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return ballots, nil
+}
+
+// ListApprovals counts the votes cast for each movie in an approval voting period, most voted first.
+// Like ListBallots, only the movies that can still be voted for in the period count.
+func (r *votingRepository) ListApprovals(ctx context.Context, periodID string) ([]models.TallyResult, error) {
+	query := `
+		SELECT m.id, m.title, COUNT(*) AS votes
+		FROM votes v
+		JOIN voting_periods p ON v.voting_period_id = p.id
+		JOIN movies m ON v.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE v.voting_period_id = ? AND ` + votingPeriodCovers + `
+		GROUP BY m.id, m.title
+		ORDER BY votes DESC, m.title, m.id`
+	rows, err := r.db.QueryContext(ctx, query, periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.TallyResult{}
+	for rows.Next() {
+		var result models.TallyResult
+		if err := rows.Scan(&result.MovieID, &result.Title, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *votingRepository) listPeriods(ctx context.Context, query string, args ...interface{}) ([]models.VotingPeriod, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	periods := []models.VotingPeriod{}
+	for rows.Next() {
+		period, err := scanVotingPeriod(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		periods = append(periods, *period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return periods, nil
+}
+
+func scanVotingPeriod(row rowScanner) (*models.VotingPeriod, error) {
+	var period models.VotingPeriod
+	var sectionID, sectionName sql.NullString
+	var maxRankings, voteBudget sql.NullInt64
+	err := row.Scan(&period.ID, &period.EditionID, &period.EditionName, &sectionID, &sectionName, &period.OpensAt, &period.ClosesAt,
+		&period.BallotMode, &maxRankings, &voteBudget, &period.CreatedAt, &period.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	period.SectionID = sectionID.String
+	period.SectionName = sectionName.String
+	period.MaxRankings = int(maxRankings.Int64)
+	if voteBudget.Valid {
+		budget := int(voteBudget.Int64)
+		period.VoteBudget = &budget
+	}
+	return &period, nil
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+type WatchProgressRepository interface {
+	FindProgress(ctx context.Context, userID, movieID string) (*models.WatchProgress, error)
+	SaveProgress(ctx context.Context, progress *models.WatchProgress) error
+	ListInProgress(ctx context.Context, userID string, limit int) ([]models.WatchProgress, error)
+}
+
+type watchProgressRepository struct {
+	db *sql.DB
+}
+
+func NewWatchProgressRepository(db *sql.DB) WatchProgressRepository {
+	return &watchProgressRepository{db}
+}
+
+// FindProgress returns the playback position of a user in a movie, or nil when the user never played it.
+func (r *watchProgressRepository) FindProgress(ctx context.Context, userID, movieID string) (*models.WatchProgress, error) {
+	query := `SELECT user_id, movie_id, position_seconds, completed_at, updated_at FROM watch_progress WHERE user_id = ? AND movie_id = ?`
+	progress, err := scanWatchProgress(r.db.QueryRowContext(ctx, query, userID, movieID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return progress, err
+}
+
+// SaveProgress stores the playback position of a user in a movie.
+// The first report with Completed set records a completion, later ones keep the original completion time.
+// A report without Completed clears the completion so the movie shows as in progress again, but a user's completion
+// of a movie is recorded once, so seeking back and forth does not inflate the completions.
+func (r *watchProgressRepository) SaveProgress(ctx context.Context, progress *models.WatchProgress) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the row so concurrent reports cannot both record the completion
+	var completedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, "SELECT completed_at FROM watch_progress WHERE user_id = ? AND movie_id = ? FOR UPDATE",
+		progress.UserID, progress.MovieID).Scan(&completedAt)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return err
+	}
+
+	progress.CompletedAt = nil
+	if progress.Completed {
+		if !completedAt.Valid {
+			completedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO movie_completions (movie_id, user_id, completed_at) VALUES (?, ?, ?)",
+				progress.MovieID, progress.UserID, completedAt.Time)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		progress.CompletedAt = &completedAt.Time
+	}
+
+	query := `
+		INSERT INTO watch_progress (user_id, movie_id, position_seconds, completed_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			position_seconds = VALUES(position_seconds),
+			completed_at = VALUES(completed_at)
+	`
+	_, err = tx.ExecContext(ctx, query, progress.UserID, progress.MovieID, progress.PositionSeconds, progress.CompletedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	progress.UpdatedAt = time.Now()
+	return tx.Commit()
+}
+
+// ListInProgress retrieves the movies a user started and did not finish, most recently played first.
+// Only the movie ID of each entry is set.
+func (r *watchProgressRepository) ListInProgress(ctx context.Context, userID string, limit int) ([]models.WatchProgress, error) {
+	query := `
+		SELECT user_id, movie_id, position_seconds, completed_at, updated_at
+		FROM watch_progress
+		WHERE user_id = ? AND completed_at IS NULL AND position_seconds > 0
+		ORDER BY updated_at DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.WatchProgress{}
+	for rows.Next() {
+		progress, err := scanWatchProgress(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entries = append(entries, *progress)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+func scanWatchProgress(row rowScanner) (*models.WatchProgress, error) {
+	var progress models.WatchProgress
+	var completedAt sql.NullTime
+	if err := row.Scan(&progress.UserID, &progress.MovieID, &progress.PositionSeconds, &completedAt, &progress.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		progress.Completed = true
+		progress.CompletedAt = &completedAt.Time
+	}
+
+	return &progress, nil
+}
@@ -5,32 +5,157 @@ import (
 
 	"github.com/stwrtrio/movie-festival/internal/controllers"
 	"github.com/stwrtrio/movie-festival/internal/middlewares"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
 )
 
-func RegisterRoutes(e *echo.Echo, movieController *controllers.MovieController, userController *controllers.UserController) {
+func RegisterRoutes(e *echo.Echo, movieController *controllers.MovieController, userController *controllers.UserController, artistController *controllers.ArtistController, genreController *controllers.GenreController, reviewController *controllers.ReviewController, movieListController *controllers.MovieListController, watchProgressController *controllers.WatchProgressController, lockoutController *controllers.LockoutController, roleController *controllers.RoleController, oidcController *controllers.OIDCController, editionController *controllers.EditionController, competitionController *controllers.CompetitionController, juryController *controllers.JuryController, votingController *controllers.VotingController, roleService services.RoleService) {
 
 	// Public routes (no authentication required)
 	e.POST("/api/user/register", userController.Register)
 	e.POST("/api/user/login", userController.Login)
+	e.POST("/api/user/login/mfa", userController.LoginMFA)
+	e.POST("/api/user/login/mfa/setup", userController.LoginMFASetup)
+	e.POST("/api/user/refresh", userController.Refresh)
+	e.POST("/api/user/password/forgot", userController.ForgotPassword, middlewares.PasswordForgotRateLimitMiddleware)
+	e.POST("/api/user/password/reset", userController.ResetPassword)
+	e.GET("/.well-known/jwks.json", userController.JWKS)
+	e.GET("/api/user/oidc/providers", oidcController.ListProviders)
+	e.GET("/api/user/oidc/:provider/authorize", oidcController.Authorize, middlewares.OptionalAuthMiddleware)
+	e.GET("/api/user/oidc/:provider/callback", oidcController.Callback)
 
-	e.POST("/api/movies/:id/view", movieController.TrackMovieView)
+	e.POST("/api/movies/:id/view", movieController.TrackMovieView, middlewares.ViewRateLimitMiddleware, middlewares.OptionalAuthMiddleware)
 	e.GET("/api/movies", movieController.GetAllMovies)
 	e.GET("/api/movies/search", movieController.SearchMovies)
+	e.GET("/api/movies/:id", movieController.GetMovieDetail)
+	e.GET("/api/movies/:id/reviews", reviewController.ListReviews)
+	e.GET("/api/artists/:id", artistController.GetArtist)
+	e.GET("/api/editions", editionController.ListPublishedEditions)
+	e.GET("/api/editions/:id", editionController.GetPublishedEdition)
+	e.GET("/api/awards", competitionController.ListAwards)
 
 	// Authenticated user routes
 	userGroup := e.Group("/api/user")
 	userGroup.Use(middlewares.AuthMiddleware)
 	userGroup.POST("/logout", userController.Logout)
-	userGroup.POST("/movies/:id/vote", movieController.VoteMovie)
-	userGroup.POST("/movies/:id/unvote", movieController.UnvoteMovie)
+	userGroup.POST("/password", userController.ChangePassword)
+	userGroup.POST("/mfa/setup", userController.SetupMFA)
+	userGroup.POST("/mfa/enable", userController.EnableMFA)
+	userGroup.POST("/mfa/disable", userController.DisableMFA)
+	userGroup.POST("/mfa/recovery-codes", userController.RegenerateRecoveryCodes)
+	userGroup.GET("/identities", oidcController.ListIdentities)
+	userGroup.GET("/sessions", userController.ListSessions)
+	userGroup.DELETE("/sessions", userController.RevokeAllSessions)
+	userGroup.DELETE("/sessions/:id", userController.RevokeSession)
+	userGroup.POST("/movies/:id/vote", votingController.VoteMovie)
+	userGroup.POST("/movies/:id/unvote", votingController.UnvoteMovie)
+	userGroup.GET("/voting", votingController.ListUserPeriods)
+	userGroup.GET("/voting/:id/ballot", votingController.GetBallot)
+	userGroup.POST("/voting/:id/ballot", votingController.CastBallot)
+	userGroup.DELETE("/voting/:id/ballot", votingController.WithdrawBallot)
 	userGroup.GET("/votes", movieController.GetUserVotesController)
+	userGroup.POST("/movies/:id/review", reviewController.CreateReview)
+	userGroup.POST("/review/:id", reviewController.UpdateReview)
+	userGroup.DELETE("/review/:id", reviewController.DeleteReview)
+	userGroup.POST("/review/:id/helpful", reviewController.MarkHelpful)
+	userGroup.DELETE("/review/:id/helpful", reviewController.UnmarkHelpful)
+	userGroup.POST("/review/:id/report", reviewController.ReportReview)
+	userGroup.GET("/lists/:list", movieListController.GetList)
+	userGroup.POST("/lists/:list", movieListController.AddMovie)
+	userGroup.POST("/lists/:list/order", movieListController.Reorder)
+	userGroup.DELETE("/lists/:list/:movie_id", movieListController.RemoveMovie)
+	userGroup.POST("/lists/:list/:movie_id/watched", movieListController.MarkWatched)
+	userGroup.GET("/movies/:id/progress", watchProgressController.GetProgress)
+	userGroup.POST("/movies/:id/progress", watchProgressController.SaveProgress)
+	userGroup.GET("/continue", watchProgressController.ContinueWatching)
+
+	// Admin routes, each guarded by the permission it needs
+	movieWrite := middlewares.RequirePermission(roleService, models.PermissionMovieWrite)
+	analyticsRead := middlewares.RequirePermission(roleService, models.PermissionAnalyticsRead)
+	reviewModerate := middlewares.RequirePermission(roleService, models.PermissionReviewModerate)
+	userManage := middlewares.RequirePermission(roleService, models.PermissionUserManage)
+	roleManage := middlewares.RequirePermission(roleService, models.PermissionRoleManage)
+	festivalManage := middlewares.RequirePermission(roleService, models.PermissionFestivalManage)
+	juryScore := middlewares.RequirePermission(roleService, models.PermissionJuryScore)
 
-	// Admin routes
 	adminGroup := e.Group("/api/admin")
-	adminGroup.Use(middlewares.AdminAuthMiddleware)
-	adminGroup.POST("/movie", movieController.CreateMovie)
-	adminGroup.POST("/movie/:id", movieController.UpdateMovie)
-	adminGroup.GET("/movies/most-viewed", movieController.GetMostViewedMovie)
-	adminGroup.GET("/movies/most-viewed-genres", movieController.GetMostViewedGenre)
-	adminGroup.GET("/movies/most-voted", movieController.GetMostVotedMovie)
+	adminGroup.Use(middlewares.AuthMiddleware)
+	adminGroup.POST("/movie", movieController.CreateMovie, movieWrite)
+	adminGroup.POST("/movie/:id", movieController.UpdateMovie, movieWrite)
+	adminGroup.DELETE("/movie/:id", movieController.DeleteMovie, movieWrite)
+	adminGroup.POST("/movie/:id/restore", movieController.RestoreMovie, movieWrite)
+	adminGroup.DELETE("/movie/:id/purge", movieController.PurgeMovie, movieWrite)
+	adminGroup.GET("/movies/most-viewed", movieController.GetMostViewedMovie, analyticsRead)
+	adminGroup.GET("/movies/most-viewed-genres", movieController.GetMostViewedGenre, analyticsRead)
+	adminGroup.GET("/movies/most-voted", movieController.GetMostVotedMovie, analyticsRead)
+	adminGroup.GET("/movies/suspicious-views", movieController.GetSuspectedViewInflation, analyticsRead)
+	adminGroup.GET("/movies/:id/views", movieController.GetMovieViewStats, analyticsRead)
+	adminGroup.GET("/artists", artistController.ListArtists, movieWrite)
+	adminGroup.POST("/artist", artistController.CreateArtist, movieWrite)
+	adminGroup.POST("/artist/:id", artistController.UpdateArtist, movieWrite)
+	adminGroup.DELETE("/artist/:id", artistController.DeleteArtist, movieWrite)
+	adminGroup.POST("/artist/:id/merge", artistController.MergeArtists, movieWrite)
+	adminGroup.GET("/genres", genreController.ListGenres, movieWrite)
+	adminGroup.POST("/genre", genreController.CreateGenre, movieWrite)
+	adminGroup.POST("/genre/:id", genreController.UpdateGenre, movieWrite)
+	adminGroup.DELETE("/genre/:id", genreController.DeleteGenre, movieWrite)
+	adminGroup.POST("/genre/:id/merge", genreController.MergeGenres, movieWrite)
+	adminGroup.GET("/reviews", reviewController.ListModerationQueue, reviewModerate)
+	adminGroup.POST("/review/:id/approve", reviewController.ApproveReview, reviewModerate)
+	adminGroup.POST("/review/:id/reject", reviewController.RejectReview, reviewModerate)
+	adminGroup.GET("/review/:id/actions", reviewController.ListModerationActions, reviewModerate)
+	adminGroup.GET("/lockouts", lockoutController.ListEvents, userManage)
+	adminGroup.POST("/lockouts/unlock", lockoutController.Unlock, userManage)
+	adminGroup.GET("/users", userController.ListUsers, userManage)
+	adminGroup.GET("/user/:id", userController.GetUserDetail, userManage)
+	adminGroup.GET("/user/:id/votes", userController.ListUserVotes, userManage)
+	adminGroup.POST("/user/:id/disable", userController.DisableUser, userManage)
+	adminGroup.POST("/user/:id/enable", userController.EnableUser, userManage)
+	adminGroup.POST("/user/:id/password-reset", userController.ForcePasswordReset, userManage)
+	adminGroup.POST("/user/:id/role", roleController.AssignRole, userManage)
+	adminGroup.GET("/roles", roleController.ListRoles, userManage)
+	adminGroup.GET("/permissions", roleController.ListPermissions, userManage)
+	adminGroup.POST("/role", roleController.CreateRole, roleManage)
+	adminGroup.POST("/role/:name", roleController.UpdateRole, roleManage)
+	adminGroup.DELETE("/role/:name", roleController.DeleteRole, roleManage)
+	adminGroup.GET("/editions", editionController.ListEditions, festivalManage)
+	adminGroup.GET("/edition/:id", editionController.GetEdition, festivalManage)
+	adminGroup.POST("/edition", editionController.CreateEdition, festivalManage)
+	adminGroup.POST("/edition/:id", editionController.UpdateEdition, festivalManage)
+	adminGroup.POST("/edition/:id/open", editionController.OpenEdition, festivalManage)
+	adminGroup.POST("/edition/:id/close", editionController.CloseEdition, festivalManage)
+	adminGroup.POST("/edition/:id/movies", editionController.EnterMovies, festivalManage)
+	adminGroup.DELETE("/edition/:id/movies/:movie_id", editionController.WithdrawMovie, festivalManage)
+	adminGroup.GET("/edition/:id/sections", competitionController.ListSections, festivalManage)
+	adminGroup.POST("/edition/:id/section", competitionController.CreateSection, festivalManage)
+	adminGroup.POST("/section/:id", competitionController.UpdateSection, festivalManage)
+	adminGroup.DELETE("/section/:id", competitionController.DeleteSection, festivalManage)
+	adminGroup.POST("/section/:id/nominations", competitionController.NominateMovies, festivalManage)
+	adminGroup.DELETE("/section/:id/nominations/:movie_id", competitionController.RemoveNomination, festivalManage)
+	adminGroup.POST("/section/:id/category", competitionController.CreateAwardCategory, festivalManage)
+	adminGroup.POST("/category/:id", competitionController.UpdateAwardCategory, festivalManage)
+	adminGroup.DELETE("/category/:id", competitionController.DeleteAwardCategory, festivalManage)
+	adminGroup.POST("/category/:id/winners", competitionController.AddWinner, festivalManage)
+	adminGroup.DELETE("/category/:id/winners/:movie_id", competitionController.RemoveWinner, festivalManage)
+	adminGroup.GET("/section/:id/jurors", juryController.ListJurors, festivalManage)
+	adminGroup.POST("/section/:id/jurors", juryController.AssignJuror, festivalManage)
+	adminGroup.DELETE("/section/:id/jurors/:user_id", juryController.RemoveJuror, festivalManage)
+	adminGroup.GET("/section/:id/criteria", juryController.ListCriteria, festivalManage)
+	adminGroup.POST("/section/:id/criteria", juryController.CreateCriterion, festivalManage)
+	adminGroup.DELETE("/criterion/:id", juryController.DeleteCriterion, festivalManage)
+	adminGroup.POST("/section/:id/scoring/open", juryController.OpenScoring, festivalManage)
+	adminGroup.POST("/section/:id/scoring/close", juryController.CloseScoring, festivalManage)
+	adminGroup.GET("/section/:id/ranking", juryController.GetRanking, festivalManage)
+	adminGroup.GET("/edition/:id/voting-periods", votingController.ListPeriods, festivalManage)
+	adminGroup.POST("/edition/:id/voting-period", votingController.CreatePeriod, festivalManage)
+	adminGroup.POST("/voting-period/:id", votingController.UpdatePeriod, festivalManage)
+	adminGroup.DELETE("/voting-period/:id", votingController.DeletePeriod, festivalManage)
+	adminGroup.GET("/voting-period/:id/tally", votingController.TallyPeriod, festivalManage)
+
+	// Jury routes, for the users whose role grants jury:score
+	juryGroup := e.Group("/api/jury")
+	juryGroup.Use(middlewares.AuthMiddleware, juryScore)
+	juryGroup.GET("/sections", juryController.ListJurorSections)
+	juryGroup.GET("/section/:id", juryController.GetJurySheet)
+	juryGroup.POST("/section/:id/scores", juryController.SubmitScores)
 }
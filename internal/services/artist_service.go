@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrArtistExists      = errors.New("artist with this name already exists")
+	ErrInvalidArtistDate = errors.New("birth_date must use the YYYY-MM-DD format")
+	ErrMergeSelf         = errors.New("an artist cannot be merged into itself")
+)
+
+type ArtistService interface {
+	CreateArtist(ctx context.Context, req models.ArtistRequest) (*models.ArtistProfile, error)
+	UpdateArtist(ctx context.Context, artistID string, req models.ArtistRequest) (*models.ArtistProfile, error)
+	DeleteArtist(ctx context.Context, artistID string) error
+	ListArtists(ctx context.Context, query string, limit, offset int) ([]models.ArtistProfile, error)
+	MergeArtists(ctx context.Context, targetID string, sourceIDs []string) error
+	GetArtistFilmography(ctx context.Context, artistID string) (*models.ArtistFilmography, error)
+}
+
+type artistService struct {
+	repo repositories.ArtistRepository
+}
+
+func NewArtistService(repo repositories.ArtistRepository) ArtistService {
+	return &artistService{repo: repo}
+}
+
+func (s *artistService) CreateArtist(ctx context.Context, req models.ArtistRequest) (*models.ArtistProfile, error) {
+	// Artists are still matched by name when movies are created, keep names unique
+	existing, err := s.repo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrArtistExists
+	}
+
+	artist, err := toArtistProfile(uuid.NewString(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, artist); err != nil {
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+func (s *artistService) UpdateArtist(ctx context.Context, artistID string, req models.ArtistRequest) (*models.ArtistProfile, error) {
+	existing, err := s.repo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != artistID {
+		return nil, ErrArtistExists
+	}
+
+	artist, err := toArtistProfile(artistID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, artist); err != nil {
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+func (s *artistService) DeleteArtist(ctx context.Context, artistID string) error {
+	return s.repo.Delete(ctx, artistID)
+}
+
+func (s *artistService) ListArtists(ctx context.Context, query string, limit, offset int) ([]models.ArtistProfile, error) {
+	return s.repo.List(ctx, query, limit, offset)
+}
+
+// MergeArtists collapses duplicated artists into the target artist
+func (s *artistService) MergeArtists(ctx context.Context, targetID string, sourceIDs []string) error {
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return ErrMergeSelf
+		}
+	}
+
+	return s.repo.Merge(ctx, targetID, sourceIDs)
+}
+
+// GetArtistFilmography returns the artist profile with every credited movie and the summed views and votes
+func (s *artistService) GetArtistFilmography(ctx context.Context, artistID string) (*models.ArtistFilmography, error) {
+	artist, err := s.repo.FindByID(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := s.repo.GetFilmography(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	filmography := &models.ArtistFilmography{
+		ArtistProfile: *artist,
+		Movies:        movies,
+	}
+	for _, movie := range movies {
+		filmography.TotalViews += movie.Views
+		filmography.TotalVotes += movie.Votes
+	}
+
+	return filmography, nil
+}
+
+func toArtistProfile(artistID string, req models.ArtistRequest) (*models.ArtistProfile, error) {
+	artist := &models.ArtistProfile{
+		ID:          artistID,
+		Name:        req.Name,
+		Bio:         req.Bio,
+		PhotoURL:    req.PhotoURL,
+		ExternalIDs: req.ExternalIDs,
+	}
+
+	if req.BirthDate != "" {
+		birthDate, err := time.Parse("2006-01-02", req.BirthDate)
+		if err != nil {
+			return nil, ErrInvalidArtistDate
+		}
+		artist.BirthDate = &birthDate
+	}
+
+	return artist, nil
+}
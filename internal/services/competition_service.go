@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrSectionExists          = errors.New("section with this name already exists in the edition")
+	ErrSectionNotExists       = errors.New("section is not exists")
+	ErrAwardCategoryExists    = errors.New("award category with this name already exists in the section")
+	ErrAwardCategoryNotExists = errors.New("award category is not exists")
+	ErrMovieNotNominated      = errors.New("movie is not nominated in this section")
+	ErrMovieNotAwarded        = errors.New("movie has not won this award")
+)
+
+type CompetitionService interface {
+	ListSections(ctx context.Context, editionID string) ([]models.Section, error)
+	CreateSection(ctx context.Context, editionID string, req models.SectionRequest) (*models.Section, error)
+	UpdateSection(ctx context.Context, sectionID string, req models.SectionRequest) (*models.Section, error)
+	DeleteSection(ctx context.Context, sectionID string) error
+	NominateMovies(ctx context.Context, sectionID string, movieIDs []string) error
+	RemoveNomination(ctx context.Context, sectionID, movieID string) error
+	CreateAwardCategory(ctx context.Context, sectionID string, req models.AwardCategoryRequest) (*models.AwardCategory, error)
+	UpdateAwardCategory(ctx context.Context, categoryID string, req models.AwardCategoryRequest) (*models.AwardCategory, error)
+	DeleteAwardCategory(ctx context.Context, categoryID string) error
+	AddWinner(ctx context.Context, categoryID, movieID string) error
+	RemoveWinner(ctx context.Context, categoryID, movieID string) error
+	ListAwards(ctx context.Context, editionID string) ([]models.EditionAwards, error)
+}
+
+type competitionService struct {
+	repo        repositories.CompetitionRepository
+	editionRepo repositories.EditionRepository
+}
+
+func NewCompetitionService(repo repositories.CompetitionRepository, editionRepo repositories.EditionRepository) CompetitionService {
+	return &competitionService{repo: repo, editionRepo: editionRepo}
+}
+
+// ListSections lists the sections of an edition with their nominees, award categories and winners
+func (s *competitionService) ListSections(ctx context.Context, editionID string) ([]models.Section, error) {
+	if _, err := s.editionRepo.FindByID(ctx, editionID); err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repo.ListSections(ctx, editionID)
+	if err != nil {
+		return nil, err
+	}
+
+	winners, err := s.repo.ListWinners(ctx, editionID)
+	if err != nil {
+		return nil, err
+	}
+	winnersByCategory := make(map[string][]models.Winner)
+	for _, winner := range winners {
+		winnersByCategory[winner.CategoryID] = append(winnersByCategory[winner.CategoryID], winner.Winner)
+	}
+
+	for i := range sections {
+		if sections[i].Nominees, err = s.repo.ListNominees(ctx, sections[i].ID); err != nil {
+			return nil, err
+		}
+		if sections[i].Categories, err = s.repo.ListCategories(ctx, sections[i].ID); err != nil {
+			return nil, err
+		}
+		for j := range sections[i].Categories {
+			sections[i].Categories[j].Winners = winnersByCategory[sections[i].Categories[j].ID]
+		}
+	}
+
+	return sections, nil
+}
+
+// CreateSection adds a section to an edition that is not closed
+func (s *competitionService) CreateSection(ctx context.Context, editionID string, req models.SectionRequest) (*models.Section, error) {
+	if err := s.checkLineupOpen(ctx, editionID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindSectionByName(ctx, editionID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrSectionExists
+	}
+
+	section := &models.Section{ID: uuid.NewString(), EditionID: editionID, Name: req.Name, Description: req.Description}
+	if err := s.repo.CreateSection(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindSection(ctx, section.ID)
+}
+
+// UpdateSection renames a section of an edition that is not closed
+func (s *competitionService) UpdateSection(ctx context.Context, sectionID string, req models.SectionRequest) (*models.Section, error) {
+	section, err := s.findOpenSection(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindSectionByName(ctx, section.EditionID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != sectionID {
+		return nil, ErrSectionExists
+	}
+
+	section.Name = req.Name
+	section.Description = req.Description
+	if err := s.repo.UpdateSection(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindSection(ctx, sectionID)
+}
+
+// DeleteSection deletes a section of an edition that is not closed, with its nominations and awards
+func (s *competitionService) DeleteSection(ctx context.Context, sectionID string) error {
+	if _, err := s.findOpenSection(ctx, sectionID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSection(ctx, sectionID)
+}
+
+// NominateMovies nominates movies entered into the edition in one of its sections
+func (s *competitionService) NominateMovies(ctx context.Context, sectionID string, movieIDs []string) error {
+	if _, err := s.findOpenSection(ctx, sectionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.NominateMovies(ctx, sectionID, movieIDs); err != nil {
+		// The section exists, so the missing row is one of the entries
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMovieNotEntered
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RemoveNomination removes a movie from a section, the awards it won in the section go with it
+func (s *competitionService) RemoveNomination(ctx context.Context, sectionID, movieID string) error {
+	if _, err := s.findOpenSection(ctx, sectionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveNomination(ctx, sectionID, movieID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMovieNotNominated
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CreateAwardCategory adds an award category to a section, awards are also given once the edition is closed
+func (s *competitionService) CreateAwardCategory(ctx context.Context, sectionID string, req models.AwardCategoryRequest) (*models.AwardCategory, error) {
+	if _, err := s.findSection(ctx, sectionID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindCategoryByName(ctx, sectionID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAwardCategoryExists
+	}
+
+	category := &models.AwardCategory{ID: uuid.NewString(), SectionID: sectionID, Name: req.Name, Description: req.Description}
+	if err := s.repo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindCategory(ctx, category.ID)
+}
+
+func (s *competitionService) UpdateAwardCategory(ctx context.Context, categoryID string, req models.AwardCategoryRequest) (*models.AwardCategory, error) {
+	category, err := s.findCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindCategoryByName(ctx, category.SectionID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != categoryID {
+		return nil, ErrAwardCategoryExists
+	}
+
+	category.Name = req.Name
+	category.Description = req.Description
+	if err := s.repo.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindCategory(ctx, categoryID)
+}
+
+func (s *competitionService) DeleteAwardCategory(ctx context.Context, categoryID string) error {
+	if err := s.repo.DeleteCategory(ctx, categoryID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAwardCategoryNotExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// AddWinner records a movie nominated in the section of the category as a winner of the award
+func (s *competitionService) AddWinner(ctx context.Context, categoryID, movieID string) error {
+	if _, err := s.findCategory(ctx, categoryID); err != nil {
+		return err
+	}
+
+	if err := s.repo.AddWinner(ctx, categoryID, movieID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMovieNotNominated
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *competitionService) RemoveWinner(ctx context.Context, categoryID, movieID string) error {
+	if _, err := s.findCategory(ctx, categoryID); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveWinner(ctx, categoryID, movieID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMovieNotAwarded
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListAwards lists the winners of an edition, or of every edition, per section.
+// Draft editions do not exist for the audience.
+func (s *competitionService) ListAwards(ctx context.Context, editionID string) ([]models.EditionAwards, error) {
+	if editionID != "" {
+		edition, err := s.editionRepo.FindByID(ctx, editionID)
+		if err != nil {
+			return nil, err
+		}
+		if edition.Status == models.EditionStatusDraft {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	winners, err := s.repo.ListWinners(ctx, editionID, models.EditionStatusOpen, models.EditionStatusClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupAwards(winners), nil
+}
+
+// checkLineupOpen makes sure the edition exists and is not closed, the sections and nominations of a closed edition are final
+func (s *competitionService) checkLineupOpen(ctx context.Context, editionID string) error {
+	edition, err := s.editionRepo.FindByID(ctx, editionID)
+	if err != nil {
+		return err
+	}
+	if edition.Status == models.EditionStatusClosed {
+		return ErrEditionClosed
+	}
+
+	return nil
+}
+
+func (s *competitionService) findSection(ctx context.Context, sectionID string) (*models.Section, error) {
+	section, err := s.repo.FindSection(ctx, sectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSectionNotExists
+	}
+
+	return section, err
+}
+
+func (s *competitionService) findOpenSection(ctx context.Context, sectionID string) (*models.Section, error) {
+	section, err := s.findSection(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLineupOpen(ctx, section.EditionID); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+func (s *competitionService) findCategory(ctx context.Context, categoryID string) (*models.AwardCategory, error) {
+	category, err := s.repo.FindCategory(ctx, categoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAwardCategoryNotExists
+	}
+
+	return category, err
+}
+
+// groupAwards groups winners ordered by edition, section and category
+func groupAwards(winners []models.AwardWinner) []models.EditionAwards {
+	awards := []models.EditionAwards{}
+	for _, winner := range winners {
+		if len(awards) == 0 || awards[len(awards)-1].EditionID != winner.EditionID {
+			awards = append(awards, models.EditionAwards{EditionID: winner.EditionID, Name: winner.EditionName})
+		}
+		edition := &awards[len(awards)-1]
+
+		if len(edition.Sections) == 0 || edition.Sections[len(edition.Sections)-1].SectionID != winner.SectionID {
+			edition.Sections = append(edition.Sections, models.SectionAwards{SectionID: winner.SectionID, Name: winner.SectionName})
+		}
+		section := &edition.Sections[len(edition.Sections)-1]
+
+		if len(section.Awards) == 0 || section.Awards[len(section.Awards)-1].CategoryID != winner.CategoryID {
+			section.Awards = append(section.Awards, models.Award{CategoryID: winner.CategoryID, Category: winner.CategoryName})
+		}
+		award := &section.Awards[len(section.Awards)-1]
+
+		award.Winners = append(award.Winners, winner.Winner)
+	}
+
+	return awards
+}
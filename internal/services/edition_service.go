@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrEditionExists         = errors.New("edition with this name already exists")
+	ErrInvalidEditionDate    = errors.New("starts_on and ends_on must use the YYYY-MM-DD format")
+	ErrInvalidEditionDates   = errors.New("ends_on must not be before starts_on")
+	ErrInvalidEditionStatus  = errors.New("invalid status, must be draft, open or closed")
+	ErrEditionAlreadyOpen    = errors.New("edition is already open")
+	ErrEditionNotOpen        = errors.New("edition is not open")
+	ErrEditionClosed         = errors.New("edition is closed, its entries are final")
+	ErrEditionMovieNotExists = errors.New("movie is not exists")
+	ErrMovieNotEntered       = errors.New("movie is not entered into this edition")
+)
+
+type EditionService interface {
+	ListEditions(ctx context.Context, status string) ([]models.Edition, error)
+	ListPublishedEditions(ctx context.Context) ([]models.Edition, error)
+	GetEdition(ctx context.Context, editionID string) (*models.EditionDetail, error)
+	GetPublishedEdition(ctx context.Context, editionID string) (*models.EditionDetail, error)
+	CreateEdition(ctx context.Context, req models.EditionRequest) (*models.Edition, error)
+	UpdateEdition(ctx context.Context, editionID string, req models.EditionRequest) (*models.Edition, error)
+	OpenEdition(ctx context.Context, editionID string) error
+	CloseEdition(ctx context.Context, editionID string) error
+	EnterMovies(ctx context.Context, editionID string, movieIDs []string) error
+	WithdrawMovie(ctx context.Context, editionID, movieID string) error
+}
+
+type editionService struct {
+	repo repositories.EditionRepository
+}
+
+func NewEditionService(repo repositories.EditionRepository) EditionService {
+	return &editionService{repo: repo}
+}
+
+// ListEditions lists the editions with the given status, or every edition
+func (s *editionService) ListEditions(ctx context.Context, status string) ([]models.Edition, error) {
+	if status == "" {
+		return s.repo.List(ctx)
+	}
+	if !isValidEditionStatus(status) {
+		return nil, ErrInvalidEditionStatus
+	}
+
+	return s.repo.List(ctx, status)
+}
+
+// ListPublishedEditions lists the editions the audience can see, drafts are still being prepared
+func (s *editionService) ListPublishedEditions(ctx context.Context) ([]models.Edition, error) {
+	return s.repo.List(ctx, models.EditionStatusOpen, models.EditionStatusClosed)
+}
+
+func (s *editionService) GetEdition(ctx context.Context, editionID string) (*models.EditionDetail, error) {
+	edition, err := s.repo.FindByID(ctx, editionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withEntries(ctx, edition)
+}
+
+// GetPublishedEdition returns an edition with its entries, a draft edition does not exist for the audience
+func (s *editionService) GetPublishedEdition(ctx context.Context, editionID string) (*models.EditionDetail, error) {
+	edition, err := s.repo.FindByID(ctx, editionID)
+	if err != nil {
+		return nil, err
+	}
+	if edition.Status == models.EditionStatusDraft {
+		return nil, sql.ErrNoRows
+	}
+
+	return s.withEntries(ctx, edition)
+}
+
+// CreateEdition creates a draft edition, movies are entered into it before it opens
+func (s *editionService) CreateEdition(ctx context.Context, req models.EditionRequest) (*models.Edition, error) {
+	existing, err := s.repo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrEditionExists
+	}
+
+	edition, err := toEdition(uuid.NewString(), req)
+	if err != nil {
+		return nil, err
+	}
+	edition.Status = models.EditionStatusDraft
+
+	if err := s.repo.Create(ctx, edition); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, edition.ID)
+}
+
+// UpdateEdition renames an edition and moves its dates
+func (s *editionService) UpdateEdition(ctx context.Context, editionID string, req models.EditionRequest) (*models.Edition, error) {
+	existing, err := s.repo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != editionID {
+		return nil, ErrEditionExists
+	}
+
+	edition, err := toEdition(editionID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, edition); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, editionID)
+}
+
+// OpenEdition opens a draft edition to the audience, or reopens a closed one
+func (s *editionService) OpenEdition(ctx context.Context, editionID string) error {
+	edition, err := s.repo.FindByID(ctx, editionID)
+	if err != nil {
+		return err
+	}
+	if edition.Status == models.EditionStatusOpen {
+		return ErrEditionAlreadyOpen
+	}
+
+	return s.repo.SetStatus(ctx, editionID, models.EditionStatusOpen)
+}
+
+// CloseEdition closes an open edition, its entries can no longer change
+func (s *editionService) CloseEdition(ctx context.Context, editionID string) error {
+	edition, err := s.repo.FindByID(ctx, editionID)
+	if err != nil {
+		return err
+	}
+	if edition.Status != models.EditionStatusOpen {
+		return ErrEditionNotOpen
+	}
+
+	return s.repo.SetStatus(ctx, editionID, models.EditionStatusClosed)
+}
+
+// EnterMovies enters movies into a draft or open edition
+func (s *editionService) EnterMovies(ctx context.Context, editionID string, movieIDs []string) error {
+	if err := s.checkEntriesOpen(ctx, editionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.EnterMovies(ctx, editionID, movieIDs); err != nil {
+		// The edition exists, so the missing row is one of the movies
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEditionMovieNotExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// WithdrawMovie removes a movie from a draft or open edition
+func (s *editionService) WithdrawMovie(ctx context.Context, editionID, movieID string) error {
+	if err := s.checkEntriesOpen(ctx, editionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.WithdrawMovie(ctx, editionID, movieID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMovieNotEntered
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkEntriesOpen makes sure the edition exists and is not closed
+func (s *editionService) checkEntriesOpen(ctx context.Context, editionID string) error {
+	edition, err := s.repo.FindByID(ctx, editionID)
+	if err != nil {
+		return err
+	}
+	if edition.Status == models.EditionStatusClosed {
+		return ErrEditionClosed
+	}
+
+	return nil
+}
+
+func (s *editionService) withEntries(ctx context.Context, edition *models.Edition) (*models.EditionDetail, error) {
+	entries, err := s.repo.ListEntries(ctx, edition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EditionDetail{Edition: *edition, Entries: entries}, nil
+}
+
+func toEdition(editionID string, req models.EditionRequest) (*models.Edition, error) {
+	startsOn, err := time.Parse("2006-01-02", req.StartsOn)
+	if err != nil {
+		return nil, ErrInvalidEditionDate
+	}
+	endsOn, err := time.Parse("2006-01-02", req.EndsOn)
+	if err != nil {
+		return nil, ErrInvalidEditionDate
+	}
+	if endsOn.Before(startsOn) {
+		return nil, ErrInvalidEditionDates
+	}
+
+	return &models.Edition{ID: editionID, Name: req.Name, StartsOn: startsOn, EndsOn: endsOn}, nil
+}
+
+func isValidEditionStatus(status string) bool {
+	switch status {
+	case models.EditionStatusDraft, models.EditionStatusOpen, models.EditionStatusClosed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// envInt reads a positive integer setting, falling back to def when it is unset or invalid
+func envInt(name string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
+
+// envDuration reads a positive duration setting, falling back to def when it is unset or invalid
+func envDuration(name string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrGenreExists      = errors.New("genre with this name already exists")
+	ErrGenreMergeSelf   = errors.New("a genre cannot be merged into itself")
+	ErrGenreParent      = errors.New("parent genre must be another top-level genre")
+	ErrGenreHasChildren = errors.New("a genre with subgenres cannot become a subgenre")
+)
+
+type GenreService interface {
+	ListGenres(ctx context.Context) ([]models.Genre, error)
+	CreateGenre(ctx context.Context, req models.GenreRequest) (*models.Genre, error)
+	UpdateGenre(ctx context.Context, genreID int64, req models.GenreRequest) (*models.Genre, error)
+	DeleteGenre(ctx context.Context, genreID int64) error
+	MergeGenres(ctx context.Context, targetID int64, sourceIDs []int64) error
+}
+
+type genreService struct {
+	repo repositories.GenreRepository
+}
+
+func NewGenreService(repo repositories.GenreRepository) GenreService {
+	return &genreService{repo: repo}
+}
+
+func (s *genreService) ListGenres(ctx context.Context) ([]models.Genre, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, req models.GenreRequest) (*models.Genre, error) {
+	existing, err := s.repo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrGenreExists
+	}
+
+	genre := &models.Genre{Name: req.Name, ParentID: req.ParentID}
+	if err := s.validateParent(ctx, genre); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, genre); err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+// UpdateGenre renames a genre and moves it under another genre or back to the top level
+func (s *genreService) UpdateGenre(ctx context.Context, genreID int64, req models.GenreRequest) (*models.Genre, error) {
+	existing, err := s.repo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != genreID {
+		return nil, ErrGenreExists
+	}
+
+	genre := &models.Genre{ID: genreID, Name: req.Name, ParentID: req.ParentID}
+	if err := s.validateParent(ctx, genre); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, genre); err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, genreID int64) error {
+	return s.repo.Delete(ctx, genreID)
+}
+
+// MergeGenres collapses duplicated genres into the target genre
+func (s *genreService) MergeGenres(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	target, err := s.repo.FindByID(ctx, targetID)
+	if err != nil {
+		return err
+	}
+
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return ErrGenreMergeSelf
+		}
+
+		// Subgenres of the sources move to the target, which keeps the hierarchy one level deep
+		// only when the target is a top-level genre or is itself one of the sources' children
+		if target.ParentID != nil && *target.ParentID != sourceID {
+			children, err := s.repo.CountChildren(ctx, sourceID)
+			if err != nil {
+				return err
+			}
+			if children > 0 {
+				return ErrGenreHasChildren
+			}
+		}
+	}
+
+	return s.repo.Merge(ctx, targetID, sourceIDs)
+}
+
+// validateParent keeps the genre hierarchy one level deep so analytics can roll subgenres up into their parent
+func (s *genreService) validateParent(ctx context.Context, genre *models.Genre) error {
+	if genre.ParentID == nil {
+		return nil
+	}
+
+	if *genre.ParentID == genre.ID {
+		return ErrGenreParent
+	}
+
+	parent, err := s.repo.FindByID(ctx, *genre.ParentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrGenreParent
+		}
+		return err
+	}
+	if parent.ParentID != nil {
+		return ErrGenreParent
+	}
+
+	// A new genre has no subgenres yet
+	if genre.ID == 0 {
+		return nil
+	}
+
+	children, err := s.repo.CountChildren(ctx, genre.ID)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrGenreHasChildren
+	}
+
+	return nil
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrJurorNotExists         = errors.New("user is not exists")
+	ErrNotJuryMember          = errors.New("user does not have a role with the jury:score permission")
+	ErrJurorNotAssigned       = errors.New("user is not a juror of this section")
+	ErrNotSectionJuror        = errors.New("you are not a juror of this section")
+	ErrCriterionExists        = errors.New("criterion with this name already exists in the section")
+	ErrCriterionNotExists     = errors.New("criterion is not exists")
+	ErrUnknownCriterion       = errors.New("criterion is not a criterion of this section")
+	ErrScoringClosed          = errors.New("scoring of this section is closed")
+	ErrScoringAlreadyOpen     = errors.New("scoring of this section is already open")
+	ErrInvalidJuryAggregation = errors.New("invalid aggregation, must be mean, trimmed_mean or median")
+)
+
+type JuryService interface {
+	ListJurors(ctx context.Context, sectionID string) ([]models.Juror, error)
+	AssignJuror(ctx context.Context, sectionID, userID string) error
+	RemoveJuror(ctx context.Context, sectionID, userID string) error
+	ListCriteria(ctx context.Context, sectionID string) ([]models.ScoringCriterion, error)
+	CreateCriterion(ctx context.Context, sectionID string, req models.ScoringCriterionRequest) (*models.ScoringCriterion, error)
+	DeleteCriterion(ctx context.Context, criterionID string) error
+	OpenScoring(ctx context.Context, sectionID string) error
+	CloseScoring(ctx context.Context, sectionID string) error
+	GetRanking(ctx context.Context, sectionID, aggregation string) (*models.SectionRanking, error)
+	ListJurorSections(ctx context.Context, userID string) ([]models.Section, error)
+	GetJurySheet(ctx context.Context, userID, sectionID string) (*models.JurySheet, error)
+	SubmitScores(ctx context.Context, userID, sectionID string, req models.JuryScoresRequest) error
+}
+
+type juryService struct {
+	repo            repositories.JuryRepository
+	competitionRepo repositories.CompetitionRepository
+	userRepo        repositories.UserRepository
+	roleService     RoleService
+}
+
+func NewJuryService(repo repositories.JuryRepository, competitionRepo repositories.CompetitionRepository, userRepo repositories.UserRepository, roleService RoleService) JuryService {
+	return &juryService{repo: repo, competitionRepo: competitionRepo, userRepo: userRepo, roleService: roleService}
+}
+
+func (s *juryService) ListJurors(ctx context.Context, sectionID string) ([]models.Juror, error) {
+	if _, err := s.findSection(ctx, sectionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListJurors(ctx, sectionID)
+}
+
+// AssignJuror assigns a user whose role grants jury:score to the jury of a section
+func (s *juryService) AssignJuror(ctx context.Context, sectionID, userID string) error {
+	if _, err := s.findSection(ctx, sectionID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrJurorNotExists
+	}
+
+	permissions, err := s.roleService.RolePermissions(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(permissions, models.PermissionJuryScore) {
+		return ErrNotJuryMember
+	}
+
+	return s.repo.AssignJuror(ctx, sectionID, userID)
+}
+
+// RemoveJuror removes a user from the jury of a section, the scores they gave in the section go with them
+func (s *juryService) RemoveJuror(ctx context.Context, sectionID, userID string) error {
+	if _, err := s.findSection(ctx, sectionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveJuror(ctx, sectionID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrJurorNotAssigned
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *juryService) ListCriteria(ctx context.Context, sectionID string) ([]models.ScoringCriterion, error) {
+	if _, err := s.findSection(ctx, sectionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListCriteria(ctx, sectionID)
+}
+
+// CreateCriterion adds a weighted criterion to a section whose scoring is open
+func (s *juryService) CreateCriterion(ctx context.Context, sectionID string, req models.ScoringCriterionRequest) (*models.ScoringCriterion, error) {
+	if _, err := s.findScoringSection(ctx, sectionID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindCriterionByName(ctx, sectionID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrCriterionExists
+	}
+
+	criterion := &models.ScoringCriterion{ID: uuid.NewString(), SectionID: sectionID, Name: req.Name, Weight: req.Weight}
+	if err := s.repo.CreateCriterion(ctx, criterion); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindCriterion(ctx, criterion.ID)
+}
+
+// DeleteCriterion deletes a criterion of a section whose scoring is open, with the scores given on it
+func (s *juryService) DeleteCriterion(ctx context.Context, criterionID string) error {
+	criterion, err := s.repo.FindCriterion(ctx, criterionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCriterionNotExists
+		}
+		return err
+	}
+	if _, err := s.findScoringSection(ctx, criterion.SectionID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteCriterion(ctx, criterionID)
+}
+
+// OpenScoring reopens the scoring of a section, the jurors no longer see each other's scores
+func (s *juryService) OpenScoring(ctx context.Context, sectionID string) error {
+	section, err := s.findSection(ctx, sectionID)
+	if err != nil {
+		return err
+	}
+	if section.ScoringClosedAt == nil {
+		return ErrScoringAlreadyOpen
+	}
+
+	return s.repo.SetScoringClosed(ctx, sectionID, false)
+}
+
+// CloseScoring closes the scoring of a section, the jurors can then see each other's scores
+func (s *juryService) CloseScoring(ctx context.Context, sectionID string) error {
+	if _, err := s.findScoringSection(ctx, sectionID); err != nil {
+		return err
+	}
+
+	return s.repo.SetScoringClosed(ctx, sectionID, true)
+}
+
+// GetRanking ranks the nominees of a section by their final score, the aggregation of their juror scores.
+// A juror score is the weighted mean of the scores a juror gave a movie, it only counts once the juror
+// scored the movie on every criterion. Movies without any such juror score are not ranked.
+func (s *juryService) GetRanking(ctx context.Context, sectionID, aggregation string) (*models.SectionRanking, error) {
+	if aggregation == "" {
+		aggregation = models.JuryAggregationMean
+	}
+	if !isValidJuryAggregation(aggregation) {
+		return nil, ErrInvalidJuryAggregation
+	}
+
+	section, err := s.findSection(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.repo.ListCriteria(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.repo.ListScores(ctx, sectionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SectionRanking{
+		SectionID:       sectionID,
+		Aggregation:     aggregation,
+		ScoringClosedAt: section.ScoringClosedAt,
+		Ranking:         rankMovies(criteria, scores, aggregation),
+	}, nil
+}
+
+func (s *juryService) ListJurorSections(ctx context.Context, userID string) ([]models.Section, error) {
+	return s.repo.ListJurorSections(ctx, userID)
+}
+
+// GetJurySheet returns a section with its nominees and criteria for one of its jurors.
+// Only the scores of the juror are returned until scoring closes, then every score is.
+func (s *juryService) GetJurySheet(ctx context.Context, userID, sectionID string) (*models.JurySheet, error) {
+	section, err := s.findJurorSection(ctx, userID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if section.Nominees, err = s.competitionRepo.ListNominees(ctx, sectionID); err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.repo.ListCriteria(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	scorer := userID
+	if section.ScoringClosedAt != nil {
+		scorer = ""
+	}
+	scores, err := s.repo.ListScores(ctx, sectionID, scorer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.JurySheet{Section: *section, Criteria: criteria, Scores: scores}, nil
+}
+
+// SubmitScores records the scores a juror gives a nominee of the section while scoring is open
+func (s *juryService) SubmitScores(ctx context.Context, userID, sectionID string, req models.JuryScoresRequest) error {
+	section, err := s.findJurorSection(ctx, userID, sectionID)
+	if err != nil {
+		return err
+	}
+	if section.ScoringClosedAt != nil {
+		return ErrScoringClosed
+	}
+
+	nominees, err := s.competitionRepo.ListNominees(ctx, sectionID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(nominees, func(nominee models.Nominee) bool { return nominee.MovieID == req.MovieID }) {
+		return ErrMovieNotNominated
+	}
+
+	criteria, err := s.repo.ListCriteria(ctx, sectionID)
+	if err != nil {
+		return err
+	}
+	for _, score := range req.Scores {
+		if !slices.ContainsFunc(criteria, func(criterion models.ScoringCriterion) bool { return criterion.ID == score.CriterionID }) {
+			return ErrUnknownCriterion
+		}
+	}
+
+	return s.repo.SaveScores(ctx, userID, req.MovieID, req.Scores)
+}
+
+func (s *juryService) findSection(ctx context.Context, sectionID string) (*models.Section, error) {
+	section, err := s.competitionRepo.FindSection(ctx, sectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSectionNotExists
+	}
+
+	return section, err
+}
+
+// findScoringSection returns a section whose scoring is still open
+func (s *juryService) findScoringSection(ctx context.Context, sectionID string) (*models.Section, error) {
+	section, err := s.findSection(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section.ScoringClosedAt != nil {
+		return nil, ErrScoringClosed
+	}
+
+	return section, nil
+}
+
+// findJurorSection returns a section the user is a juror of
+func (s *juryService) findJurorSection(ctx context.Context, userID, sectionID string) (*models.Section, error) {
+	section, err := s.findSection(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	isJuror, err := s.repo.IsJuror(ctx, sectionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isJuror {
+		return nil, ErrNotSectionJuror
+	}
+
+	return section, nil
+}
+
+// rankMovies aggregates the complete score sheets of every movie and ranks the movies by their final score.
+// Movies with the same final score share a rank.
+func rankMovies(criteria []models.ScoringCriterion, scores []models.JuryScore, aggregation string) []models.RankedMovie {
+	weights := make(map[string]float64, len(criteria))
+	var totalWeight float64
+	for _, criterion := range criteria {
+		weights[criterion.ID] = criterion.Weight
+		totalWeight += criterion.Weight
+	}
+
+	type sheet struct {
+		weighted float64
+		scored   int
+	}
+	titles := make(map[string]string)
+	sheets := make(map[string]map[string]*sheet) // movie, then juror
+	for _, score := range scores {
+		weight, ok := weights[score.CriterionID]
+		if !ok {
+			continue
+		}
+		titles[score.MovieID] = score.Title
+		if sheets[score.MovieID] == nil {
+			sheets[score.MovieID] = make(map[string]*sheet)
+		}
+		if sheets[score.MovieID][score.UserID] == nil {
+			sheets[score.MovieID][score.UserID] = &sheet{}
+		}
+		sheets[score.MovieID][score.UserID].weighted += score.Score * weight
+		sheets[score.MovieID][score.UserID].scored++
+	}
+
+	ranking := []models.RankedMovie{}
+	for movieID, jurors := range sheets {
+		var jurorScores []float64
+		for _, sheet := range jurors {
+			if sheet.scored == len(criteria) {
+				jurorScores = append(jurorScores, sheet.weighted/totalWeight)
+			}
+		}
+		if len(jurorScores) == 0 {
+			continue
+		}
+
+		ranking = append(ranking, models.RankedMovie{
+			MovieID: movieID,
+			Title:   titles[movieID],
+			Score:   math.Round(aggregateScores(jurorScores, aggregation)*100) / 100,
+			Jurors:  len(jurorScores),
+		})
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Score != ranking[j].Score {
+			return ranking[i].Score > ranking[j].Score
+		}
+		if ranking[i].Title != ranking[j].Title {
+			return ranking[i].Title < ranking[j].Title
+		}
+		return ranking[i].MovieID < ranking[j].MovieID
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+		if i > 0 && ranking[i].Score == ranking[i-1].Score {
+			ranking[i].Rank = ranking[i-1].Rank
+		}
+	}
+
+	return ranking
+}
+
+// aggregateScores aggregates the juror scores of a movie, the trimmed mean drops the highest
+// and the lowest score when there are at least 3 of them
+func aggregateScores(scores []float64, aggregation string) float64 {
+	sorted := slices.Clone(scores)
+	slices.Sort(sorted)
+
+	switch aggregation {
+	case models.JuryAggregationMedian:
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2
+		}
+		return sorted[middle]
+	case models.JuryAggregationTrimmedMean:
+		if len(sorted) >= 3 {
+			sorted = sorted[1 : len(sorted)-1]
+		}
+	}
+
+	var sum float64
+	for _, score := range sorted {
+		sum += score
+	}
+	return sum / float64(len(sorted))
+}
+
+func isValidJuryAggregation(aggregation string) bool {
+	switch aggregation {
+	case models.JuryAggregationMean, models.JuryAggregationTrimmedMean, models.JuryAggregationMedian:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+const (
+	defaultLoginMaxAttempts      = 5
+	defaultLoginMaxAttemptsPerIP = 20
+	defaultLoginFailureWindow    = 15 * time.Minute
+	defaultLoginBackoffBase      = time.Second
+	defaultLoginLockoutDuration  = 15 * time.Minute
+)
+
+var (
+	ErrAccountLocked   = errors.New("too many failed logins, the account is temporarily locked")
+	ErrTooManyAttempts = errors.New("too many failed logins, please try again later")
+)
+
+// LoginBlockedError tells how long the client has to wait before the next login attempt
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+type LockoutService interface {
+	CheckLogin(ctx context.Context, username, ipAddress string) error
+	RecordFailedLogin(ctx context.Context, username, ipAddress string) error
+	RecordSuccessfulLogin(ctx context.Context, username string) error
+	Unlock(ctx context.Context, adminID string, req models.UnlockLoginRequest) error
+	ListEvents(ctx context.Context, scope, subject string, limit, offset int) ([]models.LockoutEvent, error)
+}
+
+type lockoutService struct {
+	repo  repositories.LockoutRepository
+	redis redis.Cmdable
+}
+
+func NewLockoutService(repo repositories.LockoutRepository, redisClient redis.Cmdable) LockoutService {
+	return &lockoutService{repo: repo, redis: redisClient}
+}
+
+// CheckLogin returns a *LoginBlockedError while the username or the client address is locked,
+// or while the username waits out the backoff of its last failed login
+func (s *lockoutService) CheckLogin(ctx context.Context, username, ipAddress string) error {
+	username = strings.ToLower(username)
+	checks := []struct {
+		key string
+		err error
+	}{
+		{lockoutKey("lock", models.LockoutScopeUser, username), ErrAccountLocked},
+		{lockoutKey("lock", models.LockoutScopeIP, ipAddress), ErrTooManyAttempts},
+		{lockoutKey("backoff", models.LockoutScopeUser, username), ErrTooManyAttempts},
+	}
+
+	for _, check := range checks {
+		ttl, err := s.redis.PTTL(ctx, check.key).Result()
+		if err != nil {
+			// Do not block logins while Redis is unavailable
+			log.Printf("Error checking login lockout: %v", err)
+			return nil
+		}
+		if ttl > 0 {
+			return &LoginBlockedError{Err: check.err, RetryAfter: ttl}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailedLogin counts a failed login for the username and the client address.
+// Every failure doubles the wait before the next attempt for the username, and reaching
+// LOGIN_MAX_ATTEMPTS or LOGIN_MAX_ATTEMPTS_PER_IP within LOGIN_FAILURE_WINDOW locks it for LOGIN_LOCKOUT_DURATION.
+// It returns a *LoginBlockedError when this failure locked the username or the client address.
+func (s *lockoutService) RecordFailedLogin(ctx context.Context, username, ipAddress string) error {
+	username = strings.ToLower(username)
+	window := envDuration("LOGIN_FAILURE_WINDOW", defaultLoginFailureWindow)
+	lockout := envDuration("LOGIN_LOCKOUT_DURATION", defaultLoginLockoutDuration)
+
+	var blocked error
+
+	failures, err := s.countFailure(ctx, lockoutKey("failed", models.LockoutScopeUser, username), window)
+	if err != nil {
+		return err
+	}
+	if failures >= envInt("LOGIN_MAX_ATTEMPTS", defaultLoginMaxAttempts) {
+		if err := s.lock(ctx, models.LockoutScopeUser, username, ipAddress, failures, lockout); err != nil {
+			return err
+		}
+		blocked = &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: lockout}
+	} else {
+		backoff := loginBackoff(failures, lockout)
+		if err := s.redis.Set(ctx, lockoutKey("backoff", models.LockoutScopeUser, username), failures, backoff).Err(); err != nil {
+			return err
+		}
+	}
+
+	if ipAddress == "" {
+		return blocked
+	}
+
+	failures, err = s.countFailure(ctx, lockoutKey("failed", models.LockoutScopeIP, ipAddress), window)
+	if err != nil {
+		return err
+	}
+	if failures >= envInt("LOGIN_MAX_ATTEMPTS_PER_IP", defaultLoginMaxAttemptsPerIP) {
+		if err := s.lock(ctx, models.LockoutScopeIP, ipAddress, ipAddress, failures, lockout); err != nil {
+			return err
+		}
+		if blocked == nil {
+			blocked = &LoginBlockedError{Err: ErrTooManyAttempts, RetryAfter: lockout}
+		}
+	}
+
+	return blocked
+}
+
+// RecordSuccessfulLogin clears the failed logins of the username.
+// The failures of the client address are kept, so logging in to an own account does not reset them.
+func (s *lockoutService) RecordSuccessfulLogin(ctx context.Context, username string) error {
+	username = strings.ToLower(username)
+	return s.redis.Del(ctx,
+		lockoutKey("failed", models.LockoutScopeUser, username),
+		lockoutKey("backoff", models.LockoutScopeUser, username),
+	).Err()
+}
+
+// Unlock lifts the lockout and clears the failed logins of a username and/or a client address
+func (s *lockoutService) Unlock(ctx context.Context, adminID string, req models.UnlockLoginRequest) error {
+	subjects := map[string]string{}
+	if req.Username != "" {
+		subjects[models.LockoutScopeUser] = strings.ToLower(req.Username)
+	}
+	if req.IPAddress != "" {
+		subjects[models.LockoutScopeIP] = req.IPAddress
+	}
+
+	for _, scope := range []string{models.LockoutScopeUser, models.LockoutScopeIP} {
+		subject, ok := subjects[scope]
+		if !ok {
+			continue
+		}
+
+		err := s.redis.Del(ctx,
+			lockoutKey("lock", scope, subject),
+			lockoutKey("failed", scope, subject),
+			lockoutKey("backoff", scope, subject),
+		).Err()
+		if err != nil {
+			return err
+		}
+
+		event := &models.LockoutEvent{
+			Scope:   scope,
+			Subject: subject,
+			Action:  models.LockoutActionUnlock,
+			AdminID: adminID,
+		}
+		if err := s.repo.RecordEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *lockoutService) ListEvents(ctx context.Context, scope, subject string, limit, offset int) ([]models.LockoutEvent, error) {
+	if scope == models.LockoutScopeUser {
+		subject = strings.ToLower(subject)
+	}
+	return s.repo.ListEvents(ctx, scope, subject, limit, offset)
+}
+
+// countFailure increments a failed login counter that starts its window at the first failure
+func (s *lockoutService) countFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	count, err := s.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := s.redis.Expire(ctx, key, window).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(count), nil
+}
+
+// lock locks the subject for the lockout duration, starts its failed logins over and records the lockout event
+func (s *lockoutService) lock(ctx context.Context, scope, subject, ipAddress string, failures int, lockout time.Duration) error {
+	if err := s.redis.Set(ctx, lockoutKey("lock", scope, subject), failures, lockout).Err(); err != nil {
+		return err
+	}
+
+	keys := []string{lockoutKey("failed", scope, subject)}
+	if scope == models.LockoutScopeUser {
+		keys = append(keys, lockoutKey("backoff", scope, subject))
+	}
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+		return err
+	}
+
+	lockedUntil := time.Now().Add(lockout)
+	event := &models.LockoutEvent{
+		Scope:          scope,
+		Subject:        subject,
+		Action:         models.LockoutActionLock,
+		IPAddress:      ipAddress,
+		FailedAttempts: failures,
+		LockedUntil:    &lockedUntil,
+	}
+	if err := s.repo.RecordEvent(ctx, event); err != nil {
+		// The lockout holds without its event
+		log.Printf("Error recording lockout of %s %s: %v", scope, subject, err)
+	}
+
+	return nil
+}
+
+// loginBackoff is the wait after the given number of consecutive failures: LOGIN_BACKOFF_BASE doubled for every failure after the first
+func loginBackoff(failures int, max time.Duration) time.Duration {
+	backoff := envDuration("LOGIN_BACKOFF_BASE", defaultLoginBackoffBase)
+	for i := 1; i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+
+	return backoff
+}
+
+func lockoutKey(kind, scope, subject string) string {
+	return fmt.Sprintf("login:%s:%s:%s", kind, scope, subject)
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrInvalidMovieList      = errors.New("invalid list, must be watchlist or favorites")
+	ErrMovieAlreadyInList    = errors.New("movie is already in the list")
+	ErrInvalidMovieListOrder = errors.New("movie_ids must contain every movie of the list exactly once")
+)
+
+type MovieListService interface {
+	GetList(ctx context.Context, userID, list string) ([]models.MovieListItem, error)
+	AddMovie(ctx context.Context, userID, list, movieID string) error
+	RemoveMovie(ctx context.Context, userID, list, movieID string) error
+	Reorder(ctx context.Context, userID, list string, movieIDs []string) error
+	MarkWatched(ctx context.Context, userID, list, movieID string, watched bool) error
+}
+
+type movieListService struct {
+	repo      repositories.MovieListRepository
+	movieRepo repositories.MovieRepository
+}
+
+func NewMovieListService(repo repositories.MovieListRepository, movieRepo repositories.MovieRepository) MovieListService {
+	return &movieListService{repo: repo, movieRepo: movieRepo}
+}
+
+// GetList returns the movies of a list with their genres and artists, in the order chosen by the user.
+// Movies deleted by an admin are left out.
+func (s *movieListService) GetList(ctx context.Context, userID, list string) ([]models.MovieListItem, error) {
+	if !models.IsValidMovieList(list) {
+		return nil, ErrInvalidMovieList
+	}
+
+	items, err := s.repo.ListItems(ctx, userID, list)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	return s.hydrateItems(ctx, items)
+}
+
+// hydrateItems replaces the movie of each item with the full movie and numbers the items from 1.
+// Items whose movie was deleted by an admin are dropped.
+func (s *movieListService) hydrateItems(ctx context.Context, items []models.MovieListItem) ([]models.MovieListItem, error) {
+	movieIDs := make([]string, len(items))
+	for i, item := range items {
+		movieIDs[i] = item.Movie.ID
+	}
+
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, movieIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	moviesByID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		moviesByID[movie.ID] = movie
+	}
+
+	result := make([]models.MovieListItem, 0, len(items))
+	for _, item := range items {
+		movie, ok := moviesByID[item.Movie.ID]
+		if !ok {
+			continue
+		}
+		item.Movie = movie
+		item.Position = len(result) + 1
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+func (s *movieListService) AddMovie(ctx context.Context, userID, list, movieID string) error {
+	if !models.IsValidMovieList(list) {
+		return ErrInvalidMovieList
+	}
+
+	if _, err := s.movieRepo.FindMovieByID(ctx, movieID); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindItem(ctx, userID, list, movieID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrMovieAlreadyInList
+	}
+
+	return s.repo.AddItem(ctx, userID, list, movieID)
+}
+
+func (s *movieListService) RemoveMovie(ctx context.Context, userID, list, movieID string) error {
+	if !models.IsValidMovieList(list) {
+		return ErrInvalidMovieList
+	}
+
+	return s.repo.RemoveItem(ctx, userID, list, movieID)
+}
+
+// Reorder moves the movies of a list to the given order, which must list every movie of the list once.
+// Movies deleted by an admin are not shown by GetList, so they are not expected in the order and are moved after the others.
+func (s *movieListService) Reorder(ctx context.Context, userID, list string, movieIDs []string) error {
+	if !models.IsValidMovieList(list) {
+		return ErrInvalidMovieList
+	}
+
+	items, err := s.repo.ListItems(ctx, userID, list)
+	if err != nil {
+		return err
+	}
+
+	visible := items
+	if len(items) > 0 {
+		visible, err = s.hydrateItems(ctx, items)
+		if err != nil {
+			return err
+		}
+	}
+	if len(visible) != len(movieIDs) {
+		return ErrInvalidMovieListOrder
+	}
+
+	remaining := make(map[string]bool, len(visible))
+	for _, item := range visible {
+		remaining[item.Movie.ID] = true
+	}
+	for _, movieID := range movieIDs {
+		if !remaining[movieID] {
+			return ErrInvalidMovieListOrder
+		}
+		delete(remaining, movieID)
+	}
+
+	order := append([]string{}, movieIDs...)
+	if len(items) > len(visible) {
+		shown := make(map[string]bool, len(movieIDs))
+		for _, movieID := range movieIDs {
+			shown[movieID] = true
+		}
+		for _, item := range items {
+			if !shown[item.Movie.ID] {
+				order = append(order, item.Movie.ID)
+			}
+		}
+	}
+
+	return s.repo.Reorder(ctx, userID, list, order)
+}
+
+func (s *movieListService) MarkWatched(ctx context.Context, userID, list, movieID string, watched bool) error {
+	if !models.IsValidMovieList(list) {
+		return ErrInvalidMovieList
+	}
+
+	return s.repo.SetWatched(ctx, userID, list, movieID, watched)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,18 +20,31 @@ import (
 type MovieService interface {
 	CreateMovie(ctx context.Context, movie *models.Movie) error
 	UpdateMovie(ctx context.Context, movie *models.Movie) error
-	GetMostViewedMovie(ctx context.Context) (*models.Movie, error)
-	GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string) ([]models.GenreView, error)
+	DeleteMovie(ctx context.Context, movieID string) error
+	RestoreMovie(ctx context.Context, movieID string) error
+	PurgeMovie(ctx context.Context, movieID string) error
+	GetMostViewedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error)
+	GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string, filter models.StatsFilter) ([]models.GenreView, error)
 	GetAllMovies(ctx context.Context, limit, offset int) ([]models.Movie, error)
 	GetAllMoviesFromCache(ctx context.Context, limit, offset int) ([]models.Movie, error)
-	SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.Movie, error)
-	TrackMovieView(ctx context.Context, movieID string) error
-	VoteMovie(ctx context.Context, userID, movieID string) error
-	UnvoteMovie(ctx context.Context, userID, movieID string) error
+	SearchMovies(ctx context.Context, filter models.MovieSearchFilter, limit, offset int) ([]models.Movie, error)
+	GetMovieDetail(ctx context.Context, movieID string) (*models.Movie, error)
+	TrackMovieView(ctx context.Context, view models.ViewEvent) error
+	RollupViewStats(ctx context.Context, since time.Time) error
+	FlushViewCounts(ctx context.Context) error
+	GetMovieViewStats(ctx context.Context, movieID, granularity string, filter models.StatsFilter) (*models.MovieViewStats, error)
+	GetSuspectedViewInflation(ctx context.Context, filter models.StatsFilter, minViews int) ([]models.SuspectedViewInflation, error)
 	GetUserVotedMovies(ctx context.Context, userID string) ([]models.Movie, error)
-	GetMostVotedMovie(ctx context.Context) (*models.Movie, error)
+	GetMostVotedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error)
 }
 
+var (
+	ErrInvalidArtistRole  = errors.New("invalid artist role, must be one of director, actor, writer or composer")
+	ErrInvalidGranularity = errors.New("invalid granularity, must be hour or day")
+	ErrInvalidTimeWindow  = errors.New("invalid time window, from must be before to")
+	ErrViewAlreadyCounted = errors.New("view already counted")
+)
+
 type movieService struct {
 	repo  repositories.MovieRepository
 	redis redis.Cmdable
@@ -48,6 +62,10 @@ func (s *movieService) CreateMovie(ctx context.Context, movie *models.Movie) err
 		return errors.New(errMessage)
 	}
 
+	if err := normalizeCredits(movie.Artists); err != nil {
+		return err
+	}
+
 	for i := range movie.Artists {
 		movie.Artists[i].ID = uuid.NewString()
 	}
@@ -60,21 +78,61 @@ func (s *movieService) UpdateMovie(ctx context.Context, movie *models.Movie) err
 		return err
 	}
 
+	if err := normalizeCredits(movie.Artists); err != nil {
+		return err
+	}
+
 	return s.repo.Update(ctx, movie)
 }
 
-func (s *movieService) GetMostViewedMovie(ctx context.Context) (*models.Movie, error) {
-	return s.repo.GetMostViewedMovie(ctx)
+// normalizeCredits defaults uncredited artists to the actor role and rejects unknown roles
+func normalizeCredits(artists []models.Artist) error {
+	for i := range artists {
+		if artists[i].Role == "" {
+			artists[i].Role = models.ArtistRoleActor
+		}
+		if !models.IsValidArtistRole(artists[i].Role) {
+			return ErrInvalidArtistRole
+		}
+	}
+	return nil
+}
+
+// DeleteMovie soft-deletes a movie so it no longer shows up in listings and statistics
+func (s *movieService) DeleteMovie(ctx context.Context, movieID string) error {
+	return s.repo.SoftDelete(ctx, movieID)
+}
+
+// RestoreMovie brings back a soft-deleted movie
+func (s *movieService) RestoreMovie(ctx context.Context, movieID string) error {
+	return s.repo.Restore(ctx, movieID)
+}
+
+// PurgeMovie permanently removes a soft-deleted movie and everything linked to it
+func (s *movieService) PurgeMovie(ctx context.Context, movieID string) error {
+	return s.repo.Purge(ctx, movieID)
+}
+
+func (s *movieService) GetMostViewedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error) {
+	if err := validateStatsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMostViewedMovie(ctx, filter)
 }
 
-func (s *movieService) GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string) ([]models.GenreView, error) {
+func (s *movieService) GetMostViewedGenre(ctx context.Context, page int, pageSize int, sortOrder string, filter models.StatsFilter) ([]models.GenreView, error) {
+	if err := validateStatsFilter(filter); err != nil {
+		return nil, err
+	}
+
 	// Validate sortOrder, default to "DESC" if invalid
 	if sortOrder != "ASC" && sortOrder != "DESC" {
 		sortOrder = "DESC"
 	}
 
 	// Call repository to get most viewed genres
-	genreViews, err := s.repo.GetMostViewedGenre(ctx, page, pageSize, sortOrder)
+	genreViews, err := s.repo.GetMostViewedGenre(ctx, page, pageSize, sortOrder, filter)
 	if err != nil {
 		log.Printf("Error fetching most viewed genres: %v", err)
 		return nil, err
@@ -123,43 +181,203 @@ func (s *movieService) GetAllMoviesFromCache(ctx context.Context, limit, offset
 	return movies, nil
 }
 
-func (s *movieService) SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.Movie, error) {
-	return s.repo.SearchMovies(ctx, query, limit, offset)
-}
+func (s *movieService) SearchMovies(ctx context.Context, filter models.MovieSearchFilter, limit, offset int) ([]models.Movie, error) {
+	if filter.Role != "" && !models.IsValidArtistRole(filter.Role) {
+		return nil, ErrInvalidArtistRole
+	}
 
-func (s *movieService) TrackMovieView(ctx context.Context, movieID string) error {
-	return s.repo.TrackMovieView(ctx, movieID)
+	return s.repo.SearchMovies(ctx, filter, limit, offset)
 }
 
-func (s *movieService) VoteMovie(ctx context.Context, userID, movieID string) error {
-	// Check if the user has already voted for this movie
-	existingVote, err := s.repo.GetVoteByUserAndMovie(ctx, userID, movieID)
+// GetMovieDetail fetches a movie with all of its genres, artists, views and votes.
+// The views include the ones still buffered in Redis, so the count does not wait for FlushViewCounts.
+func (s *movieService) GetMovieDetail(ctx context.Context, movieID string) (*models.Movie, error) {
+	movie, err := s.repo.GetMovieDetail(ctx, movieID)
 	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.redis.HGet(ctx, pendingViewCountsKey, movieID).Int64()
+	if err != nil && err != redis.Nil {
+		// The flushed views are still worth showing
+		log.Printf("Error reading buffered views of movie %s: %v", movieID, err)
+	}
+	movie.Views += int(pending)
+
+	return movie, nil
+}
+
+const defaultViewDedupWindow = 30 * time.Minute
+
+// TrackMovieView records a view of an existing movie.
+// Repeated views of the same viewer inside VIEW_DEDUP_WINDOW are counted once and return ErrViewAlreadyCounted.
+func (s *movieService) TrackMovieView(ctx context.Context, view models.ViewEvent) error {
+	if _, err := s.repo.FindMovieByID(ctx, view.MovieID); err != nil {
 		return err
 	}
-	if existingVote.ID == "" {
-		return errors.New("you have already voted for this movie")
+
+	if view.ViewedAt.IsZero() {
+		view.ViewedAt = time.Now()
+	}
+
+	dedupKey := viewDedupKey(view)
+	if dedupKey != "" {
+		if window := viewDedupWindow(); window > 0 {
+			first, err := s.redis.SetNX(ctx, dedupKey, 1, window).Result()
+			if err != nil {
+				// Rather count a duplicate than lose the view while Redis is unavailable
+				log.Printf("Error checking duplicated view: %v", err)
+			} else if !first {
+				return ErrViewAlreadyCounted
+			}
+		}
 	}
 
-	// Insert vote into the repository
-	if err := s.repo.CreateVote(ctx, userID, movieID); err != nil {
+	if err := s.repo.TrackMovieView(ctx, view); err != nil {
+		if dedupKey != "" {
+			// Let the viewer retry
+			s.redis.Del(ctx, dedupKey)
+		}
 		return err
 	}
+
+	// Buffer the counter in Redis, FlushViewCounts writes it to MySQL in batches
+	if err := s.redis.HIncrBy(ctx, pendingViewCountsKey, view.MovieID, 1).Err(); err != nil {
+		log.Printf("Error buffering view count, writing it directly: %v", err)
+		return s.repo.AddViewCounts(ctx, uuid.NewString(), map[string]int64{view.MovieID: 1})
+	}
+
 	return nil
 }
 
-func (s *movieService) UnvoteMovie(ctx context.Context, userID, movieID string) error {
-	// Check if the vote exists
-	existingVote, err := s.repo.GetVoteByUserAndMovie(ctx, userID, movieID)
+const (
+	pendingViewCountsKey  = "movie_views:pending"
+	flushingViewCountsKey = "movie_views:flushing"
+	viewCountsBatchField  = "_batch"
+)
+
+// FlushViewCounts writes the view counts buffered in Redis to MySQL.
+// The buffer is moved aside before it is written and removed only after the write succeeded, so a batch left behind
+// by a crash or a failed write is picked up again by the next flush. Each batch carries an ID that MySQL records,
+// which keeps a replayed batch from being counted twice.
+func (s *movieService) FlushViewCounts(ctx context.Context) error {
+	// RENAMENX fails when there is nothing buffered, so check for the buffer first
+	pending, err := s.redis.Exists(ctx, pendingViewCountsKey).Result()
 	if err != nil {
+		return fmt.Errorf("failed to check buffered view counts: %w", err)
+	}
+	if pending > 0 {
+		// RENAMENX keeps a batch left behind by a previous flush, which is then flushed first
+		if err := s.redis.RenameNX(ctx, pendingViewCountsKey, flushingViewCountsKey).Err(); err != nil {
+			return fmt.Errorf("failed to move view counts aside: %w", err)
+		}
+	}
+
+	values, err := s.redis.HGetAll(ctx, flushingViewCountsKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read view counts: %w", err)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	batchID, ok := values[viewCountsBatchField]
+	if !ok {
+		if _, err := s.redis.HSetNX(ctx, flushingViewCountsKey, viewCountsBatchField, uuid.NewString()).Result(); err != nil {
+			return fmt.Errorf("failed to assign view counts batch: %w", err)
+		}
+		// Another instance may have assigned the batch first
+		if batchID, err = s.redis.HGet(ctx, flushingViewCountsKey, viewCountsBatchField).Result(); err != nil {
+			return fmt.Errorf("failed to assign view counts batch: %w", err)
+		}
+	}
+
+	counts := make(map[string]int64, len(values))
+	for movieID, value := range values {
+		if movieID == viewCountsBatchField {
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Printf("Skipping invalid buffered view count of movie %s: %q", movieID, value)
+			continue
+		}
+		counts[movieID] = count
+	}
+
+	if err := s.repo.AddViewCounts(ctx, batchID, counts); err != nil {
 		return err
 	}
-	if existingVote.ID == "" {
-		return errors.New("you haven't voted for this movie yet")
+
+	return s.redis.Del(ctx, flushingViewCountsKey).Err()
+}
+
+// viewDedupKey identifies the viewer of a movie by user, then client address, then session.
+// The session ID is chosen by the client, so anonymous viewers rotating it must not count as new viewers.
+func viewDedupKey(view models.ViewEvent) string {
+	var viewer string
+	switch {
+	case view.UserID != "":
+		viewer = "u:" + view.UserID
+	case view.IPAddress != "":
+		viewer = "ip:" + view.IPAddress
+	case view.SessionID != "":
+		viewer = "s:" + view.SessionID
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("view:dedup:%s:%s", view.MovieID, viewer)
+}
+
+// viewDedupWindow reads VIEW_DEDUP_WINDOW, a zero duration turns deduplication off
+func viewDedupWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("VIEW_DEDUP_WINDOW"))
+	if err != nil || window < 0 {
+		return defaultViewDedupWindow
+	}
+	return window
+}
+
+// RollupViewStats aggregates the view events since the given time into hourly and daily statistics
+func (s *movieService) RollupViewStats(ctx context.Context, since time.Time) error {
+	return s.repo.RollupViewStats(ctx, since)
+}
+
+// GetMovieViewStats returns the hourly or daily view time series of a movie
+func (s *movieService) GetMovieViewStats(ctx context.Context, movieID, granularity string, filter models.StatsFilter) (*models.MovieViewStats, error) {
+	if granularity == "" {
+		granularity = models.GranularityDay
+	}
+	if granularity != models.GranularityHour && granularity != models.GranularityDay {
+		return nil, ErrInvalidGranularity
+	}
+	if err := validateStatsFilter(filter); err != nil {
+		return nil, err
 	}
 
-	// Remove the vote
-	return s.repo.DeleteVote(ctx, existingVote.ID)
+	return s.repo.GetMovieViewStats(ctx, movieID, granularity, filter)
+}
+
+// GetSuspectedViewInflation reports the client addresses with at least minViews views of one movie, over the last day by default
+func (s *movieService) GetSuspectedViewInflation(ctx context.Context, filter models.StatsFilter, minViews int) ([]models.SuspectedViewInflation, error) {
+	if err := validateStatsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if !filter.HasWindow() {
+		from := time.Now().Add(-24 * time.Hour)
+		filter.From = &from
+	}
+
+	return s.repo.GetSuspectedViewInflation(ctx, filter, minViews)
+}
+
+func validateStatsFilter(filter models.StatsFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return ErrInvalidTimeWindow
+	}
+	return nil
 }
 
 // GetUserVotedMovies retrieves the list of movies the user has voted for.
@@ -184,6 +402,12 @@ func (s *movieService) GetUserVotedMovies(ctx context.Context, userID string) ([
 	return votedMovies, nil
 }
 
-func (s *movieService) GetMostVotedMovie(ctx context.Context) (*models.Movie, error) {
-	return s.repo.GetMostVotedMovie(ctx)
+// GetMostVotedMovie returns the movie with the most votes cast inside the time window, among the entries of an edition
+// and counting the votes of its voting periods when one is given
+func (s *movieService) GetMostVotedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error) {
+	if err := validateStatsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMostVotedMovie(ctx, filter)
 }
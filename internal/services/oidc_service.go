@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/oidc"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+const (
+	defaultOIDCStateTTL = 10 * time.Minute
+	// usernameAttempts is how many random suffixes are tried when the username of a provisioned user is taken
+	usernameAttempts = 5
+)
+
+var (
+	ErrUnknownProvider       = errors.New("unknown OIDC provider")
+	ErrInvalidOIDCState      = errors.New("invalid or expired OIDC state, please log in again")
+	ErrOIDCLoginFailed       = errors.New("login with the OIDC provider failed")
+	ErrIdentityLinked        = errors.New("this provider account is linked to another user")
+	ErrProviderAlreadyLinked = errors.New("another account of this provider is already linked")
+	ErrOIDCEmailExists       = errors.New("a user with the email of this provider account exists, log in and link the provider to it")
+	ErrUsernameUnavailable   = errors.New("no username available for this provider account")
+)
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+type OIDCService interface {
+	Providers() []string
+	Authorize(ctx context.Context, provider, linkUserID string) (*models.OIDCAuthorization, error)
+	Callback(ctx context.Context, provider string, req models.OIDCCallbackRequest, browserState string, device models.SessionDevice) (*models.LoginResponse, error)
+	ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error)
+}
+
+type oidcService struct {
+	repo      repositories.IdentityRepository
+	userRepo  repositories.UserRepository
+	users     UserService
+	providers map[string]oidc.Provider
+	redis     redis.Cmdable
+}
+
+func NewOIDCService(repo repositories.IdentityRepository, userRepo repositories.UserRepository, users UserService, providers map[string]oidc.Provider, redisClient redis.Cmdable) OIDCService {
+	return &oidcService{repo: repo, userRepo: userRepo, users: users, providers: providers, redis: redisClient}
+}
+
+// Providers lists the names of the configured providers
+func (s *oidcService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Authorize starts the authorization code flow with PKCE. The state, the nonce and the code verifier
+// are kept until the callback; with a linkUserID the callback links the provider account to that user.
+func (s *oidcService) Authorize(ctx context.Context, provider, linkUserID string) (*models.OIDCAuthorization, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	state, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := p.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(models.OIDCState{Provider: provider, Nonce: nonce, CodeVerifier: codeVerifier, LinkUserID: linkUserID})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redis.Set(ctx, oidcStateKey(state), data, envDuration("OIDC_STATE_TTL", defaultOIDCStateTTL)).Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.OIDCAuthorization{AuthorizationURL: authorizationURL, State: state}, nil
+}
+
+// Callback completes the authorization code flow. The provider account logs in the user it is linked to,
+// is linked to the user who started the flow, or provisions a new user on its first login.
+// The browserState is the state kept by the browser that started the flow, so a callback sent to
+// another browser is rejected.
+func (s *oidcService) Callback(ctx context.Context, provider string, req models.OIDCCallbackRequest, browserState string, device models.SessionDevice) (*models.LoginResponse, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	if browserState == "" || subtle.ConstantTimeCompare([]byte(browserState), []byte(req.State)) != 1 {
+		return nil, ErrInvalidOIDCState
+	}
+
+	state, err := s.takeState(ctx, req.State)
+	if err != nil {
+		return nil, err
+	}
+	if state.Provider != provider {
+		return nil, ErrInvalidOIDCState
+	}
+
+	identity, err := p.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrTokenExchange) || errors.Is(err, oidc.ErrInvalidIDToken) {
+			return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+		}
+		return nil, err
+	}
+
+	user, err := s.resolveUser(ctx, identity, state.LinkUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.users.ExternalLogin(ctx, user, device)
+}
+
+func (s *oidcService) ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error) {
+	return s.repo.ListIdentities(ctx, userID)
+}
+
+// takeState returns the state of an authorization request, a state completes one callback
+func (s *oidcService) takeState(ctx context.Context, state string) (*models.OIDCState, error) {
+	// Reading and deleting at once, another callback with the same state finds nothing
+	data, err := s.redis.GetDel(ctx, oidcStateKey(state)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+
+	var oidcState models.OIDCState
+	if err := json.Unmarshal(data, &oidcState); err != nil {
+		return nil, ErrInvalidOIDCState
+	}
+
+	return &oidcState, nil
+}
+
+// resolveUser finds, links or provisions the user of a provider account
+func (s *oidcService) resolveUser(ctx context.Context, identity *oidc.Identity, linkUserID string) (*models.User, error) {
+	existing, err := s.repo.FindIdentity(ctx, identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	switch {
+	case existing != nil:
+		if linkUserID != "" && existing.UserID != linkUserID {
+			return nil, ErrIdentityLinked
+		}
+		if err := s.repo.TouchIdentity(ctx, existing.ID, verifiedEmail(identity)); err != nil {
+			return nil, err
+		}
+		return s.getUser(ctx, existing.UserID)
+
+	case linkUserID != "":
+		return s.linkIdentity(ctx, identity, linkUserID)
+	}
+
+	return s.provisionUser(ctx, identity)
+}
+
+// linkIdentity links a provider account to the user who started the flow, one account per provider
+func (s *oidcService) linkIdentity(ctx context.Context, identity *oidc.Identity, userID string) (*models.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.repo.ListIdentities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range linked {
+		if l.Provider == identity.Provider {
+			return nil, ErrProviderAlreadyLinked
+		}
+	}
+
+	if err := s.repo.LinkIdentity(ctx, toUserIdentity(identity, userID)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// provisionUser creates the user of a provider account on its first login. The user gets a random password,
+// they can set their own with a password reset when the provider gave a verified email address.
+func (s *oidcService) provisionUser(ctx context.Context, identity *oidc.Identity) (*models.User, error) {
+	email := verifiedEmail(identity)
+	if email != "" {
+		// Linking by email would hand the account to whoever controls that address at the provider
+		existing, err := s.userRepo.GetUserByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrOIDCEmailExists
+		}
+	}
+
+	username, err := s.availableUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		ID:           uuid.NewString(),
+		Username:     username,
+		Email:        email,
+		PasswordHash: password,
+		Role:         models.RoleUser,
+	}
+	if err := s.repo.CreateUserWithIdentity(ctx, user, toUserIdentity(identity, user.ID)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// availableUsername derives a username from the provider account, with a random suffix when it is taken
+func (s *oidcService) availableUsername(ctx context.Context, identity *oidc.Identity) (string, error) {
+	base := identity.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	if base == "" {
+		base = identity.Name
+	}
+	base = usernameInvalidChars.ReplaceAllString(strings.ToLower(base), "_")
+	base = strings.Trim(base, "_.-")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	if len(base) < 3 {
+		base = identity.Provider + "_user"
+	}
+
+	username := base
+	for i := 0; i < usernameAttempts; i++ {
+		existing, err := s.userRepo.GetUserByUsername(ctx, username)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return username, nil
+		}
+		username = base + "_" + uuid.NewString()[:6]
+	}
+
+	return "", ErrUsernameUnavailable
+}
+
+func (s *oidcService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return user, nil
+}
+
+func toUserIdentity(identity *oidc.Identity, userID string) *models.UserIdentity {
+	return &models.UserIdentity{
+		ID:       uuid.NewString(),
+		UserID:   userID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    verifiedEmail(identity),
+	}
+}
+
+// verifiedEmail returns the email address of a provider account only when the provider verified it
+func verifiedEmail(identity *oidc.Identity) string {
+	if !identity.EmailVerified {
+		return ""
+	}
+	return identity.Email
+}
+
+func oidcStateKey(state string) string {
+	return "oidc:state:" + helpers.HashToken(state)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultPasswordMinLength  = 8
+	defaultPasswordMinClasses = 2
+	// bcrypt ignores everything after 72 bytes
+	passwordMaxBytes = 72
+)
+
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+
+// validatePassword checks a password against the policy: at least PASSWORD_MIN_LENGTH characters,
+// at least PASSWORD_MIN_CLASSES of lowercase letters, uppercase letters, digits and symbols, and not containing the username
+func validatePassword(password, username string) error {
+	minLength := envInt("PASSWORD_MIN_LENGTH", defaultPasswordMinLength)
+	if len([]rune(password)) < minLength {
+		return fmt.Errorf("%w: it must be at least %d characters long", ErrWeakPassword, minLength)
+	}
+	if len(password) > passwordMaxBytes {
+		return fmt.Errorf("%w: it must be at most %d bytes long", ErrWeakPassword, passwordMaxBytes)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+
+	minClasses := envInt("PASSWORD_MIN_CLASSES", defaultPasswordMinClasses)
+	if classes < minClasses {
+		return fmt.Errorf("%w: it must mix at least %d of lowercase letters, uppercase letters, digits and symbols", ErrWeakPassword, minClasses)
+	}
+
+	if username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return fmt.Errorf("%w: it must not contain the username", ErrWeakPassword)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrReviewExists            = errors.New("you have already reviewed this movie")
+	ErrReviewNotOwner          = errors.New("you can only change your own review")
+	ErrReviewOwnHelpful        = errors.New("you cannot mark your own review as helpful")
+	ErrReviewOwnReport         = errors.New("you cannot report your own review")
+	ErrInvalidReviewStatus     = errors.New("invalid status, must be pending, approved or rejected")
+	ErrInvalidModerationAction = errors.New("invalid action, must be approve or reject")
+)
+
+const defaultReviewReportThreshold = 1
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID, movieID string, req models.ReviewRequest) (*models.Review, error)
+	UpdateReview(ctx context.Context, userID, reviewID string, req models.ReviewRequest) (*models.Review, error)
+	DeleteReview(ctx context.Context, userID, reviewID string) error
+	ListReviews(ctx context.Context, movieID, sort string, limit, offset int) (*models.ReviewPage, error)
+	MarkHelpful(ctx context.Context, userID, reviewID string) error
+	UnmarkHelpful(ctx context.Context, userID, reviewID string) error
+	ReportReview(ctx context.Context, userID, reviewID string, req models.ReportReviewRequest) error
+	ListModerationQueue(ctx context.Context, status string, limit, offset int) ([]models.Review, error)
+	ModerateReview(ctx context.Context, adminID, reviewID, action string, req models.ModerationRequest) error
+	ListModerationActions(ctx context.Context, reviewID string) ([]models.ModerationAction, error)
+}
+
+type reviewService struct {
+	repo      repositories.ReviewRepository
+	movieRepo repositories.MovieRepository
+}
+
+func NewReviewService(repo repositories.ReviewRepository, movieRepo repositories.MovieRepository) ReviewService {
+	return &reviewService{repo: repo, movieRepo: movieRepo}
+}
+
+// CreateReview posts the review of a user on a movie, a user reviews a movie once
+func (s *reviewService) CreateReview(ctx context.Context, userID, movieID string, req models.ReviewRequest) (*models.Review, error) {
+	if _, err := s.movieRepo.FindMovieByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByMovieAndUser(ctx, movieID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrReviewExists
+	}
+
+	review := &models.Review{
+		ID:      uuid.NewString(),
+		MovieID: movieID,
+		UserID:  userID,
+		Rating:  req.Rating,
+		Body:    req.Body,
+	}
+	screenReview(review)
+	if err := s.repo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, review.ID)
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, userID, reviewID string, req models.ReviewRequest) (*models.Review, error) {
+	review, err := s.ownReview(ctx, userID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only an approved review stays approved after a clean edit, a pending or rejected one
+	// only comes back through the moderation queue
+	previousStatus, previousReason := review.Status, review.ModerationReason
+
+	review.Rating = req.Rating
+	review.Body = req.Body
+	screenReview(review)
+	if previousStatus != models.ReviewStatusApproved && review.Status == models.ReviewStatusApproved {
+		review.Status = models.ReviewStatusPending
+		review.ModerationReason = previousReason
+		if previousStatus == models.ReviewStatusRejected {
+			review.ModerationReason = "edited after rejection"
+		}
+	}
+	if err := s.repo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, reviewID)
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, userID, reviewID string) error {
+	if _, err := s.ownReview(ctx, userID, reviewID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, reviewID)
+}
+
+// ListReviews returns the rating summary of a movie with a page of its reviews
+func (s *reviewService) ListReviews(ctx context.Context, movieID, sort string, limit, offset int) (*models.ReviewPage, error) {
+	if _, err := s.movieRepo.FindMovieByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	// Validate sort, default to newest if invalid
+	if sort != models.ReviewSortNewest && sort != models.ReviewSortHelpful {
+		sort = models.ReviewSortNewest
+	}
+
+	summary, err := s.repo.GetRatingSummary(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.repo.ListByMovie(ctx, movieID, sort, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReviewPage{
+		Rating:  *summary,
+		Reviews: reviews,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+func (s *reviewService) MarkHelpful(ctx context.Context, userID, reviewID string) error {
+	review, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID == userID {
+		return ErrReviewOwnHelpful
+	}
+
+	return s.repo.MarkHelpful(ctx, reviewID, userID)
+}
+
+func (s *reviewService) UnmarkHelpful(ctx context.Context, userID, reviewID string) error {
+	return s.repo.UnmarkHelpful(ctx, reviewID, userID)
+}
+
+// ReportReview flags a review of another user, which sends it back to the moderation queue
+// once it has REVIEW_REPORT_THRESHOLD open reports
+func (s *reviewService) ReportReview(ctx context.Context, userID, reviewID string, req models.ReportReviewRequest) error {
+	review, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID == userID {
+		return ErrReviewOwnReport
+	}
+
+	threshold, err := strconv.Atoi(os.Getenv("REVIEW_REPORT_THRESHOLD"))
+	if err != nil || threshold <= 0 {
+		threshold = defaultReviewReportThreshold
+	}
+
+	return s.repo.Report(ctx, reviewID, userID, req.Reason, threshold)
+}
+
+// ListModerationQueue lists the reviews in a moderation state, pending by default
+func (s *reviewService) ListModerationQueue(ctx context.Context, status string, limit, offset int) ([]models.Review, error) {
+	switch status {
+	case "":
+		status = models.ReviewStatusPending
+	case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected:
+	default:
+		return nil, ErrInvalidReviewStatus
+	}
+
+	return s.repo.ListByStatus(ctx, status, limit, offset)
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, adminID, reviewID, action string, req models.ModerationRequest) error {
+	if action != models.ModerationActionApprove && action != models.ModerationActionReject {
+		return ErrInvalidModerationAction
+	}
+
+	return s.repo.Moderate(ctx, reviewID, adminID, action, req.Reason)
+}
+
+func (s *reviewService) ListModerationActions(ctx context.Context, reviewID string) ([]models.ModerationAction, error) {
+	if _, err := s.repo.FindByID(ctx, reviewID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListModerationActions(ctx, reviewID)
+}
+
+// screenReview publishes a review right away unless its text contains a banned word, which holds it for moderation
+func screenReview(review *models.Review) {
+	review.Status = models.ReviewStatusApproved
+	review.ModerationReason = ""
+
+	if word := findBannedWord(review.Body); word != "" {
+		review.Status = models.ReviewStatusPending
+		review.ModerationReason = "contains banned word: " + word
+	}
+}
+
+// findBannedWord returns the first word of text listed in the comma separated BANNED_WORDS, ignoring case
+func findBannedWord(text string) string {
+	banned := map[string]bool{}
+	for _, word := range strings.Split(os.Getenv("BANNED_WORDS"), ",") {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			banned[word] = true
+		}
+	}
+	if len(banned) == 0 {
+		return ""
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		if banned[word] {
+			return word
+		}
+	}
+
+	return ""
+}
+
+// ownReview loads a review and makes sure it belongs to the user
+func (s *reviewService) ownReview(ctx context.Context, userID, reviewID string) (*models.Review, error) {
+	review, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID != userID {
+		return nil, ErrReviewNotOwner
+	}
+
+	return review, nil
+}
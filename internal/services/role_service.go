@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+const rolePermissionsCacheTTL = 10 * time.Minute
+
+var (
+	ErrRoleExists        = errors.New("role with this name already exists")
+	ErrInvalidRoleName   = errors.New("role name must start with a letter and contain only lowercase letters, digits, - and _")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrBuiltinRole       = errors.New("the built-in admin and user roles cannot be changed")
+	ErrRoleInUse         = errors.New("role is still assigned to users")
+	ErrUnknownRole       = errors.New("role is not exists")
+	ErrRoleEscalation    = errors.New("cannot grant a role with permissions you do not have")
+	ErrChangeOwnRole     = errors.New("cannot change your own role")
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+type RoleService interface {
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	CreateRole(ctx context.Context, actor *helpers.Claims, req models.RoleRequest) (*models.Role, error)
+	UpdateRole(ctx context.Context, actor *helpers.Claims, name string, req models.RoleRequest) (*models.Role, error)
+	DeleteRole(ctx context.Context, actor *helpers.Claims, name string) error
+	AssignRole(ctx context.Context, actor *helpers.Claims, userID, role string) error
+	RolePermissions(ctx context.Context, role string) ([]string, error)
+	CheckOutranked(ctx context.Context, actor *helpers.Claims, role string) error
+}
+
+type roleService struct {
+	repo  repositories.RoleRepository
+	redis redis.Cmdable
+}
+
+func NewRoleService(repo repositories.RoleRepository, redisClient redis.Cmdable) RoleService {
+	return &roleService{repo: repo, redis: redisClient}
+}
+
+func (s *roleService) ListRoles(ctx context.Context) ([]models.Role, error) {
+	return s.repo.List(ctx)
+}
+
+// CreateRole adds a custom role, admins can only create roles with the permissions they hold themselves
+func (s *roleService) CreateRole(ctx context.Context, actor *helpers.Claims, req models.RoleRequest) (*models.Role, error) {
+	role, err := toRole(req.Name, req)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := s.RolePermissions(ctx, actor.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGranted(granted, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.FindByName(ctx, role.Name)
+	if err == nil {
+		return nil, ErrRoleExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// UpdateRole replaces the description and the permissions of a role, the name cannot change.
+// Admins can only change roles that grant nothing more than they hold, and only to permissions they hold.
+func (s *roleService) UpdateRole(ctx context.Context, actor *helpers.Claims, name string, req models.RoleRequest) (*models.Role, error) {
+	if isBuiltinRole(name) {
+		return nil, ErrBuiltinRole
+	}
+
+	role, err := toRole(name, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CheckOutranked(ctx, actor, name); err != nil {
+		return nil, err
+	}
+	granted, err := s.RolePermissions(ctx, actor.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGranted(granted, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.invalidatePermissions(ctx, name)
+	return role, nil
+}
+
+// DeleteRole removes a custom role that no user has anymore, the users have to be given another role first.
+// Admins can only delete roles that grant nothing more than they hold.
+func (s *roleService) DeleteRole(ctx context.Context, actor *helpers.Claims, name string) error {
+	if isBuiltinRole(name) {
+		return ErrBuiltinRole
+	}
+
+	if err := s.CheckOutranked(ctx, actor, name); err != nil {
+		return err
+	}
+
+	users, err := s.repo.CountUsers(ctx, name)
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return ErrRoleInUse
+	}
+
+	// A user may have been given the role since it was counted
+	if err := s.repo.Delete(ctx, name); err != nil {
+		if errors.Is(err, repositories.ErrRoleAssigned) {
+			return ErrRoleInUse
+		}
+		return err
+	}
+
+	s.invalidatePermissions(ctx, name)
+	return nil
+}
+
+// AssignRole changes the role of a user. Admins can only grant roles whose permissions they hold themselves,
+// cannot change the role of a user holding permissions they lack, and the access tokens of the user issued
+// before the change stop working.
+func (s *roleService) AssignRole(ctx context.Context, actor *helpers.Claims, userID, role string) error {
+	if actor.UserID == userID {
+		return ErrChangeOwnRole
+	}
+
+	target, err := s.repo.FindByName(ctx, role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUnknownRole
+		}
+		return err
+	}
+
+	granted, err := s.RolePermissions(ctx, actor.Role)
+	if err != nil {
+		return err
+	}
+	if err := checkGranted(granted, target.Permissions); err != nil {
+		return err
+	}
+
+	current, err := s.repo.FindUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	held, err := s.RolePermissions(ctx, current)
+	if err != nil {
+		return err
+	}
+	if err := checkGranted(granted, held); err != nil {
+		return err
+	}
+
+	if err := s.repo.AssignToUser(ctx, userID, role); err != nil {
+		return err
+	}
+
+	// The role is a claim of the access token, reject the tokens carrying the previous role.
+	// Refreshing the session reads the new role from the database.
+	changedAt := time.Now().Unix()
+	if err := s.redis.Set(ctx, helpers.RoleChangedKey(userID), changedAt, helpers.LoadJWTExpiry()).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RolePermissions returns the permissions granted by a role, cached in Redis.
+// A role that no longer exists grants no permission.
+func (s *roleService) RolePermissions(ctx context.Context, role string) ([]string, error) {
+	cacheKey := rolePermissionsKey(role)
+	if data, err := s.redis.Get(ctx, cacheKey).Bytes(); err == nil {
+		var permissions []string
+		if err := json.Unmarshal(data, &permissions); err == nil {
+			return permissions, nil
+		}
+	}
+
+	permissions := []string{}
+	found, err := s.repo.FindByName(ctx, role)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if found != nil {
+		permissions = found.Permissions
+	}
+
+	if data, err := json.Marshal(permissions); err == nil {
+		s.redis.Set(ctx, cacheKey, string(data), rolePermissionsCacheTTL)
+	}
+
+	return permissions, nil
+}
+
+// CheckOutranked returns ErrRoleEscalation when the role grants a permission the role of the actor does not
+func (s *roleService) CheckOutranked(ctx context.Context, actor *helpers.Claims, role string) error {
+	permissions, err := s.RolePermissions(ctx, role)
+	if err != nil {
+		return err
+	}
+	granted, err := s.RolePermissions(ctx, actor.Role)
+	if err != nil {
+		return err
+	}
+
+	return checkGranted(granted, permissions)
+}
+
+// checkGranted returns ErrRoleEscalation when a permission is missing from the granted ones
+func checkGranted(granted, permissions []string) error {
+	for _, permission := range permissions {
+		if !slices.Contains(granted, permission) {
+			return ErrRoleEscalation
+		}
+	}
+
+	return nil
+}
+
+func (s *roleService) invalidatePermissions(ctx context.Context, role string) {
+	if err := s.redis.Del(ctx, rolePermissionsKey(role)).Err(); err != nil {
+		log.Printf("Error invalidating permissions of role %s: %v", role, err)
+	}
+}
+
+func rolePermissionsKey(role string) string {
+	return "role:permissions:" + role
+}
+
+func isBuiltinRole(name string) bool {
+	return name == models.RoleAdmin || name == models.RoleUser
+}
+
+// toRole validates the name and the permissions of a role, duplicated permissions are dropped
+func toRole(name string, req models.RoleRequest) (*models.Role, error) {
+	if !roleNamePattern.MatchString(name) {
+		return nil, ErrInvalidRoleName
+	}
+
+	role := &models.Role{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: []string{},
+	}
+	for _, permission := range req.Permissions {
+		if !slices.Contains(models.Permissions, permission) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, permission)
+		}
+		if !slices.Contains(role.Permissions, permission) {
+			role.Permissions = append(role.Permissions, permission)
+		}
+	}
+
+	return role, nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+var (
+	ErrDisableSelf       = errors.New("cannot disable your own account")
+	ErrInvalidUserStatus = errors.New("invalid status, must be active or disabled")
+	ErrUserOutranks      = errors.New("cannot manage a user with permissions you do not have")
+)
+
+func (s *userService) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	if filter.Status != "" && filter.Status != models.UserStatusActive && filter.Status != models.UserStatusDisabled {
+		return nil, ErrInvalidUserStatus
+	}
+
+	return s.repo.ListUsers(ctx, filter)
+}
+
+// GetUserDetail returns a user with the summary of their activity
+func (s *userService) GetUserDetail(ctx context.Context, userID string) (*models.UserDetail, error) {
+	return s.repo.GetUserDetail(ctx, userID)
+}
+
+func (s *userService) ListUserVotes(ctx context.Context, userID string, limit, offset int) ([]models.UserVote, error) {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListUserVotes(ctx, userID, limit, offset)
+}
+
+// DisableUser blocks the logins of a user and signs out their sessions, their access tokens stop working at once.
+// Admins cannot disable a user whose role grants permissions they do not have.
+func (s *userService) DisableUser(ctx context.Context, actor *helpers.Claims, userID string) error {
+	if actor.UserID == userID {
+		return ErrDisableSelf
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOutranked(ctx, actor, user); err != nil {
+		return err
+	}
+
+	if !user.Disabled() {
+		if err := s.repo.SetDisabled(ctx, userID, true); err != nil {
+			return err
+		}
+	}
+
+	return s.signOutEverywhere(ctx, userID)
+}
+
+// EnableUser lets a disabled user log in again
+func (s *userService) EnableUser(ctx context.Context, userID string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.Disabled() {
+		return nil
+	}
+
+	return s.repo.SetDisabled(ctx, userID, false)
+}
+
+// ForcePasswordReset signs out every session of the user and blocks their logins until they reset the password
+// with the reset token sent to them
+func (s *userService) ForcePasswordReset(ctx context.Context, actor *helpers.Claims, userID string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOutranked(ctx, actor, user); err != nil {
+		return err
+	}
+
+	if err := s.repo.RequirePasswordReset(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.signOutEverywhere(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.sendPasswordResetToken(ctx, user, "An administrator asked you to choose a new password, you cannot log in until you do.")
+}
+
+// checkOutranked rejects admins acting on a user whose role grants permissions they do not have
+func (s *userService) checkOutranked(ctx context.Context, actor *helpers.Claims, user *models.User) error {
+	err := s.roles.CheckOutranked(ctx, actor, user.Role)
+	if errors.Is(err, ErrRoleEscalation) {
+		return ErrUserOutranks
+	}
+
+	return err
+}
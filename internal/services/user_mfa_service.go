@@ -0,0 +1,442 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+)
+
+const (
+	defaultMFAIssuer       = "Movie Festival"
+	defaultMFAChallengeTTL = 5 * time.Minute
+	// mfaMaxAttempts is the number of codes a challenge accepts before the login has to start over
+	mfaMaxAttempts     = 5
+	recoveryCodesCount = 10
+	// usedTOTPCodeTTL covers the periods a code is accepted in, so it cannot be replayed
+	usedTOTPCodeTTL = 2 * time.Minute
+)
+
+var (
+	ErrInvalidMFAToken   = errors.New("invalid or expired MFA token, please log in again")
+	ErrInvalidMFACode    = errors.New("invalid MFA code")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotSetUp       = errors.New("two-factor authentication has not been set up, start with the setup")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFARequired       = errors.New("two-factor authentication is required for this account")
+)
+
+// mfaAdminPermissions are the permissions that make a role administrative for MFA_REQUIRED_FOR_ADMIN
+var mfaAdminPermissions = []string{models.PermissionUserManage, models.PermissionRoleManage, models.PermissionFestivalManage}
+
+// CompleteMFALogin completes a login with a TOTP code or a recovery code.
+// A user who has to enroll first confirms the secret of SetupMFALogin with a TOTP code and gets the recovery codes in the response.
+func (s *userService) CompleteMFALogin(ctx context.Context, req models.MFALoginRequest, device models.SessionDevice) (*models.LoginResponse, error) {
+	challengeKey := mfaChallengeKey(req.MFAToken)
+	user, err := s.challengeUser(ctx, challengeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.lockouts.CheckLogin(ctx, user.Username, device.IPAddress); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.redis.Incr(ctx, challengeKey+":attempts").Result()
+	if err != nil {
+		return nil, err
+	}
+	if attempts == 1 {
+		s.redis.Expire(ctx, challengeKey+":attempts", envDuration("MFA_CHALLENGE_TTL", defaultMFAChallengeTTL))
+	}
+	if attempts > mfaMaxAttempts {
+		s.redis.Del(ctx, challengeKey, challengeKey+":attempts")
+		return nil, ErrInvalidMFAToken
+	}
+
+	var recoveryCodes []string
+	if user.MFAEnabled() {
+		err = s.verifySecondFactor(ctx, user, req.Code)
+	} else {
+		if user.MFASecret == "" {
+			return nil, ErrMFANotSetUp
+		}
+		if err = s.verifyTOTP(ctx, user, req.Code); err == nil {
+			recoveryCodes, err = s.enableMFA(ctx, user)
+		}
+	}
+	if err != nil {
+		if !errors.Is(err, ErrInvalidMFACode) {
+			return nil, err
+		}
+		return nil, s.failedMFACode(ctx, user.Username, device.IPAddress)
+	}
+
+	// The challenge completes one login
+	if err := s.redis.Del(ctx, challengeKey, challengeKey+":attempts").Err(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.completeLogin(ctx, user, device)
+	if err != nil {
+		return nil, err
+	}
+	res.RecoveryCodes = recoveryCodes
+
+	return res, nil
+}
+
+// SetupMFALogin starts the enrollment of a user whose login is waiting for a second factor they do not have yet
+func (s *userService) SetupMFALogin(ctx context.Context, mfaToken string) (*models.MFASetup, error) {
+	user, err := s.challengeUser(ctx, mfaChallengeKey(mfaToken))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startMFASetup(ctx, user)
+}
+
+// SetupMFA creates a TOTP secret for the user to enroll in an authenticator app.
+// The secret only protects the account once EnableMFA confirmed it with a code.
+func (s *userService) SetupMFA(ctx context.Context, claims *helpers.Claims) (*models.MFASetup, error) {
+	user, err := s.getUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startMFASetup(ctx, user)
+}
+
+// EnableMFA confirms the TOTP secret of the setup with a code and returns the recovery codes, which are only shown once
+func (s *userService) EnableMFA(ctx context.Context, claims *helpers.Claims, code, ipAddress string) ([]string, error) {
+	user, err := s.getUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFASecret == "" {
+		return nil, ErrMFANotSetUp
+	}
+
+	if err := s.verifyMFAAttempt(ctx, user, code, ipAddress, s.verifyTOTP); err != nil {
+		return nil, err
+	}
+
+	return s.enableMFA(ctx, user)
+}
+
+// DisableMFA turns two-factor authentication off after checking the password and a second factor.
+// A wrong password or code counts as a failed login, like in Login and CompleteMFALogin.
+func (s *userService) DisableMFA(ctx context.Context, claims *helpers.Claims, req models.DisableMFARequest, ipAddress string) error {
+	user, err := s.getUser(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled() {
+		return ErrMFANotEnabled
+	}
+	required, err := s.mfaRequiredForRole(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFARequired
+	}
+
+	if err := s.lockouts.CheckLogin(ctx, user.Username, ipAddress); err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return s.failedCheck(ctx, user.Username, ipAddress, ErrInvalidCurrentPassword)
+	}
+	if err := s.verifyMFAAttempt(ctx, user, req.Code, ipAddress, s.verifySecondFactor); err != nil {
+		return err
+	}
+
+	return s.repo.DisableMFA(ctx, user.ID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user after checking a TOTP code
+func (s *userService) RegenerateRecoveryCodes(ctx context.Context, claims *helpers.Claims, code, ipAddress string) ([]string, error) {
+	user, err := s.getUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled() {
+		return nil, ErrMFANotEnabled
+	}
+
+	if err := s.verifyMFAAttempt(ctx, user, code, ipAddress, s.verifyTOTP); err != nil {
+		return nil, err
+	}
+
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, user.ID, codeHashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// mfaRequired tells whether the login of the user needs a second factor
+func (s *userService) mfaRequired(ctx context.Context, user *models.User) (bool, error) {
+	if user.MFAEnabled() {
+		return true, nil
+	}
+
+	return s.mfaRequiredForRole(ctx, user.Role)
+}
+
+// mfaRequiredForRole applies MFA_REQUIRED_FOR_ADMIN, which makes every user whose role grants one of the
+// administrative permissions enroll at their next login
+func (s *userService) mfaRequiredForRole(ctx context.Context, role string) (bool, error) {
+	if required, _ := strconv.ParseBool(os.Getenv("MFA_REQUIRED_FOR_ADMIN")); !required {
+		return false, nil
+	}
+
+	permissions, err := s.roles.RolePermissions(ctx, role)
+	if err != nil {
+		return false, err
+	}
+	for _, permission := range mfaAdminPermissions {
+		if slices.Contains(permissions, permission) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// startMFAChallenge stores the login until the second factor is given and returns the token to complete it with
+func (s *userService) startMFAChallenge(ctx context.Context, user *models.User, device models.SessionDevice) (*models.LoginResponse, error) {
+	token, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := json.Marshal(models.MFAChallenge{UserID: user.ID, UserAgent: device.UserAgent, IPAddress: device.IPAddress})
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := envDuration("MFA_CHALLENGE_TTL", defaultMFAChallengeTTL)
+	if err := s.redis.Set(ctx, mfaChallengeKey(token), challenge, ttl).Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.LoginResponse{
+		MFARequired:           true,
+		MFAToken:              token,
+		MFAEnrollmentRequired: !user.MFAEnabled(),
+	}, nil
+}
+
+// challengeUser returns the user of a pending MFA challenge
+func (s *userService) challengeUser(ctx context.Context, challengeKey string) (*models.User, error) {
+	data, err := s.redis.Get(ctx, challengeKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+
+	var challenge models.MFAChallenge
+	if err := json.Unmarshal(data, &challenge); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	return user, nil
+}
+
+func (s *userService) startMFASetup(ctx context.Context, user *models.User) (*models.MFASetup, error) {
+	if user.MFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetMFASecret(ctx, user.ID, secret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMFAAlreadyEnabled
+		}
+		return nil, err
+	}
+
+	issuer := os.Getenv("MFA_ISSUER")
+	if issuer == "" {
+		issuer = defaultMFAIssuer
+	}
+
+	return &models.MFASetup{Secret: secret, OtpauthURI: helpers.TOTPURI(issuer, user.Username, secret)}, nil
+}
+
+func (s *userService) enableMFA(ctx context.Context, user *models.User) ([]string, error) {
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.EnableMFA(ctx, user.ID, codeHashes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMFAAlreadyEnabled
+		}
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// verifySecondFactor accepts a TOTP code or an unused recovery code
+func (s *userService) verifySecondFactor(ctx context.Context, user *models.User, code string) error {
+	err := s.verifyTOTP(ctx, user, code)
+	if !errors.Is(err, ErrInvalidMFACode) {
+		return err
+	}
+
+	codeHash := helpers.HashToken(helpers.NormalizeRecoveryCode(code))
+	if err := s.repo.UseRecoveryCode(ctx, user.ID, codeHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidMFACode
+		}
+		return err
+	}
+
+	return nil
+}
+
+// verifyTOTP accepts a TOTP code of the user once
+func (s *userService) verifyTOTP(ctx context.Context, user *models.User, code string) error {
+	step, ok := helpers.ValidateTOTP(user.MFASecret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	fresh, err := s.redis.SetNX(ctx, fmt.Sprintf("mfa:used:%s:%d", user.ID, step), "true", usedTOTPCodeTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// verifyMFAAttempt checks a code of a logged in user with verify, under the same attempt limit and lockout as the MFA logins
+func (s *userService) verifyMFAAttempt(ctx context.Context, user *models.User, code, ipAddress string,
+	verify func(ctx context.Context, user *models.User, code string) error) error {
+	if err := s.lockouts.CheckLogin(ctx, user.Username, ipAddress); err != nil {
+		return err
+	}
+
+	attemptsKey := mfaAttemptsKey(user.ID)
+	window := envDuration("MFA_CHALLENGE_TTL", defaultMFAChallengeTTL)
+	attempts, err := s.redis.Incr(ctx, attemptsKey).Result()
+	if err != nil {
+		return err
+	}
+	if attempts == 1 {
+		s.redis.Expire(ctx, attemptsKey, window)
+	}
+	if attempts > mfaMaxAttempts {
+		retryAfter, err := s.redis.TTL(ctx, attemptsKey).Result()
+		if err != nil || retryAfter <= 0 {
+			retryAfter = window
+		}
+		return &LoginBlockedError{Err: ErrTooManyAttempts, RetryAfter: retryAfter}
+	}
+
+	if err := verify(ctx, user, code); err != nil {
+		if !errors.Is(err, ErrInvalidMFACode) {
+			return err
+		}
+		return s.failedMFACode(ctx, user.Username, ipAddress)
+	}
+
+	s.redis.Del(ctx, attemptsKey)
+	return nil
+}
+
+// failedMFACode counts a wrong code as a failed login, so codes cannot be guessed faster than passwords
+func (s *userService) failedMFACode(ctx context.Context, username, ipAddress string) error {
+	return s.failedCheck(ctx, username, ipAddress, ErrInvalidMFACode)
+}
+
+// failedCheck counts a wrong password or code of a logged in user as a failed login and returns invalid,
+// or the lockout the failure ended in
+func (s *userService) failedCheck(ctx context.Context, username, ipAddress string, invalid error) error {
+	err := s.lockouts.RecordFailedLogin(ctx, username, ipAddress)
+	var blocked *LoginBlockedError
+	if errors.As(err, &blocked) {
+		return err
+	}
+	if err != nil {
+		log.Printf("Error recording failed login of %s: %v", username, err)
+	}
+
+	return invalid
+}
+
+func (s *userService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return user, nil
+}
+
+// generateRecoveryCodes returns the recovery codes with the hashes they are stored as
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes, err := helpers.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codeHashes := make([]string, len(codes))
+	for i, code := range codes {
+		codeHashes[i] = helpers.HashToken(code)
+	}
+
+	return codes, codeHashes, nil
+}
+
+func mfaChallengeKey(token string) string {
+	return "mfa:challenge:" + helpers.HashToken(token)
+}
+
+func mfaAttemptsKey(userID string) string {
+	return "mfa:attempts:" + userID
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -11,22 +14,64 @@ import (
 	"github.com/google/uuid"
 	"github.com/stwrtrio/movie-festival/internal/helpers"
 	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/notifiers"
 	"github.com/stwrtrio/movie-festival/internal/repositories"
 )
 
+const (
+	defaultPasswordResetTTL        = 30 * time.Minute
+	defaultPasswordForgotUserLimit = 3
+	passwordForgotWindow           = time.Hour
+)
+
+var (
+	ErrInvalidRefreshToken    = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused     = errors.New("refresh token has already been used, the session has been revoked")
+	ErrEmailExists            = errors.New("email already exists")
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+	ErrSamePassword           = errors.New("new password must be different from the current password")
+	ErrInvalidResetToken      = errors.New("invalid or expired password reset token")
+	ErrAccountDisabled        = errors.New("account has been disabled")
+	ErrPasswordResetRequired  = errors.New("password has to be reset, use the password reset token sent to you")
+)
+
 type UserService interface {
 	Register(ctx context.Context, req models.RegisterRequest) error
-	Login(ctx context.Context, username, password string) (string, error)
+	Login(ctx context.Context, username, password string, device models.SessionDevice) (*models.LoginResponse, error)
+	ExternalLogin(ctx context.Context, user *models.User, device models.SessionDevice) (*models.LoginResponse, error)
+	CompleteMFALogin(ctx context.Context, req models.MFALoginRequest, device models.SessionDevice) (*models.LoginResponse, error)
+	SetupMFALogin(ctx context.Context, mfaToken string) (*models.MFASetup, error)
+	Refresh(ctx context.Context, refreshToken string, device models.SessionDevice) (*models.TokenPair, error)
 	Logout(ctx context.Context, claims *helpers.Claims) error
+	ListSessions(ctx context.Context, claims *helpers.Claims) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) error
+	ChangePassword(ctx context.Context, claims *helpers.Claims, req models.ChangePasswordRequest, device models.SessionDevice) (*models.TokenPair, error)
+	ForgotPassword(ctx context.Context, username string) error
+	ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error
+	SetupMFA(ctx context.Context, claims *helpers.Claims) (*models.MFASetup, error)
+	EnableMFA(ctx context.Context, claims *helpers.Claims, code, ipAddress string) ([]string, error)
+	DisableMFA(ctx context.Context, claims *helpers.Claims, req models.DisableMFARequest, ipAddress string) error
+	RegenerateRecoveryCodes(ctx context.Context, claims *helpers.Claims, code, ipAddress string) ([]string, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	GetUserDetail(ctx context.Context, userID string) (*models.UserDetail, error)
+	ListUserVotes(ctx context.Context, userID string, limit, offset int) ([]models.UserVote, error)
+	DisableUser(ctx context.Context, actor *helpers.Claims, userID string) error
+	EnableUser(ctx context.Context, userID string) error
+	ForcePasswordReset(ctx context.Context, actor *helpers.Claims, userID string) error
 }
 
 type userService struct {
-	repo  repositories.UserRepository
-	redis redis.Cmdable
+	repo        repositories.UserRepository
+	sessionRepo repositories.SessionRepository
+	redis       redis.Cmdable
+	notifier    notifiers.Notifier
+	lockouts    LockoutService
+	roles       RoleService
 }
 
-func NewUserService(repo repositories.UserRepository, redisClient redis.Cmdable) UserService {
-	return &userService{repo: repo, redis: redisClient}
+func NewUserService(repo repositories.UserRepository, sessionRepo repositories.SessionRepository, redisClient redis.Cmdable, notifier notifiers.Notifier, lockouts LockoutService, roles RoleService) UserService {
+	return &userService{repo: repo, sessionRepo: sessionRepo, redis: redisClient, notifier: notifier, lockouts: lockouts, roles: roles}
 }
 
 func (s *userService) Register(ctx context.Context, req models.RegisterRequest) error {
@@ -36,41 +81,175 @@ func (s *userService) Register(ctx context.Context, req models.RegisterRequest)
 		return errors.New("username already exists")
 	}
 
+	if err := validatePassword(req.Password, req.Username); err != nil {
+		return err
+	}
+
+	if req.Email != "" {
+		existingUser, err := s.repo.GetUserByEmail(ctx, req.Email)
+		if err != nil {
+			return err
+		}
+		if existingUser != nil {
+			return ErrEmailExists
+		}
+	}
+
 	// Create user model
 	user := &models.User{
 		ID:           uuid.NewString(),
 		Username:     req.Username,
+		Email:        req.Email,
 		PasswordHash: req.Password,
-		Role:         "user",
+		Role:         models.RoleUser,
 	}
 
 	// Save user in the repository
 	return s.repo.CreateUser(ctx, user)
 }
 
-func (s *userService) Login(ctx context.Context, username, password string) (string, error) {
+// Login checks the password of the user. Users with two-factor authentication get an MFA challenge
+// to complete with CompleteMFALogin instead of the tokens.
+func (s *userService) Login(ctx context.Context, username, password string, device models.SessionDevice) (*models.LoginResponse, error) {
+	if err := s.lockouts.CheckLogin(ctx, username, device.IPAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.GetUserByUsername(ctx, username)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if user == nil {
-		return "", errors.New("invalid credentials")
+
+	// Compare hashed passwords, unknown usernames count as failed logins too
+	if user == nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, s.failedLogin(ctx, username, device.IPAddress)
 	}
 
-	// Compare hashed passwords
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", errors.New("invalid credentials")
+	return s.authenticated(ctx, user, device)
+}
+
+// ExternalLogin logs in a user an identity provider authenticated, they still have to give their second factor
+func (s *userService) ExternalLogin(ctx context.Context, user *models.User, device models.SessionDevice) (*models.LoginResponse, error) {
+	return s.authenticated(ctx, user, device)
+}
+
+// authenticated continues a login once the user proved who they are, with the MFA challenge or the session of the login
+func (s *userService) authenticated(ctx context.Context, user *models.User, device models.SessionDevice) (*models.LoginResponse, error) {
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
 	}
 
-	// Generate JWT token
-	token, err := helpers.GenerateJWTToken(user.ID, user.Username, user.Role)
+	// The failed logins are kept until the second factor is given too
+	required, err := s.mfaRequired(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return s.startMFAChallenge(ctx, user, device)
+	}
+
+	return s.completeLogin(ctx, user, device)
+}
+
+// completeLogin clears the failed logins of the user and opens the session of the login
+func (s *userService) completeLogin(ctx context.Context, user *models.User, device models.SessionDevice) (*models.LoginResponse, error) {
+	// The account may have been disabled while the second factor was awaited
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.lockouts.RecordSuccessfulLogin(ctx, user.Username); err != nil {
+		log.Printf("Error clearing failed logins of %s: %v", user.Username, err)
+	}
+
+	tokens, err := s.openSession(ctx, user, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LoginResponse{TokenPair: tokens}, nil
+}
+
+// checkAccountStatus refuses the logins of disabled accounts and of accounts waiting for a password reset
+func checkAccountStatus(user *models.User) error {
+	if user.Disabled() {
+		return ErrAccountDisabled
+	}
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+
+	return nil
+}
+
+// failedLogin records a failed login and returns the error to answer it with
+func (s *userService) failedLogin(ctx context.Context, username, ipAddress string) error {
+	err := s.lockouts.RecordFailedLogin(ctx, username, ipAddress)
+	var blocked *LoginBlockedError
+	if errors.As(err, &blocked) {
+		return err
+	}
+	if err != nil {
+		// Do not block logins while Redis is unavailable
+		log.Printf("Error recording failed login of %s: %v", username, err)
+	}
+
+	return errors.New("invalid credentials")
+}
+
+// Refresh exchanges a refresh token for a new access token and the next refresh token of the session.
+// A refresh token can be used once, using it again means it was stolen and revokes the whole session.
+func (s *userService) Refresh(ctx context.Context, refreshToken string, device models.SessionDevice) (*models.TokenPair, error) {
+	token, err := s.sessionRepo.FindRefreshToken(ctx, helpers.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	session := token.Session
+	if session.RevokedAt != nil || !time.Now().Before(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		return nil, s.revokeReusedSession(ctx, session)
+	}
+
+	user, err := s.repo.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || checkAccountStatus(user) != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	// Sessions opened before two-factor authentication became required have to log in again
+	required, err := s.mfaRequired(ctx, user)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if required && !user.MFAEnabled() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	nextToken, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(helpers.LoadRefreshTokenExpiry())
+	if err := s.sessionRepo.RotateRefreshToken(ctx, token, helpers.HashToken(nextToken), device, expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Another request exchanged the same token first
+			return nil, s.revokeReusedSession(ctx, session)
+		}
+		return nil, err
 	}
 
-	return token, nil
+	return issueTokenPair(user, session.ID, nextToken)
 }
 
+// Logout revokes the access token and ends its session
 func (s *userService) Logout(ctx context.Context, claims *helpers.Claims) error {
 	// Calculate the token's remaining TTL
 	ttl := time.Until(claims.ExpiresAt.Time)
@@ -81,5 +260,250 @@ func (s *userService) Logout(ctx context.Context, claims *helpers.Claims) error
 		return err
 	}
 
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	err = s.sessionRepo.RevokeSession(ctx, claims.UserID, claims.SessionID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return s.revokeAccessTokens(ctx, claims.SessionID)
+}
+
+// ListSessions lists the active sessions of the user, flagging the session of the token used for the request
+func (s *userService) ListSessions(ctx context.Context, claims *helpers.Claims) ([]models.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == claims.SessionID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends a session of the user, which signs the device of the session out
+func (s *userService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if err := s.sessionRepo.RevokeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	return s.revokeAccessTokens(ctx, sessionID)
+}
+
+// RevokeAllSessions ends every session of the user, including the one of the request
+func (s *userService) RevokeAllSessions(ctx context.Context, userID string) error {
+	return s.signOutEverywhere(ctx, userID)
+}
+
+// ChangePassword replaces the password of the user after checking the current one.
+// Every session of the user is signed out and a new session is opened for the device of the request.
+func (s *userService) ChangePassword(ctx context.Context, claims *helpers.Claims, req models.ChangePasswordRequest, device models.SessionDevice) (*models.TokenPair, error) {
+	user, err := s.repo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	// A wrong current password counts as a failed login, so a stolen session cannot guess the password
+	if err := s.lockouts.CheckLogin(ctx, user.Username, device.IPAddress); err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return nil, s.failedCheck(ctx, user.Username, device.IPAddress, ErrInvalidCurrentPassword)
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return nil, ErrSamePassword
+	}
+	if err := validatePassword(req.NewPassword, user.Username); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, user.ID, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.signOutEverywhere(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return s.openSession(ctx, user, device)
+}
+
+// ForgotPassword sends a single-use password reset token to the user through the notifier, at most
+// PASSWORD_FORGOT_USER_LIMIT times an hour. Unknown usernames are ignored without an error, so the response
+// does not tell which users exist.
+func (s *userService) ForgotPassword(ctx context.Context, username string) error {
+	user, err := s.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	// Past the limit the request is dropped with the same response, so the user is not flooded with tokens
+	requestsKey := "password:forgot:" + user.ID
+	requests, err := s.redis.Incr(ctx, requestsKey).Result()
+	if err != nil {
+		return err
+	}
+	if requests == 1 {
+		s.redis.Expire(ctx, requestsKey, passwordForgotWindow)
+	}
+	if requests > int64(envInt("PASSWORD_FORGOT_USER_LIMIT", defaultPasswordForgotUserLimit)) {
+		log.Printf("Too many password reset requests for user %s, ignoring the request", user.ID)
+		return nil
+	}
+
+	return s.sendPasswordResetToken(ctx, user, "If you did not ask for a password reset, you can ignore this message.")
+}
+
+// sendPasswordResetToken creates a single-use password reset token and sends it to the user through the notifier.
+// A failed delivery is only logged, the user can ask for another token.
+func (s *userService) sendPasswordResetToken(ctx context.Context, user *models.User, note string) error {
+	resetToken, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return err
+	}
+
+	token := &models.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(envDuration("PASSWORD_RESET_TTL", defaultPasswordResetTTL)),
+	}
+	if err := s.repo.CreatePasswordResetToken(ctx, token, helpers.HashToken(resetToken)); err != nil {
+		return err
+	}
+
+	msg := notifiers.Message{
+		UserID:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Subject:  "Reset your password",
+		Body: fmt.Sprintf("Use this token to reset your password before %s:\n\n%s\n\n%s",
+			token.ExpiresAt.Format(time.RFC1123), resetToken, note),
+	}
+	if err := s.notifier.Send(ctx, msg); err != nil {
+		log.Printf("Error sending password reset token to user %s: %v", user.ID, err)
+	}
+
 	return nil
 }
+
+// ResetPassword replaces the password of the user a reset token was sent to and signs out every session of the user
+func (s *userService) ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error {
+	token, err := s.repo.FindPasswordResetToken(ctx, helpers.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if token.UsedAt != nil || !time.Now().Before(token.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.repo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidResetToken
+	}
+
+	if err := validatePassword(req.NewPassword, user.Username); err != nil {
+		return err
+	}
+
+	if err := s.repo.ResetPassword(ctx, token, req.NewPassword); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	return s.signOutEverywhere(ctx, user.ID)
+}
+
+// openSession opens a session for the device and returns a short-lived access token with the first refresh token of the session
+func (s *userService) openSession(ctx context.Context, user *models.User, device models.SessionDevice) (*models.TokenPair, error) {
+	refreshToken, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		ID:         uuid.NewString(),
+		UserID:     user.ID,
+		UserAgent:  device.UserAgent,
+		IPAddress:  device.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(helpers.LoadRefreshTokenExpiry()),
+	}
+	if err := s.sessionRepo.CreateSession(ctx, session, helpers.HashToken(refreshToken)); err != nil {
+		return nil, err
+	}
+
+	return issueTokenPair(user, session.ID, refreshToken)
+}
+
+// signOutEverywhere ends every session of the user and rejects their access tokens
+func (s *userService) signOutEverywhere(ctx context.Context, userID string) error {
+	sessionIDs, err := s.sessionRepo.RevokeAllSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err := s.revokeAccessTokens(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+
+	// Tokens issued before sessions existed have no session to revoke, reject every token issued until now
+	signedOutAt := time.Now().Unix()
+	return s.redis.Set(ctx, helpers.SignedOutKey(userID), signedOutAt, helpers.LoadJWTExpiry()).Err()
+}
+
+// revokeReusedSession ends a session whose refresh token was presented twice and reports the reuse
+func (s *userService) revokeReusedSession(ctx context.Context, session models.Session) error {
+	log.Printf("Refresh token reused in session %s of user %s, revoking the session", session.ID, session.UserID)
+
+	err := s.sessionRepo.RevokeSession(ctx, session.UserID, session.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if err := s.revokeAccessTokens(ctx, session.ID); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// revokeAccessTokens rejects the access tokens already issued for a session until they expire on their own
+func (s *userService) revokeAccessTokens(ctx context.Context, sessionID string) error {
+	return s.redis.Set(ctx, helpers.RevokedSessionKey(sessionID), "true", helpers.LoadJWTExpiry()).Err()
+}
+
+func issueTokenPair(user *models.User, sessionID, refreshToken string) (*models.TokenPair, error) {
+	// Generate JWT token
+	token, err := helpers.GenerateJWTToken(user.ID, user.Username, user.Role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		Token:        token,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(helpers.LoadJWTExpiry().Seconds()),
+	}, nil
+}
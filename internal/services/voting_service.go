@@ -0,0 +1,543 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+var (
+	ErrVotingPeriodNotExists = errors.New("voting period is not exists")
+	ErrInvalidVotingPeriod   = errors.New("closes_at must be after opens_at")
+	ErrVotingClosed          = errors.New("voting is closed for this movie")
+	ErrVoteBudgetExhausted   = errors.New("you have no votes left in this voting period")
+	ErrAlreadyVoted          = errors.New("you have already voted for this movie")
+	ErrNotVoted              = errors.New("you haven't voted for this movie yet")
+	ErrInvalidRankedPeriod   = errors.New("ranked voting periods need max_rankings and take no vote_budget")
+	ErrInvalidApprovalPeriod = errors.New("approval voting periods take no max_rankings")
+	ErrBallotModeLocked      = errors.New("ballot mode and max_rankings cannot change once ballots are cast")
+	ErrNotRankedPeriod       = errors.New("voting period does not take ranked ballots")
+	ErrVotingPeriodClosed    = errors.New("voting period is closed")
+	ErrInvalidBallot         = errors.New("ballot must rank different movies, no more than max_rankings of the voting period")
+	ErrMovieNotEligible      = errors.New("movie cannot be voted for in this voting period")
+	ErrBallotNotExists       = errors.New("you have not cast a ballot in this voting period")
+	ErrInvalidTallyMethod    = errors.New("invalid tally method, must be instant_runoff or borda for ranked ballots and approval for approval ballots")
+)
+
+type VotingService interface {
+	ListPeriods(ctx context.Context, editionID string) ([]models.VotingPeriod, error)
+	CreatePeriod(ctx context.Context, editionID string, req models.VotingPeriodRequest) (*models.VotingPeriod, error)
+	UpdatePeriod(ctx context.Context, periodID string, req models.VotingPeriodRequest) (*models.VotingPeriod, error)
+	DeletePeriod(ctx context.Context, periodID string) error
+	ListUserPeriods(ctx context.Context, userID string) ([]models.UserVotingPeriod, error)
+	VoteMovie(ctx context.Context, userID, movieID string) error
+	UnvoteMovie(ctx context.Context, userID, movieID string) error
+	GetBallot(ctx context.Context, userID, periodID string) (*models.Ballot, error)
+	CastBallot(ctx context.Context, userID, periodID string, req models.BallotRequest) (*models.Ballot, error)
+	WithdrawBallot(ctx context.Context, userID, periodID string) error
+	TallyPeriod(ctx context.Context, periodID, method string) (*models.BallotTally, error)
+}
+
+type votingService struct {
+	repo            repositories.VotingRepository
+	movieRepo       repositories.MovieRepository
+	editionRepo     repositories.EditionRepository
+	competitionRepo repositories.CompetitionRepository
+}
+
+func NewVotingService(repo repositories.VotingRepository, movieRepo repositories.MovieRepository,
+	editionRepo repositories.EditionRepository, competitionRepo repositories.CompetitionRepository) VotingService {
+	return &votingService{repo: repo, movieRepo: movieRepo, editionRepo: editionRepo, competitionRepo: competitionRepo}
+}
+
+// ListPeriods lists the voting periods of an edition
+func (s *votingService) ListPeriods(ctx context.Context, editionID string) ([]models.VotingPeriod, error) {
+	if _, err := s.editionRepo.FindByID(ctx, editionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListPeriods(ctx, editionID)
+}
+
+// CreatePeriod adds a voting period to an edition that is not closed, for all its entries or for the nominees of one of its sections
+func (s *votingService) CreatePeriod(ctx context.Context, editionID string, req models.VotingPeriodRequest) (*models.VotingPeriod, error) {
+	if err := s.checkPeriod(ctx, editionID, &req); err != nil {
+		return nil, err
+	}
+
+	period := &models.VotingPeriod{
+		ID:          uuid.NewString(),
+		EditionID:   editionID,
+		SectionID:   req.SectionID,
+		OpensAt:     req.OpensAt,
+		ClosesAt:    req.ClosesAt,
+		BallotMode:  req.BallotMode,
+		MaxRankings: req.MaxRankings,
+		VoteBudget:  req.VoteBudget,
+	}
+	if err := s.repo.CreatePeriod(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindPeriod(ctx, period.ID)
+}
+
+// UpdatePeriod moves a voting period of an edition that is not closed, votes already cast in it are kept.
+// Its ballot mode can only change until the first ballot is cast.
+func (s *votingService) UpdatePeriod(ctx context.Context, periodID string, req models.VotingPeriodRequest) (*models.VotingPeriod, error) {
+	period, err := s.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPeriod(ctx, period.EditionID, &req); err != nil {
+		return nil, err
+	}
+
+	if req.BallotMode != period.BallotMode || req.MaxRankings != period.MaxRankings {
+		cast, err := s.repo.HasBallots(ctx, periodID)
+		if err != nil {
+			return nil, err
+		}
+		if cast {
+			return nil, ErrBallotModeLocked
+		}
+	}
+
+	period.SectionID = req.SectionID
+	period.OpensAt = req.OpensAt
+	period.ClosesAt = req.ClosesAt
+	period.BallotMode = req.BallotMode
+	period.MaxRankings = req.MaxRankings
+	period.VoteBudget = req.VoteBudget
+	if err := s.repo.UpdatePeriod(ctx, period); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVotingPeriodNotExists
+		}
+		return nil, err
+	}
+
+	return s.repo.FindPeriod(ctx, periodID)
+}
+
+// DeletePeriod deletes a voting period with its ranked ballots, approval votes already cast in it are kept
+func (s *votingService) DeletePeriod(ctx context.Context, periodID string) error {
+	if err := s.repo.DeletePeriod(ctx, periodID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVotingPeriodNotExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListUserPeriods lists the voting periods open now with the votes the user has left in each of them,
+// or the ballot they cast in the ranked ones
+func (s *votingService) ListUserPeriods(ctx context.Context, userID string) ([]models.UserVotingPeriod, error) {
+	periods, err := s.repo.ListOpenPeriods(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	userPeriods := make([]models.UserVotingPeriod, 0, len(periods))
+	for _, period := range periods {
+		if period.BallotMode == models.BallotModeRanked {
+			ballot, err := s.repo.FindBallot(ctx, period.ID, userID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			userPeriods = append(userPeriods, models.UserVotingPeriod{VotingPeriod: period, Ballot: ballot})
+			continue
+		}
+
+		used, err := s.repo.CountVotes(ctx, period.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		userPeriod := models.UserVotingPeriod{VotingPeriod: period, VotesUsed: used}
+		if period.VoteBudget != nil {
+			left := max(*period.VoteBudget-used, 0)
+			userPeriod.VotesLeft = &left
+		}
+		userPeriods = append(userPeriods, userPeriod)
+	}
+
+	return userPeriods, nil
+}
+
+// VoteMovie votes for a movie in one of the approval voting periods open for it.
+// A section period the movie is nominated in is used before a period of the whole edition,
+// and the vote goes to the next period when the user has no vote left in one of them.
+// A user votes for a movie once among the periods open for it, a later period takes a new vote.
+func (s *votingService) VoteMovie(ctx context.Context, userID, movieID string) error {
+	periods, err := s.repo.ListOpenPeriodsForMovie(ctx, movieID, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(periods) == 0 {
+		return ErrVotingClosed
+	}
+
+	if _, err := s.repo.FindVote(ctx, userID, movieID, periodIDs(periods)); err == nil {
+		return ErrAlreadyVoted
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	for i := range periods {
+		cast, err := s.repo.CastVote(ctx, userID, movieID, &periods[i])
+		if err != nil {
+			// A concurrent request cast the same vote first
+			if errors.Is(err, repositories.ErrVoteExists) {
+				return ErrAlreadyVoted
+			}
+			return err
+		}
+		if cast {
+			return nil
+		}
+	}
+
+	return ErrVoteBudgetExhausted
+}
+
+// UnvoteMovie takes back the vote cast in a voting period still open for the movie, which gives the vote back to the budget.
+// Votes cast before voting periods existed can always be taken back.
+func (s *votingService) UnvoteMovie(ctx context.Context, userID, movieID string) error {
+	periods, err := s.repo.ListOpenPeriodsForMovie(ctx, movieID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	vote, err := s.repo.FindVote(ctx, userID, movieID, periodIDs(periods))
+	if err == nil {
+		return s.movieRepo.DeleteVote(ctx, vote.ID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	existingVote, err := s.movieRepo.GetVoteByUserAndMovie(ctx, userID, movieID)
+	if err != nil {
+		return err
+	}
+	if existingVote.ID == "" {
+		return ErrNotVoted
+	}
+	if existingVote.VotingPeriodID != "" {
+		return ErrVotingClosed
+	}
+
+	return s.movieRepo.DeleteVote(ctx, existingVote.ID)
+}
+
+// GetBallot returns the ballot the user cast in a ranked voting period
+func (s *votingService) GetBallot(ctx context.Context, userID, periodID string) (*models.Ballot, error) {
+	if _, err := s.findPeriod(ctx, periodID); err != nil {
+		return nil, err
+	}
+
+	ballot, err := s.repo.FindBallot(ctx, periodID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBallotNotExists
+	}
+
+	return ballot, err
+}
+
+// CastBallot ranks up to max_rankings movies of an open ranked voting period in order of preference,
+// casting it again replaces the previous ballot of the user
+func (s *votingService) CastBallot(ctx context.Context, userID, periodID string, req models.BallotRequest) (*models.Ballot, error) {
+	period, err := s.findOpenRankedPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.MovieIDs) > period.MaxRankings {
+		return nil, ErrInvalidBallot
+	}
+	ranked := make(map[string]bool, len(req.MovieIDs))
+	for _, movieID := range req.MovieIDs {
+		if ranked[movieID] {
+			return nil, ErrInvalidBallot
+		}
+		ranked[movieID] = true
+	}
+
+	eligible, err := s.repo.CountEligibleMovies(ctx, periodID, req.MovieIDs)
+	if err != nil {
+		return nil, err
+	}
+	if eligible != len(req.MovieIDs) {
+		return nil, ErrMovieNotEligible
+	}
+
+	ballot := &models.Ballot{VotingPeriodID: periodID, UserID: userID}
+	for _, movieID := range req.MovieIDs {
+		ballot.Rankings = append(ballot.Rankings, models.BallotRanking{MovieID: movieID})
+	}
+	if err := s.repo.SaveBallot(ctx, ballot); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindBallot(ctx, periodID, userID)
+}
+
+// WithdrawBallot withdraws the ballot the user cast in a ranked voting period while it is open
+func (s *votingService) WithdrawBallot(ctx context.Context, userID, periodID string) error {
+	if _, err := s.findOpenRankedPeriod(ctx, periodID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteBallot(ctx, periodID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBallotNotExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// TallyPeriod tallies the ballots of a voting period round by round. Approval periods are tallied by their votes,
+// ranked periods by instant-runoff, the default, or by Borda count.
+func (s *votingService) TallyPeriod(ctx context.Context, periodID, method string) (*models.BallotTally, error) {
+	period, err := s.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if method == "" {
+		method = models.TallyMethodApproval
+		if period.BallotMode == models.BallotModeRanked {
+			method = models.TallyMethodInstantRunoff
+		}
+	}
+
+	tally := &models.BallotTally{VotingPeriodID: periodID, BallotMode: period.BallotMode, Method: method}
+	switch {
+	case period.BallotMode == models.BallotModeApproval && method == models.TallyMethodApproval:
+		results, err := s.repo.ListApprovals(ctx, periodID)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			tally.Ballots += result.Score
+		}
+		tally.Rounds, tally.Winners = singleRoundTally(results)
+	case period.BallotMode == models.BallotModeRanked &&
+		(method == models.TallyMethodInstantRunoff || method == models.TallyMethodBorda):
+		ballots, err := s.repo.ListBallots(ctx, periodID)
+		if err != nil {
+			return nil, err
+		}
+		tally.Ballots = len(ballots)
+		if method == models.TallyMethodBorda {
+			tally.Rounds, tally.Winners = tallyBorda(ballots, period.MaxRankings)
+		} else {
+			tally.Rounds, tally.Winners = tallyInstantRunoff(ballots)
+		}
+	default:
+		return nil, ErrInvalidTallyMethod
+	}
+
+	return tally, nil
+}
+
+// tallyInstantRunoff counts the first preferences of the ballots among the movies still in the running, round by round.
+// A movie wins with more than half of the ballots not exhausted, otherwise the movies with the fewest votes are eliminated
+// together and their ballots go to the next preference. The movies left tie when they all have the fewest votes.
+func tallyInstantRunoff(ballots []models.Ballot) ([]models.TallyRound, []models.TallyResult) {
+	titles := ballotTitles(ballots)
+	running := make(map[string]bool, len(titles))
+	for movieID := range titles {
+		running[movieID] = true
+	}
+
+	rounds := []models.TallyRound{}
+	for len(running) > 0 {
+		votes := make(map[string]int, len(running))
+		for movieID := range running {
+			votes[movieID] = 0
+		}
+
+		exhausted := 0
+		for _, ballot := range ballots {
+			counted := false
+			for _, ranking := range ballot.Rankings {
+				if running[ranking.MovieID] {
+					votes[ranking.MovieID]++
+					counted = true
+					break
+				}
+			}
+			if !counted {
+				exhausted++
+			}
+		}
+
+		round := models.TallyRound{Round: len(rounds) + 1, Results: sortTallyResults(votes, titles), Exhausted: exhausted}
+		top, fewest := round.Results[0].Score, round.Results[len(round.Results)-1].Score
+		if top*2 > len(ballots)-exhausted || top == fewest {
+			return append(rounds, round), leadingResults(round.Results)
+		}
+
+		for _, result := range round.Results {
+			if result.Score == fewest {
+				round.Eliminated = append(round.Eliminated, result.MovieID)
+				delete(running, result.MovieID)
+			}
+		}
+		rounds = append(rounds, round)
+	}
+
+	return rounds, []models.TallyResult{}
+}
+
+// tallyBorda gives each movie of a ballot max_rankings points for the first preference, one less for each next one
+func tallyBorda(ballots []models.Ballot, maxRankings int) ([]models.TallyRound, []models.TallyResult) {
+	points := make(map[string]int)
+	for _, ballot := range ballots {
+		// Rankings only hold the movies still eligible, so the preferences below a withdrawn movie move up
+		for i, ranking := range ballot.Rankings {
+			points[ranking.MovieID] += max(maxRankings-i, 0)
+		}
+	}
+
+	return singleRoundTally(sortTallyResults(points, ballotTitles(ballots)))
+}
+
+func singleRoundTally(results []models.TallyResult) ([]models.TallyRound, []models.TallyResult) {
+	if len(results) == 0 {
+		return []models.TallyRound{}, []models.TallyResult{}
+	}
+
+	return []models.TallyRound{{Round: 1, Results: results}}, leadingResults(results)
+}
+
+func ballotTitles(ballots []models.Ballot) map[string]string {
+	titles := make(map[string]string)
+	for _, ballot := range ballots {
+		for _, ranking := range ballot.Rankings {
+			titles[ranking.MovieID] = ranking.Title
+		}
+	}
+
+	return titles
+}
+
+// sortTallyResults orders the scores of the movies from the highest, then by title
+func sortTallyResults(scores map[string]int, titles map[string]string) []models.TallyResult {
+	results := make([]models.TallyResult, 0, len(scores))
+	for movieID, score := range scores {
+		results = append(results, models.TallyResult{MovieID: movieID, Title: titles[movieID], Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Title != results[j].Title {
+			return results[i].Title < results[j].Title
+		}
+		return results[i].MovieID < results[j].MovieID
+	})
+
+	return results
+}
+
+// leadingResults returns the results sharing the highest score of sorted results
+func leadingResults(results []models.TallyResult) []models.TallyResult {
+	leaders := []models.TallyResult{}
+	for _, result := range results {
+		if result.Score != results[0].Score {
+			break
+		}
+		leaders = append(leaders, result)
+	}
+
+	return leaders
+}
+
+// checkPeriod checks the edition of a voting period is not closed, its section belongs to the edition,
+// it opens before it closes and its ballot options fit its ballot mode, approval when none is given
+func (s *votingService) checkPeriod(ctx context.Context, editionID string, req *models.VotingPeriodRequest) error {
+	if !req.OpensAt.Before(req.ClosesAt) {
+		return ErrInvalidVotingPeriod
+	}
+
+	if req.BallotMode == "" {
+		req.BallotMode = models.BallotModeApproval
+	}
+	if req.BallotMode == models.BallotModeRanked && (req.MaxRankings == 0 || req.VoteBudget != nil) {
+		return ErrInvalidRankedPeriod
+	}
+	if req.BallotMode == models.BallotModeApproval && req.MaxRankings != 0 {
+		return ErrInvalidApprovalPeriod
+	}
+
+	edition, err := s.editionRepo.FindByID(ctx, editionID)
+	if err != nil {
+		return err
+	}
+	if edition.Status == models.EditionStatusClosed {
+		return ErrEditionClosed
+	}
+
+	if req.SectionID != "" {
+		section, err := s.competitionRepo.FindSection(ctx, req.SectionID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && section.EditionID != editionID) {
+			return ErrSectionNotExists
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *votingService) findPeriod(ctx context.Context, periodID string) (*models.VotingPeriod, error) {
+	period, err := s.repo.FindPeriod(ctx, periodID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVotingPeriodNotExists
+	}
+
+	return period, err
+}
+
+// findOpenRankedPeriod finds a ranked voting period that takes ballots now
+func (s *votingService) findOpenRankedPeriod(ctx context.Context, periodID string) (*models.VotingPeriod, error) {
+	period, err := s.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if period.BallotMode != models.BallotModeRanked {
+		return nil, ErrNotRankedPeriod
+	}
+
+	edition, err := s.editionRepo.FindByID(ctx, period.EditionID)
+	if err != nil {
+		return nil, err
+	}
+	if edition.Status != models.EditionStatusOpen || !period.Open(time.Now()) {
+		return nil, ErrVotingPeriodClosed
+	}
+
+	return period, nil
+}
+
+func periodIDs(periods []models.VotingPeriod) []string {
+	ids := make([]string, len(periods))
+	for i, period := range periods {
+		ids[i] = period.ID
+	}
+	return ids
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+const defaultWatchCompletionThreshold = 0.9
+
+type WatchProgressService interface {
+	SaveProgress(ctx context.Context, userID, movieID string, positionSeconds int) (*models.WatchProgress, error)
+	GetProgress(ctx context.Context, userID, movieID string) (*models.WatchProgress, error)
+	ContinueWatching(ctx context.Context, userID string, limit int) ([]models.WatchProgress, error)
+}
+
+type watchProgressService struct {
+	repo      repositories.WatchProgressRepository
+	movieRepo repositories.MovieRepository
+}
+
+func NewWatchProgressService(repo repositories.WatchProgressRepository, movieRepo repositories.MovieRepository) WatchProgressService {
+	return &watchProgressService{repo: repo, movieRepo: movieRepo}
+}
+
+// SaveProgress records the playback position of a user in a movie.
+// The movie counts as completed once the position passes WATCH_COMPLETION_THRESHOLD of its duration.
+func (s *watchProgressService) SaveProgress(ctx context.Context, userID, movieID string, positionSeconds int) (*models.WatchProgress, error) {
+	movie, err := s.movieRepo.FindMovieByID(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	durationSeconds := movie.Duration * 60
+	if positionSeconds > durationSeconds {
+		positionSeconds = durationSeconds
+	}
+
+	progress := &models.WatchProgress{
+		UserID:          userID,
+		MovieID:         movieID,
+		PositionSeconds: positionSeconds,
+		Completed:       float64(positionSeconds) >= watchCompletionThreshold()*float64(durationSeconds),
+	}
+	if err := s.repo.SaveProgress(ctx, progress); err != nil {
+		return nil, err
+	}
+
+	setWatchProgress(progress, durationSeconds)
+	return progress, nil
+}
+
+// GetProgress returns the position to resume a movie from, which is the start for a movie the user never played
+func (s *watchProgressService) GetProgress(ctx context.Context, userID, movieID string) (*models.WatchProgress, error) {
+	movie, err := s.movieRepo.FindMovieByID(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.repo.FindProgress(ctx, userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		progress = &models.WatchProgress{UserID: userID, MovieID: movieID}
+	}
+
+	setWatchProgress(progress, movie.Duration*60)
+	return progress, nil
+}
+
+// ContinueWatching lists the movies the user started and did not finish with their genres and artists,
+// most recently played first. Movies deleted by an admin are left out.
+func (s *watchProgressService) ContinueWatching(ctx context.Context, userID string, limit int) ([]models.WatchProgress, error) {
+	entries, err := s.repo.ListInProgress(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	movieIDs := make([]string, len(entries))
+	for i, entry := range entries {
+		movieIDs[i] = entry.MovieID
+	}
+
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, movieIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	moviesByID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		moviesByID[movie.ID] = movie
+	}
+
+	result := make([]models.WatchProgress, 0, len(entries))
+	for _, entry := range entries {
+		movie, ok := moviesByID[entry.MovieID]
+		if !ok {
+			continue
+		}
+		entry.Movie = &movie
+		setWatchProgress(&entry, movie.Duration*60)
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// setWatchProgress fills in the duration of the movie and the share of it that was watched
+func setWatchProgress(progress *models.WatchProgress, durationSeconds int) {
+	progress.DurationSeconds = durationSeconds
+	if durationSeconds > 0 {
+		progress.Progress = float64(progress.PositionSeconds) / float64(durationSeconds)
+	}
+}
+
+// watchCompletionThreshold reads WATCH_COMPLETION_THRESHOLD, the share of a movie that has to be watched to complete it
+func watchCompletionThreshold() float64 {
+	threshold, err := strconv.ParseFloat(os.Getenv("WATCH_COMPLETION_THRESHOLD"), 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		return defaultWatchCompletionThreshold
+	}
+	return threshold
+}
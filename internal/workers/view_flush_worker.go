@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/stwrtrio/movie-festival/internal/services"
+)
+
+const (
+	defaultViewFlushInterval = 10 * time.Second
+	finalFlushTimeout        = 10 * time.Second
+)
+
+// ViewFlushWorker periodically writes the view counts buffered in Redis to MySQL.
+type ViewFlushWorker struct {
+	service  services.MovieService
+	interval time.Duration
+}
+
+func NewViewFlushWorker(service services.MovieService) *ViewFlushWorker {
+	interval, err := time.ParseDuration(os.Getenv("VIEW_FLUSH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultViewFlushInterval
+	}
+
+	return &ViewFlushWorker{service: service, interval: interval}
+}
+
+// Start flushes on every tick until the context is cancelled, then flushes one last time.
+// The first flush runs right away to recover the counts left behind by a crash.
+func (w *ViewFlushWorker) Start(ctx context.Context) {
+	w.flush(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The application is shutting down, flush what is left with a fresh context
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			w.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			w.flush(ctx)
+		}
+	}
+}
+
+func (w *ViewFlushWorker) flush(ctx context.Context) {
+	if err := w.service.FlushViewCounts(ctx); err != nil {
+		log.Printf("Error flushing view counts: %v", err)
+	}
+}
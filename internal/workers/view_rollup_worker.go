@@ -0,0 +1,52 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/stwrtrio/movie-festival/internal/services"
+)
+
+const defaultViewRollupInterval = 5 * time.Minute
+
+// ViewRollupWorker periodically aggregates raw view events into the hourly and daily statistics tables.
+type ViewRollupWorker struct {
+	service  services.MovieService
+	interval time.Duration
+}
+
+func NewViewRollupWorker(service services.MovieService) *ViewRollupWorker {
+	interval, err := time.ParseDuration(os.Getenv("VIEW_ROLLUP_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultViewRollupInterval
+	}
+
+	return &ViewRollupWorker{service: service, interval: interval}
+}
+
+// Start runs the rollup on every tick until the context is cancelled.
+func (w *ViewRollupWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.run(ctx)
+		}
+	}
+}
+
+func (w *ViewRollupWorker) run(ctx context.Context) {
+	// Recompute from the start of the previous day so late events still land in their daily bucket
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -1)
+
+	if err := w.service.RollupViewStats(ctx, since); err != nil {
+		log.Printf("Error rolling up view statistics: %v", err)
+	}
+}
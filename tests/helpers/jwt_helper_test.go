@@ -0,0 +1,193 @@
+package helpers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+)
+
+// writeKeys stores an RSA and an Ed25519 private key and the public half of a retired Ed25519 key in a key directory
+func writeKeys(t *testing.T) (string, *rsa.PrivateKey) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "rsa-1.pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ed-1.pem"), "PRIVATE KEY", der)
+
+	retiredKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(retiredKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ed-0.pem"), "PUBLIC KEY", der)
+
+	return dir, rsaKey
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	require.NoError(t, err)
+}
+
+func TestAsymmetricJWT(t *testing.T) {
+	dir, _ := writeKeys(t)
+	t.Setenv("JWT_KEYS_DIR", dir)
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	for _, kid := range []string{"rsa-1", "ed-1"} {
+		t.Run(kid, func(t *testing.T) {
+			t.Setenv("JWT_SIGNING_KEY_ID", kid)
+
+			token, err := helpers.GenerateJWTToken("user1", "user123", "user", "session1")
+			require.NoError(t, err)
+
+			claims, err := helpers.ValidateJWTToken(token)
+			assert.NoError(t, err)
+			if assert.NotNil(t, claims) {
+				assert.Equal(t, "user1", claims.UserID)
+				assert.Equal(t, "session1", claims.SessionID)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &helpers.Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, kid, parsed.Header["kid"])
+		})
+	}
+
+	// Tokens signed with the previous key keep working after the rotation
+	t.Setenv("JWT_SIGNING_KEY_ID", "rsa-1")
+	token, err := helpers.GenerateJWTToken("user1", "user123", "user", "")
+	require.NoError(t, err)
+
+	t.Setenv("JWT_SIGNING_KEY_ID", "ed-1")
+	_, err = helpers.ValidateJWTToken(token)
+	assert.NoError(t, err)
+
+	jwks, err := helpers.PublicJWKS()
+	require.NoError(t, err)
+	kids := []string{}
+	for _, key := range jwks.Keys {
+		kids = append(kids, key.KeyID)
+	}
+	assert.Equal(t, []string{"ed-0", "ed-1", "rsa-1"}, kids)
+}
+
+func TestValidateJWTTokenRejectsAlgorithmConfusion(t *testing.T) {
+	dir, rsaKey := writeKeys(t)
+	t.Setenv("JWT_KEYS_DIR", dir)
+	t.Setenv("JWT_SIGNING_KEY_ID", "rsa-1")
+	t.Setenv("JWT_SECRET", "legacy-secret")
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	claims := helpers.Claims{
+		UserID: "user1",
+		Role:   "admin",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)})
+
+	testCases := []struct {
+		name  string
+		token func() (string, error)
+	}{
+		{
+			name: "HS256 signed with the RSA public key",
+			token: func() (string, error) {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				token.Header["kid"] = "rsa-1"
+				return token.SignedString(publicPEM)
+			},
+		},
+		{
+			name: "Unsigned token",
+			token: func() (string, error) {
+				return jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+			},
+		},
+		{
+			name: "RS256 without kid",
+			token: func() (string, error) {
+				return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(rsaKey)
+			},
+		},
+		{
+			name: "Unknown kid",
+			token: func() (string, error) {
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+				token.Header["kid"] = "rsa-2"
+				return token.SignedString(rsaKey)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := tc.token()
+			require.NoError(t, err)
+
+			_, err = helpers.ValidateJWTToken(token)
+			assert.Error(t, err)
+		})
+	}
+
+	// Tokens of the legacy secret stay valid while JWT_SECRET is set
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("legacy-secret"))
+	require.NoError(t, err)
+	_, err = helpers.ValidateJWTToken(legacy)
+	assert.NoError(t, err)
+}
+
+func TestInitJWTKeysRequiresSigningKey(t *testing.T) {
+	dir, _ := writeKeys(t)
+	t.Setenv("JWT_KEYS_DIR", dir)
+	t.Setenv("JWT_SECRET", "")
+
+	t.Setenv("JWT_SIGNING_KEY_ID", "")
+	assert.Error(t, helpers.InitJWTKeys())
+
+	// A public key cannot sign
+	t.Setenv("JWT_SIGNING_KEY_ID", "ed-0")
+	assert.Error(t, helpers.InitJWTKeys())
+
+	t.Setenv("JWT_SIGNING_KEY_ID", "ed-1")
+	assert.NoError(t, helpers.InitJWTKeys())
+}
+
+func TestInitJWTKeysRejectsEmptyKeyID(t *testing.T) {
+	dir, _ := writeKeys(t)
+	t.Setenv("JWT_KEYS_DIR", dir)
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("JWT_SIGNING_KEY_ID", "")
+	require.NoError(t, helpers.InitJWTKeys())
+
+	// A file named .pem would take the kid of the JWT_SECRET key
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, ".pem"), "PUBLIC KEY", der)
+
+	// The key settings did not change, use another signing key to reload the keys
+	t.Setenv("JWT_SIGNING_KEY_ID", "ed-1")
+	assert.ErrorContains(t, helpers.InitJWTKeys(), "cannot be empty")
+}
@@ -0,0 +1,79 @@
+package helpers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+)
+
+// The SHA-1 test secret of RFC 6238, "12345678901234567890" base32 encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// The last six digits of the RFC 6238 test vectors
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := helpers.TOTPCode(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "code at %d", unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, err := helpers.TOTPCode(rfcSecret, now)
+	require.NoError(t, err)
+
+	step, ok := helpers.ValidateTOTP(rfcSecret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234567890/30), step)
+
+	// One period of clock drift is accepted
+	_, ok = helpers.ValidateTOTP(rfcSecret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+
+	_, ok = helpers.ValidateTOTP(rfcSecret, code, now.Add(2*time.Minute))
+	assert.False(t, ok)
+
+	_, ok = helpers.ValidateTOTP(rfcSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := helpers.GenerateTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := helpers.TOTPURI("Movie Festival", "user123", secret)
+	assert.Contains(t, uri, "otpauth://totp/Movie%20Festival:user123?")
+	assert.Contains(t, uri, "secret="+secret)
+
+	code, err := helpers.TOTPCode(secret, time.Now())
+	require.NoError(t, err)
+	_, ok := helpers.ValidateTOTP(secret, code, time.Now())
+	assert.True(t, ok)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := helpers.GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	for _, code := range codes {
+		assert.Len(t, code, 9)
+		assert.Equal(t, code, helpers.NormalizeRecoveryCode(code))
+	}
+
+	assert.Equal(t, "abcd-efgh", helpers.NormalizeRecoveryCode(" ABCDEFGH "))
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/artist_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockArtistRepository is a mock of ArtistRepository interface.
+type MockArtistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistRepositoryMockRecorder
+}
+
+// MockArtistRepositoryMockRecorder is the mock recorder for MockArtistRepository.
+type MockArtistRepositoryMockRecorder struct {
+	mock *MockArtistRepository
+}
+
+// NewMockArtistRepository creates a new mock instance.
+func NewMockArtistRepository(ctrl *gomock.Controller) *MockArtistRepository {
+	mock := &MockArtistRepository{ctrl: ctrl}
+	mock.recorder = &MockArtistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistRepository) EXPECT() *MockArtistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArtistRepository) Create(ctx context.Context, artist *models.ArtistProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArtistRepositoryMockRecorder) Create(ctx, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArtistRepository)(nil).Create), ctx, artist)
+}
+
+// Delete mocks base method.
+func (m *MockArtistRepository) Delete(ctx context.Context, artistID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, artistID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArtistRepositoryMockRecorder) Delete(ctx, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArtistRepository)(nil).Delete), ctx, artistID)
+}
+
+// FindByID mocks base method.
+func (m *MockArtistRepository) FindByID(ctx context.Context, artistID string) (*models.ArtistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, artistID)
+	ret0, _ := ret[0].(*models.ArtistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockArtistRepositoryMockRecorder) FindByID(ctx, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockArtistRepository)(nil).FindByID), ctx, artistID)
+}
+
+// FindByName mocks base method.
+func (m *MockArtistRepository) FindByName(ctx context.Context, name string) (*models.ArtistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*models.ArtistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockArtistRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockArtistRepository)(nil).FindByName), ctx, name)
+}
+
+// GetFilmography mocks base method.
+func (m *MockArtistRepository) GetFilmography(ctx context.Context, artistID string) ([]models.FilmographyItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmography", ctx, artistID)
+	ret0, _ := ret[0].([]models.FilmographyItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmography indicates an expected call of GetFilmography.
+func (mr *MockArtistRepositoryMockRecorder) GetFilmography(ctx, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmography", reflect.TypeOf((*MockArtistRepository)(nil).GetFilmography), ctx, artistID)
+}
+
+// List mocks base method.
+func (m *MockArtistRepository) List(ctx context.Context, query string, limit, offset int) ([]models.ArtistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, query, limit, offset)
+	ret0, _ := ret[0].([]models.ArtistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArtistRepositoryMockRecorder) List(ctx, query, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArtistRepository)(nil).List), ctx, query, limit, offset)
+}
+
+// Merge mocks base method.
+func (m *MockArtistRepository) Merge(ctx context.Context, targetID string, sourceIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, targetID, sourceIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockArtistRepositoryMockRecorder) Merge(ctx, targetID, sourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockArtistRepository)(nil).Merge), ctx, targetID, sourceIDs)
+}
+
+// Update mocks base method.
+func (m *MockArtistRepository) Update(ctx context.Context, artist *models.ArtistProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, artist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArtistRepositoryMockRecorder) Update(ctx, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArtistRepository)(nil).Update), ctx, artist)
+}
+
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
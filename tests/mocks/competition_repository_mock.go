@@ -0,0 +1,301 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/competition_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockCompetitionRepository is a mock of CompetitionRepository interface.
+type MockCompetitionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompetitionRepositoryMockRecorder
+}
+
+// MockCompetitionRepositoryMockRecorder is the mock recorder for MockCompetitionRepository.
+type MockCompetitionRepositoryMockRecorder struct {
+	mock *MockCompetitionRepository
+}
+
+// NewMockCompetitionRepository creates a new mock instance.
+func NewMockCompetitionRepository(ctrl *gomock.Controller) *MockCompetitionRepository {
+	mock := &MockCompetitionRepository{ctrl: ctrl}
+	mock.recorder = &MockCompetitionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompetitionRepository) EXPECT() *MockCompetitionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddWinner mocks base method.
+func (m *MockCompetitionRepository) AddWinner(ctx context.Context, categoryID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWinner", ctx, categoryID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWinner indicates an expected call of AddWinner.
+func (mr *MockCompetitionRepositoryMockRecorder) AddWinner(ctx, categoryID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWinner", reflect.TypeOf((*MockCompetitionRepository)(nil).AddWinner), ctx, categoryID, movieID)
+}
+
+// CreateCategory mocks base method.
+func (m *MockCompetitionRepository) CreateCategory(ctx context.Context, category *models.AwardCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCompetitionRepositoryMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCompetitionRepository)(nil).CreateCategory), ctx, category)
+}
+
+// CreateSection mocks base method.
+func (m *MockCompetitionRepository) CreateSection(ctx context.Context, section *models.Section) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSection", ctx, section)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSection indicates an expected call of CreateSection.
+func (mr *MockCompetitionRepositoryMockRecorder) CreateSection(ctx, section interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSection", reflect.TypeOf((*MockCompetitionRepository)(nil).CreateSection), ctx, section)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCompetitionRepository) DeleteCategory(ctx context.Context, categoryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCompetitionRepositoryMockRecorder) DeleteCategory(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCompetitionRepository)(nil).DeleteCategory), ctx, categoryID)
+}
+
+// DeleteSection mocks base method.
+func (m *MockCompetitionRepository) DeleteSection(ctx context.Context, sectionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSection", ctx, sectionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSection indicates an expected call of DeleteSection.
+func (mr *MockCompetitionRepositoryMockRecorder) DeleteSection(ctx, sectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSection", reflect.TypeOf((*MockCompetitionRepository)(nil).DeleteSection), ctx, sectionID)
+}
+
+// FindCategory mocks base method.
+func (m *MockCompetitionRepository) FindCategory(ctx context.Context, categoryID string) (*models.AwardCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCategory", ctx, categoryID)
+	ret0, _ := ret[0].(*models.AwardCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCategory indicates an expected call of FindCategory.
+func (mr *MockCompetitionRepositoryMockRecorder) FindCategory(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCategory", reflect.TypeOf((*MockCompetitionRepository)(nil).FindCategory), ctx, categoryID)
+}
+
+// FindCategoryByName mocks base method.
+func (m *MockCompetitionRepository) FindCategoryByName(ctx context.Context, sectionID, name string) (*models.AwardCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCategoryByName", ctx, sectionID, name)
+	ret0, _ := ret[0].(*models.AwardCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCategoryByName indicates an expected call of FindCategoryByName.
+func (mr *MockCompetitionRepositoryMockRecorder) FindCategoryByName(ctx, sectionID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCategoryByName", reflect.TypeOf((*MockCompetitionRepository)(nil).FindCategoryByName), ctx, sectionID, name)
+}
+
+// FindSection mocks base method.
+func (m *MockCompetitionRepository) FindSection(ctx context.Context, sectionID string) (*models.Section, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSection", ctx, sectionID)
+	ret0, _ := ret[0].(*models.Section)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSection indicates an expected call of FindSection.
+func (mr *MockCompetitionRepositoryMockRecorder) FindSection(ctx, sectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSection", reflect.TypeOf((*MockCompetitionRepository)(nil).FindSection), ctx, sectionID)
+}
+
+// FindSectionByName mocks base method.
+func (m *MockCompetitionRepository) FindSectionByName(ctx context.Context, editionID, name string) (*models.Section, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSectionByName", ctx, editionID, name)
+	ret0, _ := ret[0].(*models.Section)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSectionByName indicates an expected call of FindSectionByName.
+func (mr *MockCompetitionRepositoryMockRecorder) FindSectionByName(ctx, editionID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSectionByName", reflect.TypeOf((*MockCompetitionRepository)(nil).FindSectionByName), ctx, editionID, name)
+}
+
+// ListCategories mocks base method.
+func (m *MockCompetitionRepository) ListCategories(ctx context.Context, sectionID string) ([]models.AwardCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx, sectionID)
+	ret0, _ := ret[0].([]models.AwardCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCompetitionRepositoryMockRecorder) ListCategories(ctx, sectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCompetitionRepository)(nil).ListCategories), ctx, sectionID)
+}
+
+// ListNominees mocks base method.
+func (m *MockCompetitionRepository) ListNominees(ctx context.Context, sectionID string) ([]models.Nominee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNominees", ctx, sectionID)
+	ret0, _ := ret[0].([]models.Nominee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNominees indicates an expected call of ListNominees.
+func (mr *MockCompetitionRepositoryMockRecorder) ListNominees(ctx, sectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNominees", reflect.TypeOf((*MockCompetitionRepository)(nil).ListNominees), ctx, sectionID)
+}
+
+// ListSections mocks base method.
+func (m *MockCompetitionRepository) ListSections(ctx context.Context, editionID string) ([]models.Section, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSections", ctx, editionID)
+	ret0, _ := ret[0].([]models.Section)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSections indicates an expected call of ListSections.
+func (mr *MockCompetitionRepositoryMockRecorder) ListSections(ctx, editionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSections", reflect.TypeOf((*MockCompetitionRepository)(nil).ListSections), ctx, editionID)
+}
+
+// ListWinners mocks base method.
+func (m *MockCompetitionRepository) ListWinners(ctx context.Context, editionID string, statuses ...string) ([]models.AwardWinner, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, editionID}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListWinners", varargs...)
+	ret0, _ := ret[0].([]models.AwardWinner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWinners indicates an expected call of ListWinners.
+func (mr *MockCompetitionRepositoryMockRecorder) ListWinners(ctx, editionID interface{}, statuses ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, editionID}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWinners", reflect.TypeOf((*MockCompetitionRepository)(nil).ListWinners), varargs...)
+}
+
+// NominateMovies mocks base method.
+func (m *MockCompetitionRepository) NominateMovies(ctx context.Context, sectionID string, movieIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NominateMovies", ctx, sectionID, movieIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NominateMovies indicates an expected call of NominateMovies.
+func (mr *MockCompetitionRepositoryMockRecorder) NominateMovies(ctx, sectionID, movieIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NominateMovies", reflect.TypeOf((*MockCompetitionRepository)(nil).NominateMovies), ctx, sectionID, movieIDs)
+}
+
+// RemoveNomination mocks base method.
+func (m *MockCompetitionRepository) RemoveNomination(ctx context.Context, sectionID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveNomination", ctx, sectionID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNomination indicates an expected call of RemoveNomination.
+func (mr *MockCompetitionRepositoryMockRecorder) RemoveNomination(ctx, sectionID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNomination", reflect.TypeOf((*MockCompetitionRepository)(nil).RemoveNomination), ctx, sectionID, movieID)
+}
+
+// RemoveWinner mocks base method.
+func (m *MockCompetitionRepository) RemoveWinner(ctx context.Context, categoryID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWinner", ctx, categoryID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWinner indicates an expected call of RemoveWinner.
+func (mr *MockCompetitionRepositoryMockRecorder) RemoveWinner(ctx, categoryID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWinner", reflect.TypeOf((*MockCompetitionRepository)(nil).RemoveWinner), ctx, categoryID, movieID)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCompetitionRepository) UpdateCategory(ctx context.Context, category *models.AwardCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCompetitionRepositoryMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCompetitionRepository)(nil).UpdateCategory), ctx, category)
+}
+
+// UpdateSection mocks base method.
+func (m *MockCompetitionRepository) UpdateSection(ctx context.Context, section *models.Section) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSection", ctx, section)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSection indicates an expected call of UpdateSection.
+func (mr *MockCompetitionRepositoryMockRecorder) UpdateSection(ctx, section interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSection", reflect.TypeOf((*MockCompetitionRepository)(nil).UpdateSection), ctx, section)
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/edition_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockEditionRepository is a mock of EditionRepository interface.
+type MockEditionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEditionRepositoryMockRecorder
+}
+
+// MockEditionRepositoryMockRecorder is the mock recorder for MockEditionRepository.
+type MockEditionRepositoryMockRecorder struct {
+	mock *MockEditionRepository
+}
+
+// NewMockEditionRepository creates a new mock instance.
+func NewMockEditionRepository(ctrl *gomock.Controller) *MockEditionRepository {
+	mock := &MockEditionRepository{ctrl: ctrl}
+	mock.recorder = &MockEditionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEditionRepository) EXPECT() *MockEditionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEditionRepository) Create(ctx context.Context, edition *models.Edition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, edition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEditionRepositoryMockRecorder) Create(ctx, edition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEditionRepository)(nil).Create), ctx, edition)
+}
+
+// EnterMovies mocks base method.
+func (m *MockEditionRepository) EnterMovies(ctx context.Context, editionID string, movieIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnterMovies", ctx, editionID, movieIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnterMovies indicates an expected call of EnterMovies.
+func (mr *MockEditionRepositoryMockRecorder) EnterMovies(ctx, editionID, movieIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnterMovies", reflect.TypeOf((*MockEditionRepository)(nil).EnterMovies), ctx, editionID, movieIDs)
+}
+
+// FindByID mocks base method.
+func (m *MockEditionRepository) FindByID(ctx context.Context, editionID string) (*models.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, editionID)
+	ret0, _ := ret[0].(*models.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockEditionRepositoryMockRecorder) FindByID(ctx, editionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEditionRepository)(nil).FindByID), ctx, editionID)
+}
+
+// FindByName mocks base method.
+func (m *MockEditionRepository) FindByName(ctx context.Context, name string) (*models.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*models.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockEditionRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockEditionRepository)(nil).FindByName), ctx, name)
+}
+
+// List mocks base method.
+func (m *MockEditionRepository) List(ctx context.Context, statuses ...string) ([]models.Edition, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range statuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]models.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEditionRepositoryMockRecorder) List(ctx interface{}, statuses ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, statuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEditionRepository)(nil).List), varargs...)
+}
+
+// ListEntries mocks base method.
+func (m *MockEditionRepository) ListEntries(ctx context.Context, editionID string) ([]models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, editionID)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockEditionRepositoryMockRecorder) ListEntries(ctx, editionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockEditionRepository)(nil).ListEntries), ctx, editionID)
+}
+
+// SetStatus mocks base method.
+func (m *MockEditionRepository) SetStatus(ctx context.Context, editionID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, editionID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockEditionRepositoryMockRecorder) SetStatus(ctx, editionID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockEditionRepository)(nil).SetStatus), ctx, editionID, status)
+}
+
+// Update mocks base method.
+func (m *MockEditionRepository) Update(ctx context.Context, edition *models.Edition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, edition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEditionRepositoryMockRecorder) Update(ctx, edition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEditionRepository)(nil).Update), ctx, edition)
+}
+
+// WithdrawMovie mocks base method.
+func (m *MockEditionRepository) WithdrawMovie(ctx context.Context, editionID, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawMovie", ctx, editionID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithdrawMovie indicates an expected call of WithdrawMovie.
+func (mr *MockEditionRepositoryMockRecorder) WithdrawMovie(ctx, editionID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawMovie", reflect.TypeOf((*MockEditionRepository)(nil).WithdrawMovie), ctx, editionID, movieID)
+}
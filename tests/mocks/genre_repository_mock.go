@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/genre_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockGenreRepository is a mock of GenreRepository interface.
+type MockGenreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreRepositoryMockRecorder
+}
+
+// MockGenreRepositoryMockRecorder is the mock recorder for MockGenreRepository.
+type MockGenreRepositoryMockRecorder struct {
+	mock *MockGenreRepository
+}
+
+// NewMockGenreRepository creates a new mock instance.
+func NewMockGenreRepository(ctrl *gomock.Controller) *MockGenreRepository {
+	mock := &MockGenreRepository{ctrl: ctrl}
+	mock.recorder = &MockGenreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreRepository) EXPECT() *MockGenreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountChildren mocks base method.
+func (m *MockGenreRepository) CountChildren(ctx context.Context, genreID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", ctx, genreID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockGenreRepositoryMockRecorder) CountChildren(ctx, genreID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockGenreRepository)(nil).CountChildren), ctx, genreID)
+}
+
+// Create mocks base method.
+func (m *MockGenreRepository) Create(ctx context.Context, genre *models.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGenreRepositoryMockRecorder) Create(ctx, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGenreRepository)(nil).Create), ctx, genre)
+}
+
+// Delete mocks base method.
+func (m *MockGenreRepository) Delete(ctx context.Context, genreID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGenreRepositoryMockRecorder) Delete(ctx, genreID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreRepository)(nil).Delete), ctx, genreID)
+}
+
+// FindByID mocks base method.
+func (m *MockGenreRepository) FindByID(ctx context.Context, genreID int64) (*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, genreID)
+	ret0, _ := ret[0].(*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockGenreRepositoryMockRecorder) FindByID(ctx, genreID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGenreRepository)(nil).FindByID), ctx, genreID)
+}
+
+// FindByName mocks base method.
+func (m *MockGenreRepository) FindByName(ctx context.Context, name string) (*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockGenreRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockGenreRepository)(nil).FindByName), ctx, name)
+}
+
+// List mocks base method.
+func (m *MockGenreRepository) List(ctx context.Context) ([]models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGenreRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGenreRepository)(nil).List), ctx)
+}
+
+// Merge mocks base method.
+func (m *MockGenreRepository) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, targetID, sourceIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockGenreRepositoryMockRecorder) Merge(ctx, targetID, sourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockGenreRepository)(nil).Merge), ctx, targetID, sourceIDs)
+}
+
+// Update mocks base method.
+func (m *MockGenreRepository) Update(ctx context.Context, genre *models.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreRepositoryMockRecorder) Update(ctx, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreRepository)(nil).Update), ctx, genre)
+}
@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/identity_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockIdentityRepository is a mock of IdentityRepository interface.
+type MockIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityRepositoryMockRecorder
+}
+
+// MockIdentityRepositoryMockRecorder is the mock recorder for MockIdentityRepository.
+type MockIdentityRepositoryMockRecorder struct {
+	mock *MockIdentityRepository
+}
+
+// NewMockIdentityRepository creates a new mock instance.
+func NewMockIdentityRepository(ctrl *gomock.Controller) *MockIdentityRepository {
+	mock := &MockIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityRepository) EXPECT() *MockIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUserWithIdentity mocks base method.
+func (m *MockIdentityRepository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserWithIdentity", ctx, user, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserWithIdentity indicates an expected call of CreateUserWithIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) CreateUserWithIdentity(ctx, user, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWithIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).CreateUserWithIdentity), ctx, user, identity)
+}
+
+// FindIdentity mocks base method.
+func (m *MockIdentityRepository) FindIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindIdentity indicates an expected call of FindIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) FindIdentity(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).FindIdentity), ctx, provider, subject)
+}
+
+// LinkIdentity mocks base method.
+func (m *MockIdentityRepository) LinkIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) LinkIdentity(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).LinkIdentity), ctx, identity)
+}
+
+// ListIdentities mocks base method.
+func (m *MockIdentityRepository) ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentities", ctx, userID)
+	ret0, _ := ret[0].([]models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIdentities indicates an expected call of ListIdentities.
+func (mr *MockIdentityRepositoryMockRecorder) ListIdentities(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentities", reflect.TypeOf((*MockIdentityRepository)(nil).ListIdentities), ctx, userID)
+}
+
+// TouchIdentity mocks base method.
+func (m *MockIdentityRepository) TouchIdentity(ctx context.Context, identityID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchIdentity", ctx, identityID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchIdentity indicates an expected call of TouchIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) TouchIdentity(ctx, identityID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).TouchIdentity), ctx, identityID, email)
+}
+
+// Mockexecer is a mock of execer interface.
+type Mockexecer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexecerMockRecorder
+}
+
+// MockexecerMockRecorder is the mock recorder for Mockexecer.
+type MockexecerMockRecorder struct {
+	mock *Mockexecer
+}
+
+// NewMockexecer creates a new mock instance.
+func NewMockexecer(ctrl *gomock.Controller) *Mockexecer {
+	mock := &Mockexecer{ctrl: ctrl}
+	mock.recorder = &MockexecerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockexecer) EXPECT() *MockexecerMockRecorder {
+	return m.recorder
+}
+
+// ExecContext mocks base method.
+func (m *Mockexecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockexecerMockRecorder) ExecContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*Mockexecer)(nil).ExecContext), varargs...)
+}
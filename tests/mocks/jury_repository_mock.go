@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/jury_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockJuryRepository is a mock of JuryRepository interface.
+type MockJuryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJuryRepositoryMockRecorder
+}
+
+// MockJuryRepositoryMockRecorder is the mock recorder for MockJuryRepository.
+type MockJuryRepositoryMockRecorder struct {
+	mock *MockJuryRepository
+}
+
+// NewMockJuryRepository creates a new mock instance.
+func NewMockJuryRepository(ctrl *gomock.Controller) *MockJuryRepository {
+	mock := &MockJuryRepository{ctrl: ctrl}
+	mock.recorder = &MockJuryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJuryRepository) EXPECT() *MockJuryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignJuror mocks base method.
+func (m *MockJuryRepository) AssignJuror(ctx context.Context, sectionID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignJuror", ctx, sectionID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignJuror indicates an expected call of AssignJuror.
+func (mr *MockJuryRepositoryMockRecorder) AssignJuror(ctx, sectionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignJuror", reflect.TypeOf((*MockJuryRepository)(nil).AssignJuror), ctx, sectionID, userID)
+}
+
+// CreateCriterion mocks base method.
+func (m *MockJuryRepository) CreateCriterion(ctx context.Context, criterion *models.ScoringCriterion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCriterion", ctx, criterion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCriterion indicates an expected call of CreateCriterion.
+func (mr *MockJuryRepositoryMockRecorder) CreateCriterion(ctx, criterion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCriterion", reflect.TypeOf((*MockJuryRepository)(nil).CreateCriterion), ctx, criterion)
+}
+
+// DeleteCriterion mocks base method.
+func (m *MockJuryRepository) DeleteCriterion(ctx context.Context, criterionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCriterion", ctx, criterionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCriterion indicates an expected call of DeleteCriterion.
+func (mr *MockJuryRepositoryMockRecorder) DeleteCriterion(ctx, criterionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCriterion", reflect.TypeOf((*MockJuryRepository)(nil).DeleteCriterion), ctx, criterionID)
+}
+
+// FindCriterion mocks base method.
+func (m *MockJuryRepository) FindCriterion(ctx context.Context, criterionID string) (*models.ScoringCriterion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCriterion", ctx, criterionID)
+	ret0, _ := ret[0].(*models.ScoringCriterion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCriterion indicates an expected call of FindCriterion.
+func (mr *MockJuryRepositoryMockRecorder) FindCriterion(ctx, criterionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCriterion", reflect.TypeOf((*MockJuryRepository)(nil).FindCriterion), ctx, criterionID)
+}
+
+// FindCriterionByName mocks base method.
+func (m *MockJuryRepository) FindCriterionByName(ctx context.Context, sectionID, name string) (*models.ScoringCriterion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCriterionByName", ctx, sectionID, name)
+	ret0, _ := ret[0].(*models.ScoringCriterion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCriterionByName indicates an expected call of FindCriterionByName.
+func (mr *MockJuryRepositoryMockRecorder) FindCriterionByName(ctx, sectionID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCriterionByName", reflect.TypeOf((*MockJuryRepository)(nil).FindCriterionByName), ctx, sectionID, name)
+}
+
+// IsJuror mocks base method.
+func (m *MockJuryRepository) IsJuror(ctx context.Context, sectionID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsJuror", ctx, sectionID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsJuror indicates an expected call of IsJuror.
+func (mr *MockJuryRepositoryMockRecorder) IsJuror(ctx, sectionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsJuror", reflect.TypeOf((*MockJuryRepository)(nil).IsJuror), ctx, sectionID, userID)
+}
+
+// ListCriteria mocks base method.
+func (m *MockJuryRepository) ListCriteria(ctx context.Context, sectionID string) ([]models.ScoringCriterion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCriteria", ctx, sectionID)
+	ret0, _ := ret[0].([]models.ScoringCriterion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCriteria indicates an expected call of ListCriteria.
+func (mr *MockJuryRepositoryMockRecorder) ListCriteria(ctx, sectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCriteria", reflect.TypeOf((*MockJuryRepository)(nil).ListCriteria), ctx, sectionID)
+}
+
+// ListJurorSections mocks base method.
+func (m *MockJuryRepository) ListJurorSections(ctx context.Context, userID string) ([]models.Section, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJurorSections", ctx, userID)
+	ret0, _ := ret[0].([]models.Section)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJurorSections indicates an expected call of ListJurorSections.
+func (mr *MockJuryRepositoryMockRecorder) ListJurorSections(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJurorSections", reflect.TypeOf((*MockJuryRepository)(nil).ListJurorSections), ctx, userID)
+}
+
+// ListJurors mocks base method.
+func (m *MockJuryRepository) ListJurors(ctx context.Context, sectionID string) ([]models.Juror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJurors", ctx, sectionID)
+	ret0, _ := ret[0].([]models.Juror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJurors indicates an expected call of ListJurors.
+func (mr *MockJuryRepositoryMockRecorder) ListJurors(ctx, sectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJurors", reflect.TypeOf((*MockJuryRepository)(nil).ListJurors), ctx, sectionID)
+}
+
+// ListScores mocks base method.
+func (m *MockJuryRepository) ListScores(ctx context.Context, sectionID, userID string) ([]models.JuryScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScores", ctx, sectionID, userID)
+	ret0, _ := ret[0].([]models.JuryScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScores indicates an expected call of ListScores.
+func (mr *MockJuryRepositoryMockRecorder) ListScores(ctx, sectionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScores", reflect.TypeOf((*MockJuryRepository)(nil).ListScores), ctx, sectionID, userID)
+}
+
+// RemoveJuror mocks base method.
+func (m *MockJuryRepository) RemoveJuror(ctx context.Context, sectionID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveJuror", ctx, sectionID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveJuror indicates an expected call of RemoveJuror.
+func (mr *MockJuryRepositoryMockRecorder) RemoveJuror(ctx, sectionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveJuror", reflect.TypeOf((*MockJuryRepository)(nil).RemoveJuror), ctx, sectionID, userID)
+}
+
+// SaveScores mocks base method.
+func (m *MockJuryRepository) SaveScores(ctx context.Context, userID, movieID string, scores []models.CriterionScore) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveScores", ctx, userID, movieID, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveScores indicates an expected call of SaveScores.
+func (mr *MockJuryRepositoryMockRecorder) SaveScores(ctx, userID, movieID, scores interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveScores", reflect.TypeOf((*MockJuryRepository)(nil).SaveScores), ctx, userID, movieID, scores)
+}
+
+// SetScoringClosed mocks base method.
+func (m *MockJuryRepository) SetScoringClosed(ctx context.Context, sectionID string, closed bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScoringClosed", ctx, sectionID, closed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScoringClosed indicates an expected call of SetScoringClosed.
+func (mr *MockJuryRepositoryMockRecorder) SetScoringClosed(ctx, sectionID, closed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScoringClosed", reflect.TypeOf((*MockJuryRepository)(nil).SetScoringClosed), ctx, sectionID, closed)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/lockout_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockLockoutRepository is a mock of LockoutRepository interface.
+type MockLockoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutRepositoryMockRecorder
+}
+
+// MockLockoutRepositoryMockRecorder is the mock recorder for MockLockoutRepository.
+type MockLockoutRepositoryMockRecorder struct {
+	mock *MockLockoutRepository
+}
+
+// NewMockLockoutRepository creates a new mock instance.
+func NewMockLockoutRepository(ctrl *gomock.Controller) *MockLockoutRepository {
+	mock := &MockLockoutRepository{ctrl: ctrl}
+	mock.recorder = &MockLockoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutRepository) EXPECT() *MockLockoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListEvents mocks base method.
+func (m *MockLockoutRepository) ListEvents(ctx context.Context, scope, subject string, limit, offset int) ([]models.LockoutEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, scope, subject, limit, offset)
+	ret0, _ := ret[0].([]models.LockoutEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockLockoutRepositoryMockRecorder) ListEvents(ctx, scope, subject, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockLockoutRepository)(nil).ListEvents), ctx, scope, subject, limit, offset)
+}
+
+// RecordEvent mocks base method.
+func (m *MockLockoutRepository) RecordEvent(ctx context.Context, event *models.LockoutEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordEvent indicates an expected call of RecordEvent.
+func (mr *MockLockoutRepositoryMockRecorder) RecordEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEvent", reflect.TypeOf((*MockLockoutRepository)(nil).RecordEvent), ctx, event)
+}
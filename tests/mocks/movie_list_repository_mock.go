@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/movie_list_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockMovieListRepository is a mock of MovieListRepository interface.
+type MockMovieListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieListRepositoryMockRecorder
+}
+
+// MockMovieListRepositoryMockRecorder is the mock recorder for MockMovieListRepository.
+type MockMovieListRepositoryMockRecorder struct {
+	mock *MockMovieListRepository
+}
+
+// NewMockMovieListRepository creates a new mock instance.
+func NewMockMovieListRepository(ctrl *gomock.Controller) *MockMovieListRepository {
+	mock := &MockMovieListRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieListRepository) EXPECT() *MockMovieListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockMovieListRepository) AddItem(ctx context.Context, userID, list, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, userID, list, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockMovieListRepositoryMockRecorder) AddItem(ctx, userID, list, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockMovieListRepository)(nil).AddItem), ctx, userID, list, movieID)
+}
+
+// FindItem mocks base method.
+func (m *MockMovieListRepository) FindItem(ctx context.Context, userID, list, movieID string) (*models.MovieListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItem", ctx, userID, list, movieID)
+	ret0, _ := ret[0].(*models.MovieListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItem indicates an expected call of FindItem.
+func (mr *MockMovieListRepositoryMockRecorder) FindItem(ctx, userID, list, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItem", reflect.TypeOf((*MockMovieListRepository)(nil).FindItem), ctx, userID, list, movieID)
+}
+
+// ListItems mocks base method.
+func (m *MockMovieListRepository) ListItems(ctx context.Context, userID, list string) ([]models.MovieListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, userID, list)
+	ret0, _ := ret[0].([]models.MovieListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockMovieListRepositoryMockRecorder) ListItems(ctx, userID, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockMovieListRepository)(nil).ListItems), ctx, userID, list)
+}
+
+// RemoveItem mocks base method.
+func (m *MockMovieListRepository) RemoveItem(ctx context.Context, userID, list, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", ctx, userID, list, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockMovieListRepositoryMockRecorder) RemoveItem(ctx, userID, list, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockMovieListRepository)(nil).RemoveItem), ctx, userID, list, movieID)
+}
+
+// Reorder mocks base method.
+func (m *MockMovieListRepository) Reorder(ctx context.Context, userID, list string, movieIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, userID, list, movieIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockMovieListRepositoryMockRecorder) Reorder(ctx, userID, list, movieIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockMovieListRepository)(nil).Reorder), ctx, userID, list, movieIDs)
+}
+
+// SetWatched mocks base method.
+func (m *MockMovieListRepository) SetWatched(ctx context.Context, userID, list, movieID string, watched bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWatched", ctx, userID, list, movieID, watched)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWatched indicates an expected call of SetWatched.
+func (mr *MockMovieListRepositoryMockRecorder) SetWatched(ctx, userID, list, movieID, watched interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWatched", reflect.TypeOf((*MockMovieListRepository)(nil).SetWatched), ctx, userID, list, movieID, watched)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/stwrtrio/movie-festival/internal/models"
@@ -35,32 +36,32 @@ func (m *MockMovieRepository) EXPECT() *MockMovieRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockMovieRepository) Create(ctx context.Context, movie *models.Movie) error {
+// AddViewCounts mocks base method.
+func (m *MockMovieRepository) AddViewCounts(ctx context.Context, batchID string, counts map[string]int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, movie)
+	ret := m.ctrl.Call(m, "AddViewCounts", ctx, batchID, counts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockMovieRepositoryMockRecorder) Create(ctx, movie interface{}) *gomock.Call {
+// AddViewCounts indicates an expected call of AddViewCounts.
+func (mr *MockMovieRepositoryMockRecorder) AddViewCounts(ctx, batchID, counts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMovieRepository)(nil).Create), ctx, movie)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddViewCounts", reflect.TypeOf((*MockMovieRepository)(nil).AddViewCounts), ctx, batchID, counts)
 }
 
-// CreateVote mocks base method.
-func (m *MockMovieRepository) CreateVote(ctx context.Context, userID, movieID string) error {
+// Create mocks base method.
+func (m *MockMovieRepository) Create(ctx context.Context, movie *models.Movie) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVote", ctx, userID, movieID)
+	ret := m.ctrl.Call(m, "Create", ctx, movie)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateVote indicates an expected call of CreateVote.
-func (mr *MockMovieRepositoryMockRecorder) CreateVote(ctx, userID, movieID interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockMovieRepositoryMockRecorder) Create(ctx, movie interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVote", reflect.TypeOf((*MockMovieRepository)(nil).CreateVote), ctx, userID, movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMovieRepository)(nil).Create), ctx, movie)
 }
 
 // DeleteVote mocks base method.
@@ -77,34 +78,34 @@ func (mr *MockMovieRepositoryMockRecorder) DeleteVote(ctx, voteID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVote", reflect.TypeOf((*MockMovieRepository)(nil).DeleteVote), ctx, voteID)
 }
 
-// FindArtistByMovieID mocks base method.
-func (m *MockMovieRepository) FindArtistByMovieID(ctx context.Context, movieID string) (models.Artist, error) {
+// FindArtistsByMovieID mocks base method.
+func (m *MockMovieRepository) FindArtistsByMovieID(ctx context.Context, movieID string) ([]models.Artist, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindArtistByMovieID", ctx, movieID)
-	ret0, _ := ret[0].(models.Artist)
+	ret := m.ctrl.Call(m, "FindArtistsByMovieID", ctx, movieID)
+	ret0, _ := ret[0].([]models.Artist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindArtistByMovieID indicates an expected call of FindArtistByMovieID.
-func (mr *MockMovieRepositoryMockRecorder) FindArtistByMovieID(ctx, movieID interface{}) *gomock.Call {
+// FindArtistsByMovieID indicates an expected call of FindArtistsByMovieID.
+func (mr *MockMovieRepositoryMockRecorder) FindArtistsByMovieID(ctx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindArtistByMovieID", reflect.TypeOf((*MockMovieRepository)(nil).FindArtistByMovieID), ctx, movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindArtistsByMovieID", reflect.TypeOf((*MockMovieRepository)(nil).FindArtistsByMovieID), ctx, movieID)
 }
 
-// FindGenreByMovieID mocks base method.
-func (m *MockMovieRepository) FindGenreByMovieID(ctx context.Context, movieID string) (models.Genre, error) {
+// FindGenresByMovieID mocks base method.
+func (m *MockMovieRepository) FindGenresByMovieID(ctx context.Context, movieID string) ([]models.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindGenreByMovieID", ctx, movieID)
-	ret0, _ := ret[0].(models.Genre)
+	ret := m.ctrl.Call(m, "FindGenresByMovieID", ctx, movieID)
+	ret0, _ := ret[0].([]models.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindGenreByMovieID indicates an expected call of FindGenreByMovieID.
-func (mr *MockMovieRepositoryMockRecorder) FindGenreByMovieID(ctx, movieID interface{}) *gomock.Call {
+// FindGenresByMovieID indicates an expected call of FindGenresByMovieID.
+func (mr *MockMovieRepositoryMockRecorder) FindGenresByMovieID(ctx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGenreByMovieID", reflect.TypeOf((*MockMovieRepository)(nil).FindGenreByMovieID), ctx, movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGenresByMovieID", reflect.TypeOf((*MockMovieRepository)(nil).FindGenresByMovieID), ctx, movieID)
 }
 
 // FindMovieByID mocks base method.
@@ -138,48 +139,78 @@ func (mr *MockMovieRepositoryMockRecorder) GetAllMovies(ctx, limit, offset inter
 }
 
 // GetMostViewedGenre mocks base method.
-func (m *MockMovieRepository) GetMostViewedGenre(ctx context.Context, page, pageSize int, sortOrder string) ([]models.GenreView, error) {
+func (m *MockMovieRepository) GetMostViewedGenre(ctx context.Context, page, pageSize int, sortOrder string, filter models.StatsFilter) ([]models.GenreView, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMostViewedGenre", ctx, page, pageSize, sortOrder)
+	ret := m.ctrl.Call(m, "GetMostViewedGenre", ctx, page, pageSize, sortOrder, filter)
 	ret0, _ := ret[0].([]models.GenreView)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMostViewedGenre indicates an expected call of GetMostViewedGenre.
-func (mr *MockMovieRepositoryMockRecorder) GetMostViewedGenre(ctx, page, pageSize, sortOrder interface{}) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) GetMostViewedGenre(ctx, page, pageSize, sortOrder, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostViewedGenre", reflect.TypeOf((*MockMovieRepository)(nil).GetMostViewedGenre), ctx, page, pageSize, sortOrder)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostViewedGenre", reflect.TypeOf((*MockMovieRepository)(nil).GetMostViewedGenre), ctx, page, pageSize, sortOrder, filter)
 }
 
 // GetMostViewedMovie mocks base method.
-func (m *MockMovieRepository) GetMostViewedMovie(ctx context.Context) (*models.Movie, error) {
+func (m *MockMovieRepository) GetMostViewedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMostViewedMovie", ctx)
+	ret := m.ctrl.Call(m, "GetMostViewedMovie", ctx, filter)
 	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMostViewedMovie indicates an expected call of GetMostViewedMovie.
-func (mr *MockMovieRepositoryMockRecorder) GetMostViewedMovie(ctx interface{}) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) GetMostViewedMovie(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostViewedMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetMostViewedMovie), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostViewedMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetMostViewedMovie), ctx, filter)
 }
 
 // GetMostVotedMovie mocks base method.
-func (m *MockMovieRepository) GetMostVotedMovie(ctx context.Context) (*models.Movie, error) {
+func (m *MockMovieRepository) GetMostVotedMovie(ctx context.Context, filter models.StatsFilter) (*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMostVotedMovie", ctx)
+	ret := m.ctrl.Call(m, "GetMostVotedMovie", ctx, filter)
 	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMostVotedMovie indicates an expected call of GetMostVotedMovie.
-func (mr *MockMovieRepositoryMockRecorder) GetMostVotedMovie(ctx interface{}) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) GetMostVotedMovie(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostVotedMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetMostVotedMovie), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostVotedMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetMostVotedMovie), ctx, filter)
+}
+
+// GetMovieDetail mocks base method.
+func (m *MockMovieRepository) GetMovieDetail(ctx context.Context, movieID string) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieDetail", ctx, movieID)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieDetail indicates an expected call of GetMovieDetail.
+func (mr *MockMovieRepositoryMockRecorder) GetMovieDetail(ctx, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieDetail", reflect.TypeOf((*MockMovieRepository)(nil).GetMovieDetail), ctx, movieID)
+}
+
+// GetMovieViewStats mocks base method.
+func (m *MockMovieRepository) GetMovieViewStats(ctx context.Context, movieID, granularity string, filter models.StatsFilter) (*models.MovieViewStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieViewStats", ctx, movieID, granularity, filter)
+	ret0, _ := ret[0].(*models.MovieViewStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieViewStats indicates an expected call of GetMovieViewStats.
+func (mr *MockMovieRepositoryMockRecorder) GetMovieViewStats(ctx, movieID, granularity, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieViewStats", reflect.TypeOf((*MockMovieRepository)(nil).GetMovieViewStats), ctx, movieID, granularity, filter)
 }
 
 // GetMoviesByIDs mocks base method.
@@ -197,6 +228,21 @@ func (mr *MockMovieRepositoryMockRecorder) GetMoviesByIDs(ctx, movieIDs interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesByIDs", reflect.TypeOf((*MockMovieRepository)(nil).GetMoviesByIDs), ctx, movieIDs)
 }
 
+// GetSuspectedViewInflation mocks base method.
+func (m *MockMovieRepository) GetSuspectedViewInflation(ctx context.Context, filter models.StatsFilter, minViews int) ([]models.SuspectedViewInflation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuspectedViewInflation", ctx, filter, minViews)
+	ret0, _ := ret[0].([]models.SuspectedViewInflation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuspectedViewInflation indicates an expected call of GetSuspectedViewInflation.
+func (mr *MockMovieRepositoryMockRecorder) GetSuspectedViewInflation(ctx, filter, minViews interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuspectedViewInflation", reflect.TypeOf((*MockMovieRepository)(nil).GetSuspectedViewInflation), ctx, filter, minViews)
+}
+
 // GetUserVotedMovieIDs mocks base method.
 func (m *MockMovieRepository) GetUserVotedMovieIDs(ctx context.Context, userID string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -227,33 +273,89 @@ func (mr *MockMovieRepositoryMockRecorder) GetVoteByUserAndMovie(ctx, userID, mo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoteByUserAndMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetVoteByUserAndMovie), ctx, userID, movieID)
 }
 
+// Purge mocks base method.
+func (m *MockMovieRepository) Purge(ctx context.Context, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockMovieRepositoryMockRecorder) Purge(ctx, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockMovieRepository)(nil).Purge), ctx, movieID)
+}
+
+// Restore mocks base method.
+func (m *MockMovieRepository) Restore(ctx context.Context, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockMovieRepositoryMockRecorder) Restore(ctx, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockMovieRepository)(nil).Restore), ctx, movieID)
+}
+
+// RollupViewStats mocks base method.
+func (m *MockMovieRepository) RollupViewStats(ctx context.Context, since time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupViewStats", ctx, since)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollupViewStats indicates an expected call of RollupViewStats.
+func (mr *MockMovieRepositoryMockRecorder) RollupViewStats(ctx, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupViewStats", reflect.TypeOf((*MockMovieRepository)(nil).RollupViewStats), ctx, since)
+}
+
 // SearchMovies mocks base method.
-func (m *MockMovieRepository) SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.Movie, error) {
+func (m *MockMovieRepository) SearchMovies(ctx context.Context, filter models.MovieSearchFilter, limit, offset int) ([]models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchMovies", ctx, query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchMovies", ctx, filter, limit, offset)
 	ret0, _ := ret[0].([]models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchMovies indicates an expected call of SearchMovies.
-func (mr *MockMovieRepositoryMockRecorder) SearchMovies(ctx, query, limit, offset interface{}) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) SearchMovies(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockMovieRepository)(nil).SearchMovies), ctx, filter, limit, offset)
+}
+
+// SoftDelete mocks base method.
+func (m *MockMovieRepository) SoftDelete(ctx context.Context, movieID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockMovieRepositoryMockRecorder) SoftDelete(ctx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockMovieRepository)(nil).SearchMovies), ctx, query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockMovieRepository)(nil).SoftDelete), ctx, movieID)
 }
 
 // TrackMovieView mocks base method.
-func (m *MockMovieRepository) TrackMovieView(ctx context.Context, movieID string) error {
+func (m *MockMovieRepository) TrackMovieView(ctx context.Context, view models.ViewEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TrackMovieView", ctx, movieID)
+	ret := m.ctrl.Call(m, "TrackMovieView", ctx, view)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // TrackMovieView indicates an expected call of TrackMovieView.
-func (mr *MockMovieRepositoryMockRecorder) TrackMovieView(ctx, movieID interface{}) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) TrackMovieView(ctx, view interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackMovieView", reflect.TypeOf((*MockMovieRepository)(nil).TrackMovieView), ctx, movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackMovieView", reflect.TypeOf((*MockMovieRepository)(nil).TrackMovieView), ctx, view)
 }
 
 // Update mocks base method.
@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/review_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewRepositoryMockRecorder) Create(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewRepository)(nil).Create), ctx, review)
+}
+
+// Delete mocks base method.
+func (m *MockReviewRepository) Delete(ctx context.Context, reviewID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewRepositoryMockRecorder) Delete(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewRepository)(nil).Delete), ctx, reviewID)
+}
+
+// FindByID mocks base method.
+func (m *MockReviewRepository) FindByID(ctx context.Context, reviewID string) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, reviewID)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockReviewRepositoryMockRecorder) FindByID(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockReviewRepository)(nil).FindByID), ctx, reviewID)
+}
+
+// FindByMovieAndUser mocks base method.
+func (m *MockReviewRepository) FindByMovieAndUser(ctx context.Context, movieID, userID string) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMovieAndUser", ctx, movieID, userID)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMovieAndUser indicates an expected call of FindByMovieAndUser.
+func (mr *MockReviewRepositoryMockRecorder) FindByMovieAndUser(ctx, movieID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMovieAndUser", reflect.TypeOf((*MockReviewRepository)(nil).FindByMovieAndUser), ctx, movieID, userID)
+}
+
+// GetRatingSummary mocks base method.
+func (m *MockReviewRepository) GetRatingSummary(ctx context.Context, movieID string) (*models.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingSummary", ctx, movieID)
+	ret0, _ := ret[0].(*models.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingSummary indicates an expected call of GetRatingSummary.
+func (mr *MockReviewRepositoryMockRecorder) GetRatingSummary(ctx, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingSummary", reflect.TypeOf((*MockReviewRepository)(nil).GetRatingSummary), ctx, movieID)
+}
+
+// ListByMovie mocks base method.
+func (m *MockReviewRepository) ListByMovie(ctx context.Context, movieID, sort string, limit, offset int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMovie", ctx, movieID, sort, limit, offset)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMovie indicates an expected call of ListByMovie.
+func (mr *MockReviewRepositoryMockRecorder) ListByMovie(ctx, movieID, sort, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMovie", reflect.TypeOf((*MockReviewRepository)(nil).ListByMovie), ctx, movieID, sort, limit, offset)
+}
+
+// ListByStatus mocks base method.
+func (m *MockReviewRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStatus", ctx, status, limit, offset)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockReviewRepositoryMockRecorder) ListByStatus(ctx, status, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockReviewRepository)(nil).ListByStatus), ctx, status, limit, offset)
+}
+
+// ListModerationActions mocks base method.
+func (m *MockReviewRepository) ListModerationActions(ctx context.Context, reviewID string) ([]models.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationActions", ctx, reviewID)
+	ret0, _ := ret[0].([]models.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationActions indicates an expected call of ListModerationActions.
+func (mr *MockReviewRepositoryMockRecorder) ListModerationActions(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationActions", reflect.TypeOf((*MockReviewRepository)(nil).ListModerationActions), ctx, reviewID)
+}
+
+// MarkHelpful mocks base method.
+func (m *MockReviewRepository) MarkHelpful(ctx context.Context, reviewID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkHelpful", ctx, reviewID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkHelpful indicates an expected call of MarkHelpful.
+func (mr *MockReviewRepositoryMockRecorder) MarkHelpful(ctx, reviewID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkHelpful", reflect.TypeOf((*MockReviewRepository)(nil).MarkHelpful), ctx, reviewID, userID)
+}
+
+// Moderate mocks base method.
+func (m *MockReviewRepository) Moderate(ctx context.Context, reviewID, adminID, action, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, reviewID, adminID, action, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockReviewRepositoryMockRecorder) Moderate(ctx, reviewID, adminID, action, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockReviewRepository)(nil).Moderate), ctx, reviewID, adminID, action, reason)
+}
+
+// Report mocks base method.
+func (m *MockReviewRepository) Report(ctx context.Context, reviewID, userID, reason string, threshold int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, reviewID, userID, reason, threshold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockReviewRepositoryMockRecorder) Report(ctx, reviewID, userID, reason, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockReviewRepository)(nil).Report), ctx, reviewID, userID, reason, threshold)
+}
+
+// UnmarkHelpful mocks base method.
+func (m *MockReviewRepository) UnmarkHelpful(ctx context.Context, reviewID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarkHelpful", ctx, reviewID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarkHelpful indicates an expected call of UnmarkHelpful.
+func (mr *MockReviewRepositoryMockRecorder) UnmarkHelpful(ctx, reviewID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkHelpful", reflect.TypeOf((*MockReviewRepository)(nil).UnmarkHelpful), ctx, reviewID, userID)
+}
+
+// Update mocks base method.
+func (m *MockReviewRepository) Update(ctx context.Context, review *models.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewRepositoryMockRecorder) Update(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewRepository)(nil).Update), ctx, review)
+}
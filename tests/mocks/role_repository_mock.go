@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/role_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockRoleRepository is a mock of RoleRepository interface.
+type MockRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleRepositoryMockRecorder
+}
+
+// MockRoleRepositoryMockRecorder is the mock recorder for MockRoleRepository.
+type MockRoleRepositoryMockRecorder struct {
+	mock *MockRoleRepository
+}
+
+// NewMockRoleRepository creates a new mock instance.
+func NewMockRoleRepository(ctrl *gomock.Controller) *MockRoleRepository {
+	mock := &MockRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleRepository) EXPECT() *MockRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignToUser mocks base method.
+func (m *MockRoleRepository) AssignToUser(ctx context.Context, userID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignToUser", ctx, userID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignToUser indicates an expected call of AssignToUser.
+func (mr *MockRoleRepositoryMockRecorder) AssignToUser(ctx, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignToUser", reflect.TypeOf((*MockRoleRepository)(nil).AssignToUser), ctx, userID, name)
+}
+
+// CountUsers mocks base method.
+func (m *MockRoleRepository) CountUsers(ctx context.Context, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockRoleRepositoryMockRecorder) CountUsers(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockRoleRepository)(nil).CountUsers), ctx, name)
+}
+
+// Create mocks base method.
+func (m *MockRoleRepository) Create(ctx context.Context, role *models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRoleRepositoryMockRecorder) Create(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRoleRepository)(nil).Create), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockRoleRepository) Delete(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRoleRepositoryMockRecorder) Delete(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoleRepository)(nil).Delete), ctx, name)
+}
+
+// FindByName mocks base method.
+func (m *MockRoleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockRoleRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockRoleRepository)(nil).FindByName), ctx, name)
+}
+
+// FindUserRole mocks base method.
+func (m *MockRoleRepository) FindUserRole(ctx context.Context, userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserRole", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserRole indicates an expected call of FindUserRole.
+func (mr *MockRoleRepositoryMockRecorder) FindUserRole(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserRole", reflect.TypeOf((*MockRoleRepository)(nil).FindUserRole), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockRoleRepository) List(ctx context.Context) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRoleRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleRepository)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockRoleRepository) Update(ctx context.Context, role *models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRoleRepositoryMockRecorder) Update(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRoleRepository)(nil).Update), ctx, role)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/session_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionRepository) CreateSession(ctx context.Context, session *models.Session, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionRepositoryMockRecorder) CreateSession(ctx, session, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionRepository)(nil).CreateSession), ctx, session, tokenHash)
+}
+
+// FindRefreshToken mocks base method.
+func (m *MockSessionRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefreshToken indicates an expected call of FindRefreshToken.
+func (mr *MockSessionRepositoryMockRecorder) FindRefreshToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefreshToken", reflect.TypeOf((*MockSessionRepository)(nil).FindRefreshToken), ctx, tokenHash)
+}
+
+// ListActiveSessions mocks base method.
+func (m *MockSessionRepository) ListActiveSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", ctx, userID)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockSessionRepositoryMockRecorder) ListActiveSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*MockSessionRepository)(nil).ListActiveSessions), ctx, userID)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockSessionRepository) RevokeAllSessions(ctx context.Context, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockSessionRepositoryMockRecorder) RevokeAllSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockSessionRepository)(nil).RevokeAllSessions), ctx, userID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionRepository) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionRepositoryMockRecorder) RevokeSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionRepository)(nil).RevokeSession), ctx, userID, sessionID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockSessionRepository) RotateRefreshToken(ctx context.Context, token *models.RefreshToken, newTokenHash string, device models.SessionDevice, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, token, newTokenHash, device, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockSessionRepositoryMockRecorder) RotateRefreshToken(ctx, token, newTokenHash, device, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockSessionRepository)(nil).RotateRefreshToken), ctx, token, newTokenHash, device, expiresAt)
+}
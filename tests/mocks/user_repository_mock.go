@@ -35,6 +35,20 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreatePasswordResetToken mocks base method.
+func (m *MockUserRepository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, token, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockUserRepositoryMockRecorder) CreatePasswordResetToken(ctx, token, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockUserRepository)(nil).CreatePasswordResetToken), ctx, token, tokenHash)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepository) CreateUser(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
@@ -49,6 +63,79 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(ctx, user interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), ctx, user)
 }
 
+// DisableMFA mocks base method.
+func (m *MockUserRepository) DisableMFA(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockUserRepositoryMockRecorder) DisableMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockUserRepository)(nil).DisableMFA), ctx, userID)
+}
+
+// EnableMFA mocks base method.
+func (m *MockUserRepository) EnableMFA(ctx context.Context, userID string, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", ctx, userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockUserRepositoryMockRecorder) EnableMFA(ctx, userID, codeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockUserRepository)(nil).EnableMFA), ctx, userID, codeHashes)
+}
+
+// FindPasswordResetToken mocks base method.
+func (m *MockUserRepository) FindPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPasswordResetToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPasswordResetToken indicates an expected call of FindPasswordResetToken.
+func (mr *MockUserRepositoryMockRecorder) FindPasswordResetToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPasswordResetToken", reflect.TypeOf((*MockUserRepository)(nil).FindPasswordResetToken), ctx, tokenHash)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockUserRepositoryMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserRepository) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), ctx, userID)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +150,146 @@ func (mr *MockUserRepositoryMockRecorder) GetUserByUsername(ctx, username interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetUserByUsername), ctx, username)
 }
+
+// GetUserDetail mocks base method.
+func (m *MockUserRepository) GetUserDetail(ctx context.Context, userID string) (*models.UserDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDetail", ctx, userID)
+	ret0, _ := ret[0].(*models.UserDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDetail indicates an expected call of GetUserDetail.
+func (mr *MockUserRepositoryMockRecorder) GetUserDetail(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetail", reflect.TypeOf((*MockUserRepository)(nil).GetUserDetail), ctx, userID)
+}
+
+// ListUserVotes mocks base method.
+func (m *MockUserRepository) ListUserVotes(ctx context.Context, userID string, limit, offset int) ([]models.UserVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserVotes", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]models.UserVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserVotes indicates an expected call of ListUserVotes.
+func (mr *MockUserRepositoryMockRecorder) ListUserVotes(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserVotes", reflect.TypeOf((*MockUserRepository)(nil).ListUserVotes), ctx, userID, limit, offset)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, filter)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockUserRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", ctx, userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockUserRepositoryMockRecorder) ReplaceRecoveryCodes(ctx, userID, codeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockUserRepository)(nil).ReplaceRecoveryCodes), ctx, userID, codeHashes)
+}
+
+// RequirePasswordReset mocks base method.
+func (m *MockUserRepository) RequirePasswordReset(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequirePasswordReset", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequirePasswordReset indicates an expected call of RequirePasswordReset.
+func (mr *MockUserRepositoryMockRecorder) RequirePasswordReset(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePasswordReset", reflect.TypeOf((*MockUserRepository)(nil).RequirePasswordReset), ctx, userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserRepository) ResetPassword(ctx context.Context, token *models.PasswordResetToken, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserRepositoryMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserRepository)(nil).ResetPassword), ctx, token, password)
+}
+
+// SetDisabled mocks base method.
+func (m *MockUserRepository) SetDisabled(ctx context.Context, userID string, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", ctx, userID, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockUserRepositoryMockRecorder) SetDisabled(ctx, userID, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockUserRepository)(nil).SetDisabled), ctx, userID, disabled)
+}
+
+// SetMFASecret mocks base method.
+func (m *MockUserRepository) SetMFASecret(ctx context.Context, userID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMFASecret", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMFASecret indicates an expected call of SetMFASecret.
+func (mr *MockUserRepositoryMockRecorder) SetMFASecret(ctx, userID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMFASecret", reflect.TypeOf((*MockUserRepository)(nil).SetMFASecret), ctx, userID, secret)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, userID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(ctx, userID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), ctx, userID, password)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockUserRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockUserRepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockUserRepository)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
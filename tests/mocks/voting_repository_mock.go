@@ -0,0 +1,287 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/voting_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockVotingRepository is a mock of VotingRepository interface.
+type MockVotingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVotingRepositoryMockRecorder
+}
+
+// MockVotingRepositoryMockRecorder is the mock recorder for MockVotingRepository.
+type MockVotingRepositoryMockRecorder struct {
+	mock *MockVotingRepository
+}
+
+// NewMockVotingRepository creates a new mock instance.
+func NewMockVotingRepository(ctrl *gomock.Controller) *MockVotingRepository {
+	mock := &MockVotingRepository{ctrl: ctrl}
+	mock.recorder = &MockVotingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVotingRepository) EXPECT() *MockVotingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CastVote mocks base method.
+func (m *MockVotingRepository) CastVote(ctx context.Context, userID, movieID string, period *models.VotingPeriod) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CastVote", ctx, userID, movieID, period)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CastVote indicates an expected call of CastVote.
+func (mr *MockVotingRepositoryMockRecorder) CastVote(ctx, userID, movieID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CastVote", reflect.TypeOf((*MockVotingRepository)(nil).CastVote), ctx, userID, movieID, period)
+}
+
+// CountEligibleMovies mocks base method.
+func (m *MockVotingRepository) CountEligibleMovies(ctx context.Context, periodID string, movieIDs []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEligibleMovies", ctx, periodID, movieIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEligibleMovies indicates an expected call of CountEligibleMovies.
+func (mr *MockVotingRepositoryMockRecorder) CountEligibleMovies(ctx, periodID, movieIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEligibleMovies", reflect.TypeOf((*MockVotingRepository)(nil).CountEligibleMovies), ctx, periodID, movieIDs)
+}
+
+// CountVotes mocks base method.
+func (m *MockVotingRepository) CountVotes(ctx context.Context, periodID, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVotes", ctx, periodID, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountVotes indicates an expected call of CountVotes.
+func (mr *MockVotingRepositoryMockRecorder) CountVotes(ctx, periodID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVotes", reflect.TypeOf((*MockVotingRepository)(nil).CountVotes), ctx, periodID, userID)
+}
+
+// CreatePeriod mocks base method.
+func (m *MockVotingRepository) CreatePeriod(ctx context.Context, period *models.VotingPeriod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePeriod", ctx, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePeriod indicates an expected call of CreatePeriod.
+func (mr *MockVotingRepositoryMockRecorder) CreatePeriod(ctx, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePeriod", reflect.TypeOf((*MockVotingRepository)(nil).CreatePeriod), ctx, period)
+}
+
+// DeleteBallot mocks base method.
+func (m *MockVotingRepository) DeleteBallot(ctx context.Context, periodID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBallot", ctx, periodID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBallot indicates an expected call of DeleteBallot.
+func (mr *MockVotingRepositoryMockRecorder) DeleteBallot(ctx, periodID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBallot", reflect.TypeOf((*MockVotingRepository)(nil).DeleteBallot), ctx, periodID, userID)
+}
+
+// DeletePeriod mocks base method.
+func (m *MockVotingRepository) DeletePeriod(ctx context.Context, periodID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePeriod", ctx, periodID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePeriod indicates an expected call of DeletePeriod.
+func (mr *MockVotingRepositoryMockRecorder) DeletePeriod(ctx, periodID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePeriod", reflect.TypeOf((*MockVotingRepository)(nil).DeletePeriod), ctx, periodID)
+}
+
+// FindBallot mocks base method.
+func (m *MockVotingRepository) FindBallot(ctx context.Context, periodID, userID string) (*models.Ballot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBallot", ctx, periodID, userID)
+	ret0, _ := ret[0].(*models.Ballot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBallot indicates an expected call of FindBallot.
+func (mr *MockVotingRepositoryMockRecorder) FindBallot(ctx, periodID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBallot", reflect.TypeOf((*MockVotingRepository)(nil).FindBallot), ctx, periodID, userID)
+}
+
+// FindPeriod mocks base method.
+func (m *MockVotingRepository) FindPeriod(ctx context.Context, periodID string) (*models.VotingPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPeriod", ctx, periodID)
+	ret0, _ := ret[0].(*models.VotingPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPeriod indicates an expected call of FindPeriod.
+func (mr *MockVotingRepositoryMockRecorder) FindPeriod(ctx, periodID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPeriod", reflect.TypeOf((*MockVotingRepository)(nil).FindPeriod), ctx, periodID)
+}
+
+// FindVote mocks base method.
+func (m *MockVotingRepository) FindVote(ctx context.Context, userID, movieID string, periodIDs []string) (*models.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVote", ctx, userID, movieID, periodIDs)
+	ret0, _ := ret[0].(*models.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVote indicates an expected call of FindVote.
+func (mr *MockVotingRepositoryMockRecorder) FindVote(ctx, userID, movieID, periodIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVote", reflect.TypeOf((*MockVotingRepository)(nil).FindVote), ctx, userID, movieID, periodIDs)
+}
+
+// HasBallots mocks base method.
+func (m *MockVotingRepository) HasBallots(ctx context.Context, periodID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasBallots", ctx, periodID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasBallots indicates an expected call of HasBallots.
+func (mr *MockVotingRepositoryMockRecorder) HasBallots(ctx, periodID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasBallots", reflect.TypeOf((*MockVotingRepository)(nil).HasBallots), ctx, periodID)
+}
+
+// ListApprovals mocks base method.
+func (m *MockVotingRepository) ListApprovals(ctx context.Context, periodID string) ([]models.TallyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovals", ctx, periodID)
+	ret0, _ := ret[0].([]models.TallyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovals indicates an expected call of ListApprovals.
+func (mr *MockVotingRepositoryMockRecorder) ListApprovals(ctx, periodID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovals", reflect.TypeOf((*MockVotingRepository)(nil).ListApprovals), ctx, periodID)
+}
+
+// ListBallots mocks base method.
+func (m *MockVotingRepository) ListBallots(ctx context.Context, periodID string) ([]models.Ballot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBallots", ctx, periodID)
+	ret0, _ := ret[0].([]models.Ballot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBallots indicates an expected call of ListBallots.
+func (mr *MockVotingRepositoryMockRecorder) ListBallots(ctx, periodID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBallots", reflect.TypeOf((*MockVotingRepository)(nil).ListBallots), ctx, periodID)
+}
+
+// ListOpenPeriods mocks base method.
+func (m *MockVotingRepository) ListOpenPeriods(ctx context.Context, at time.Time) ([]models.VotingPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenPeriods", ctx, at)
+	ret0, _ := ret[0].([]models.VotingPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenPeriods indicates an expected call of ListOpenPeriods.
+func (mr *MockVotingRepositoryMockRecorder) ListOpenPeriods(ctx, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenPeriods", reflect.TypeOf((*MockVotingRepository)(nil).ListOpenPeriods), ctx, at)
+}
+
+// ListOpenPeriodsForMovie mocks base method.
+func (m *MockVotingRepository) ListOpenPeriodsForMovie(ctx context.Context, movieID string, at time.Time) ([]models.VotingPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenPeriodsForMovie", ctx, movieID, at)
+	ret0, _ := ret[0].([]models.VotingPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenPeriodsForMovie indicates an expected call of ListOpenPeriodsForMovie.
+func (mr *MockVotingRepositoryMockRecorder) ListOpenPeriodsForMovie(ctx, movieID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenPeriodsForMovie", reflect.TypeOf((*MockVotingRepository)(nil).ListOpenPeriodsForMovie), ctx, movieID, at)
+}
+
+// ListPeriods mocks base method.
+func (m *MockVotingRepository) ListPeriods(ctx context.Context, editionID string) ([]models.VotingPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPeriods", ctx, editionID)
+	ret0, _ := ret[0].([]models.VotingPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPeriods indicates an expected call of ListPeriods.
+func (mr *MockVotingRepositoryMockRecorder) ListPeriods(ctx, editionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPeriods", reflect.TypeOf((*MockVotingRepository)(nil).ListPeriods), ctx, editionID)
+}
+
+// SaveBallot mocks base method.
+func (m *MockVotingRepository) SaveBallot(ctx context.Context, ballot *models.Ballot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBallot", ctx, ballot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBallot indicates an expected call of SaveBallot.
+func (mr *MockVotingRepositoryMockRecorder) SaveBallot(ctx, ballot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBallot", reflect.TypeOf((*MockVotingRepository)(nil).SaveBallot), ctx, ballot)
+}
+
+// UpdatePeriod mocks base method.
+func (m *MockVotingRepository) UpdatePeriod(ctx context.Context, period *models.VotingPeriod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePeriod", ctx, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePeriod indicates an expected call of UpdatePeriod.
+func (mr *MockVotingRepositoryMockRecorder) UpdatePeriod(ctx, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePeriod", reflect.TypeOf((*MockVotingRepository)(nil).UpdatePeriod), ctx, period)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/watch_progress_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/stwrtrio/movie-festival/internal/models"
+)
+
+// MockWatchProgressRepository is a mock of WatchProgressRepository interface.
+type MockWatchProgressRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchProgressRepositoryMockRecorder
+}
+
+// MockWatchProgressRepositoryMockRecorder is the mock recorder for MockWatchProgressRepository.
+type MockWatchProgressRepositoryMockRecorder struct {
+	mock *MockWatchProgressRepository
+}
+
+// NewMockWatchProgressRepository creates a new mock instance.
+func NewMockWatchProgressRepository(ctrl *gomock.Controller) *MockWatchProgressRepository {
+	mock := &MockWatchProgressRepository{ctrl: ctrl}
+	mock.recorder = &MockWatchProgressRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchProgressRepository) EXPECT() *MockWatchProgressRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindProgress mocks base method.
+func (m *MockWatchProgressRepository) FindProgress(ctx context.Context, userID, movieID string) (*models.WatchProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProgress", ctx, userID, movieID)
+	ret0, _ := ret[0].(*models.WatchProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProgress indicates an expected call of FindProgress.
+func (mr *MockWatchProgressRepositoryMockRecorder) FindProgress(ctx, userID, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProgress", reflect.TypeOf((*MockWatchProgressRepository)(nil).FindProgress), ctx, userID, movieID)
+}
+
+// ListInProgress mocks base method.
+func (m *MockWatchProgressRepository) ListInProgress(ctx context.Context, userID string, limit int) ([]models.WatchProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInProgress", ctx, userID, limit)
+	ret0, _ := ret[0].([]models.WatchProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInProgress indicates an expected call of ListInProgress.
+func (mr *MockWatchProgressRepositoryMockRecorder) ListInProgress(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInProgress", reflect.TypeOf((*MockWatchProgressRepository)(nil).ListInProgress), ctx, userID, limit)
+}
+
+// SaveProgress mocks base method.
+func (m *MockWatchProgressRepository) SaveProgress(ctx context.Context, progress *models.WatchProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProgress", ctx, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProgress indicates an expected call of SaveProgress.
+func (mr *MockWatchProgressRepositoryMockRecorder) SaveProgress(ctx, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProgress", reflect.TypeOf((*MockWatchProgressRepository)(nil).SaveProgress), ctx, progress)
+}
@@ -0,0 +1,213 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/oidc"
+)
+
+// mockServer is a minimal OpenID Connect provider issuing ID tokens with the given claims
+type mockServer struct {
+	*httptest.Server
+	key           *rsa.PrivateKey
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	m := &mockServer{key: key}
+	mux := http.NewServeMux()
+	m.Server = httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.URL,
+			"authorization_endpoint": m.URL + "/authorize",
+			"token_endpoint":         m.URL + "/token",
+			"jwks_uri":               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "client1" || clientSecret != "secret1" || r.FormValue("code") != "code1" ||
+			oidc.CodeChallenge(r.FormValue("code_verifier")) != m.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims)
+		token.Header["kid"] = "key1"
+		idToken, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer", "id_token": idToken})
+	})
+
+	return m
+}
+
+func (m *mockServer) validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                m.URL,
+		"aud":                "client1",
+		"sub":                "sub1",
+		"exp":                time.Now().Add(time.Minute).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              "nonce1",
+		"email":              "john@example.com",
+		"email_verified":     "true",
+		"preferred_username": "johndoe",
+	}
+}
+
+func TestProviderLogin(t *testing.T) {
+	server := newMockServer(t)
+	defer server.Close()
+
+	verifier, err := oidc.GenerateCodeVerifier()
+	assert.NoError(t, err)
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		claims        func(claims jwt.MapClaims)
+		code          string
+		codeVerifier  string
+		expectedError error
+	}{
+		{
+			name:         "Success - ID token verified",
+			claims:       func(claims jwt.MapClaims) {},
+			code:         "code1",
+			codeVerifier: verifier,
+		},
+		{
+			name:          "Failure - Code verifier does not match the challenge",
+			claims:        func(claims jwt.MapClaims) {},
+			code:          "code1",
+			codeVerifier:  "another-verifier",
+			expectedError: oidc.ErrTokenExchange,
+		},
+		{
+			name:          "Failure - Unknown code",
+			claims:        func(claims jwt.MapClaims) {},
+			code:          "code2",
+			codeVerifier:  verifier,
+			expectedError: oidc.ErrTokenExchange,
+		},
+		{
+			name:          "Failure - Nonce mismatch",
+			claims:        func(claims jwt.MapClaims) { claims["nonce"] = "nonce2" },
+			code:          "code1",
+			codeVerifier:  verifier,
+			expectedError: oidc.ErrInvalidIDToken,
+		},
+		{
+			name:          "Failure - Issued for another client",
+			claims:        func(claims jwt.MapClaims) { claims["aud"] = "client2" },
+			code:          "code1",
+			codeVerifier:  verifier,
+			expectedError: oidc.ErrInvalidIDToken,
+		},
+		{
+			name:          "Failure - Another issuer",
+			claims:        func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+			code:          "code1",
+			codeVerifier:  verifier,
+			expectedError: oidc.ErrInvalidIDToken,
+		},
+		{
+			name:          "Failure - Expired",
+			claims:        func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+			code:          "code1",
+			codeVerifier:  verifier,
+			expectedError: oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := oidc.NewProvider(oidc.Config{
+				Name:         "mock",
+				Issuer:       server.URL,
+				ClientID:     "client1",
+				ClientSecret: "secret1",
+				RedirectURL:  "http://localhost:8080/api/user/oidc/mock/callback",
+			})
+
+			authorizationURL, err := provider.AuthCodeURL(context.Background(), "state1", "nonce1", oidc.CodeChallenge(verifier))
+			assert.NoError(t, err)
+
+			parsed, err := url.Parse(authorizationURL)
+			assert.NoError(t, err)
+			assert.Equal(t, server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+			assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+			assert.Equal(t, "state1", parsed.Query().Get("state"))
+			assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+
+			server.codeChallenge = parsed.Query().Get("code_challenge")
+			server.claims = server.validClaims()
+			tc.claims(server.claims)
+
+			identity, err := provider.Exchange(context.Background(), tc.code, tc.codeVerifier, "nonce1")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, identity)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &oidc.Identity{
+				Provider:          "mock",
+				Subject:           "sub1",
+				Email:             "john@example.com",
+				EmailVerified:     true,
+				PreferredUsername: "johndoe",
+			}, identity)
+		})
+	}
+}
+
+func TestNewProvidersFromEnv(t *testing.T) {
+	t.Setenv("OIDC_PROVIDERS", "mock, google")
+	t.Setenv("OIDC_MOCK_ISSUER", "http://localhost:8081/")
+	t.Setenv("OIDC_MOCK_CLIENT_ID", "client1")
+	t.Setenv("OIDC_MOCK_REDIRECT_URL", "http://localhost:8080/api/user/oidc/mock/callback")
+	t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+	t.Setenv("OIDC_GOOGLE_CLIENT_ID", "client2")
+
+	_, err := oidc.NewProvidersFromEnv()
+	assert.Error(t, err, "google has no redirect URL")
+
+	t.Setenv("OIDC_GOOGLE_REDIRECT_URL", "http://localhost:8080/api/user/oidc/google/callback")
+	providers, err := oidc.NewProvidersFromEnv()
+	assert.NoError(t, err)
+	assert.Len(t, providers, 2)
+	assert.Equal(t, "mock", providers["mock"].Name())
+}
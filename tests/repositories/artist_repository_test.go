@@ -0,0 +1,98 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestArtistProfileCRUD(t *testing.T) {
+	repo := repositories.NewArtistRepository(testDB)
+	ctx := context.Background()
+
+	artist := &models.ArtistProfile{
+		ID:          uuid.NewString(),
+		Name:        "Artist Repository Test",
+		Bio:         "A test artist",
+		PhotoURL:    "http://example.com/photo.jpg",
+		ExternalIDs: map[string]string{"imdb": "nm0000001"},
+	}
+
+	err := repo.Create(ctx, artist)
+	require.NoError(t, err)
+
+	found, err := repo.FindByID(ctx, artist.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, artist.Name, found.Name)
+	assert.Equal(t, "nm0000001", found.ExternalIDs["imdb"])
+
+	artist.Bio = "An updated bio"
+	err = repo.Update(ctx, artist)
+	assert.NoError(t, err)
+
+	found, err = repo.FindByName(ctx, artist.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "An updated bio", found.Bio)
+
+	listed, err := repo.List(ctx, "Repository Test", 10, 0)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, listed)
+
+	// The LIKE wildcards of the query match themselves
+	listed, err = repo.List(ctx, "Repository_Test", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+
+	err = repo.Delete(ctx, artist.ID)
+	assert.NoError(t, err)
+
+	_, err = repo.FindByID(ctx, artist.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestMergeArtists(t *testing.T) {
+	movieRepo := repositories.NewMovieRepository(testDB)
+	repo := repositories.NewArtistRepository(testDB)
+	ctx := context.Background()
+
+	movie := &models.Movie{
+		ID:          uuid.NewString(),
+		Title:       "Merge Test Movie",
+		Description: "A movie with a duplicated artist",
+		Duration:    90,
+		WatchURL:    "http://example.com/merge.mp4",
+		Genres:      []models.Genre{{Name: "Drama"}},
+		Artists: []models.Artist{
+			{ID: uuid.NewString(), Name: "Merge Test Artist", Role: models.ArtistRoleDirector, BillingOrder: 1},
+			{ID: uuid.NewString(), Name: "Merge Test Artst", Role: models.ArtistRoleWriter, BillingOrder: 2},
+		},
+	}
+	err := movieRepo.Create(ctx, movie)
+	require.NoError(t, err)
+
+	target, source := movie.Artists[0], movie.Artists[1]
+
+	err = repo.Merge(ctx, target.ID, []string{source.ID})
+	assert.NoError(t, err)
+
+	// The typo artist is gone and its credit now belongs to the target
+	_, err = repo.FindByID(ctx, source.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	filmography, err := repo.GetFilmography(ctx, target.ID)
+	assert.NoError(t, err)
+	require.Len(t, filmography, 1)
+	assert.ElementsMatch(t, []string{models.ArtistRoleDirector, models.ArtistRoleWriter}, filmography[0].Roles)
+
+	// Clean up test data
+	movie.Artists = movie.Artists[:1]
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
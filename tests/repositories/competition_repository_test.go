@@ -0,0 +1,99 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestCompetitionLifecycle(t *testing.T) {
+	editionRepo := repositories.NewEditionRepository(testDB)
+	repo := repositories.NewCompetitionRepository(testDB)
+	ctx := context.Background()
+
+	edition := &models.Edition{
+		ID:       uuid.NewString(),
+		Name:     "competitiontestdummy",
+		StartsOn: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+		Status:   models.EditionStatusDraft,
+	}
+	require.NoError(t, editionRepo.Create(ctx, edition))
+
+	section := &models.Section{ID: uuid.NewString(), EditionID: edition.ID, Name: "Shorts"}
+	require.NoError(t, repo.CreateSection(ctx, section))
+
+	found, err := repo.FindSectionByName(ctx, edition.ID, "Shorts")
+	assert.NoError(t, err)
+	assert.Equal(t, section.ID, found.ID)
+	assert.Empty(t, found.Description)
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	// Only the entries of the edition can be nominated
+	err = repo.NominateMovies(ctx, section.ID, []string{movie.ID})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, editionRepo.EnterMovies(ctx, edition.ID, []string{movie.ID}))
+	err = repo.NominateMovies(ctx, section.ID, []string{movie.ID, movie.ID})
+	assert.NoError(t, err)
+
+	nominees, err := repo.ListNominees(ctx, section.ID)
+	assert.NoError(t, err)
+	require.Len(t, nominees, 1)
+	assert.Equal(t, movie.ID, nominees[0].MovieID)
+
+	category := &models.AwardCategory{ID: uuid.NewString(), SectionID: section.ID, Name: "Best Short", Description: "Best short film"}
+	require.NoError(t, repo.CreateCategory(ctx, category))
+
+	err = repo.AddWinner(ctx, category.ID, movie.ID)
+	assert.NoError(t, err)
+
+	err = repo.AddWinner(ctx, category.ID, "not-a-movie")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Winners of draft editions are not listed to the audience
+	winners, err := repo.ListWinners(ctx, edition.ID, models.EditionStatusOpen, models.EditionStatusClosed)
+	assert.NoError(t, err)
+	assert.Empty(t, winners)
+
+	require.NoError(t, editionRepo.SetStatus(ctx, edition.ID, models.EditionStatusOpen))
+	winners, err = repo.ListWinners(ctx, edition.ID, models.EditionStatusOpen, models.EditionStatusClosed)
+	assert.NoError(t, err)
+	require.Len(t, winners, 1)
+	assert.Equal(t, "Shorts", winners[0].SectionName)
+	assert.Equal(t, "Best Short", winners[0].CategoryName)
+	assert.Equal(t, movie.ID, winners[0].MovieID)
+
+	// Withdrawing the movie from the edition removes its nominations and awards
+	require.NoError(t, editionRepo.WithdrawMovie(ctx, edition.ID, movie.ID))
+	nominees, err = repo.ListNominees(ctx, section.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, nominees)
+	winners, err = repo.ListWinners(ctx, edition.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, winners)
+
+	err = repo.RemoveNomination(ctx, section.ID, movie.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.DeleteSection(ctx, section.ID)
+	assert.NoError(t, err)
+	_, err = repo.FindCategory(ctx, category.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM festival_editions WHERE id = ?", edition.ID)
+	require.NoError(t, err)
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestEditionLifecycle(t *testing.T) {
+	repo := repositories.NewEditionRepository(testDB)
+	ctx := context.Background()
+
+	edition := &models.Edition{
+		ID:       uuid.NewString(),
+		Name:     "editiontestdummy",
+		StartsOn: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+		Status:   models.EditionStatusDraft,
+	}
+	err := repo.Create(ctx, edition)
+	require.NoError(t, err)
+
+	found, err := repo.FindByName(ctx, "editiontestdummy")
+	assert.NoError(t, err)
+	assert.Equal(t, edition.ID, found.ID)
+	assert.Equal(t, models.EditionStatusDraft, found.Status)
+	assert.Nil(t, found.OpenedAt)
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	err = repo.EnterMovies(ctx, edition.ID, []string{movie.ID, movie.ID})
+	assert.NoError(t, err)
+
+	// Nothing is entered when one of the movies does not exist
+	err = repo.EnterMovies(ctx, edition.ID, []string{"not-a-movie"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	entries, err := repo.ListEntries(ctx, edition.ID)
+	assert.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, movie.ID, entries[0].ID)
+
+	err = repo.SetStatus(ctx, edition.ID, models.EditionStatusOpen)
+	assert.NoError(t, err)
+
+	editions, err := repo.List(ctx, models.EditionStatusOpen)
+	assert.NoError(t, err)
+	var listed *models.Edition
+	for i := range editions {
+		if editions[i].ID == edition.ID {
+			listed = &editions[i]
+		}
+	}
+	require.NotNil(t, listed)
+	assert.Equal(t, 1, listed.Movies)
+	assert.NotNil(t, listed.OpenedAt)
+
+	// The statistics of the edition only count its entries
+	user, err := createUserDummy()
+	require.NoError(t, err)
+	_, err = testDB.Exec("INSERT INTO votes (id, user_id, movie_id) VALUES (?, ?, ?)", uuid.NewString(), user.ID, movie.ID)
+	require.NoError(t, err)
+
+	movieRepo := repositories.NewMovieRepository(testDB)
+	mostVoted, err := movieRepo.GetMostVotedMovie(ctx, models.StatsFilter{EditionID: edition.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, movie.ID, mostVoted.ID)
+	assert.Equal(t, 1, mostVoted.Votes)
+
+	_, err = movieRepo.GetMostVotedMovie(ctx, models.StatsFilter{EditionID: "not-an-edition"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.SetStatus(ctx, edition.ID, models.EditionStatusClosed)
+	assert.NoError(t, err)
+
+	err = repo.WithdrawMovie(ctx, edition.ID, movie.ID)
+	assert.NoError(t, err)
+
+	err = repo.WithdrawMovie(ctx, edition.ID, movie.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.SetStatus(ctx, "not-an-edition", models.EditionStatusOpen)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM votes WHERE movie_id = ?", movie.ID)
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM festival_editions WHERE id = ?", edition.ID)
+	require.NoError(t, err)
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
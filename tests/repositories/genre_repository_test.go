@@ -0,0 +1,59 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestMergeGenres(t *testing.T) {
+	repo := repositories.NewGenreRepository(testDB)
+	ctx := context.Background()
+
+	target := &models.Genre{Name: "Genre Repository Science Fiction"}
+	err := repo.Create(ctx, target)
+	require.NoError(t, err)
+
+	source := &models.Genre{Name: "Genre Repository Sci-Fi"}
+	err = repo.Create(ctx, source)
+	require.NoError(t, err)
+
+	subgenre := &models.Genre{Name: "Genre Repository Cyberpunk", ParentID: &source.ID}
+	err = repo.Create(ctx, subgenre)
+	require.NoError(t, err)
+
+	// Create Movie Dummy Data tagged with the duplicated genre
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	_, err = testDB.Exec("INSERT INTO movie_genres (movie_id, genre_id) VALUES (?, ?)", movie.ID, source.ID)
+	require.NoError(t, err)
+
+	err = repo.Merge(ctx, target.ID, []int64{source.ID})
+	assert.NoError(t, err)
+
+	// The movie is now tagged with the target genre
+	var count int
+	err = testDB.QueryRow("SELECT COUNT(*) FROM movie_genres WHERE movie_id = ? AND genre_id = ?", movie.ID, target.ID).Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// The subgenre moved under the target
+	found, err := repo.FindByID(ctx, subgenre.ID)
+	assert.NoError(t, err)
+	require.NotNil(t, found.ParentID)
+	assert.Equal(t, target.ID, *found.ParentID)
+
+	// Clean up test data
+	err = repo.Delete(ctx, subgenre.ID)
+	assert.NoError(t, err)
+	err = repo.Delete(ctx, target.ID)
+	assert.NoError(t, err)
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
@@ -0,0 +1,64 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestIdentityLifecycle(t *testing.T) {
+	repo := repositories.NewIdentityRepository(testDB)
+	ctx := context.Background()
+
+	// First login provisions the user with the identity
+	user := &models.User{
+		ID:           uuid.NewString(),
+		Username:     "oidctestdummy",
+		Email:        "oidctestdummy@example.com",
+		PasswordHash: "random-password",
+		Role:         models.RoleUser,
+	}
+	identity := &models.UserIdentity{ID: uuid.NewString(), UserID: user.ID, Provider: "mock", Subject: "sub-oidctestdummy"}
+	err := repo.CreateUserWithIdentity(ctx, user, identity)
+	require.NoError(t, err)
+
+	found, err := repo.FindIdentity(ctx, "mock", "sub-oidctestdummy")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, found.UserID)
+	assert.NotNil(t, found.LastLoginAt)
+
+	err = repo.TouchIdentity(ctx, identity.ID, "oidctestdummy@example.com")
+	assert.NoError(t, err)
+
+	// A second provider is linked to the same user
+	err = repo.LinkIdentity(ctx, &models.UserIdentity{ID: uuid.NewString(), UserID: user.ID, Provider: "google", Subject: "sub-oidctestdummy"})
+	assert.NoError(t, err)
+
+	// One account per provider and user
+	err = repo.LinkIdentity(ctx, &models.UserIdentity{ID: uuid.NewString(), UserID: user.ID, Provider: "mock", Subject: "sub-other"})
+	assert.Error(t, err)
+
+	identities, err := repo.ListIdentities(ctx, user.ID)
+	assert.NoError(t, err)
+	require.Len(t, identities, 2)
+	assert.Equal(t, "google", identities[0].Provider)
+	assert.Equal(t, "oidctestdummy@example.com", identities[1].Email)
+
+	_, err = repo.FindIdentity(ctx, "mock", "sub-unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up, the identities go with the user
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+
+	identities, err = repo.ListIdentities(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, identities)
+}
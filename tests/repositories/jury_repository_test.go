@@ -0,0 +1,93 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestJuryScoring(t *testing.T) {
+	editionRepo := repositories.NewEditionRepository(testDB)
+	competitionRepo := repositories.NewCompetitionRepository(testDB)
+	repo := repositories.NewJuryRepository(testDB)
+	ctx := context.Background()
+
+	edition := &models.Edition{
+		ID:       uuid.NewString(),
+		Name:     "jurytestdummy",
+		StartsOn: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+		Status:   models.EditionStatusOpen,
+	}
+	require.NoError(t, editionRepo.Create(ctx, edition))
+	section := &models.Section{ID: uuid.NewString(), EditionID: edition.ID, Name: "Main Competition"}
+	require.NoError(t, competitionRepo.CreateSection(ctx, section))
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+	require.NoError(t, editionRepo.EnterMovies(ctx, edition.ID, []string{movie.ID}))
+	require.NoError(t, competitionRepo.NominateMovies(ctx, section.ID, []string{movie.ID}))
+
+	juror, err := createUserDummy()
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AssignJuror(ctx, section.ID, juror.ID))
+	require.NoError(t, repo.AssignJuror(ctx, section.ID, juror.ID))
+	isJuror, err := repo.IsJuror(ctx, section.ID, juror.ID)
+	assert.NoError(t, err)
+	assert.True(t, isJuror)
+
+	sections, err := repo.ListJurorSections(ctx, juror.ID)
+	assert.NoError(t, err)
+	require.Len(t, sections, 1)
+	assert.Nil(t, sections[0].ScoringClosedAt)
+
+	criterion := &models.ScoringCriterion{ID: uuid.NewString(), SectionID: section.ID, Name: "Direction", Weight: 2.5}
+	require.NoError(t, repo.CreateCriterion(ctx, criterion))
+	found, err := repo.FindCriterionByName(ctx, section.ID, "Direction")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, found.Weight)
+
+	// Scoring the same criterion again replaces the score
+	require.NoError(t, repo.SaveScores(ctx, juror.ID, movie.ID, []models.CriterionScore{{CriterionID: criterion.ID, Score: 7}}))
+	require.NoError(t, repo.SaveScores(ctx, juror.ID, movie.ID, []models.CriterionScore{{CriterionID: criterion.ID, Score: 8.5}}))
+	scores, err := repo.ListScores(ctx, section.ID, juror.ID)
+	assert.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, 8.5, scores[0].Score)
+	assert.Equal(t, "usertestdummy", scores[0].Username)
+
+	scores, err = repo.ListScores(ctx, section.ID, "someone-else")
+	assert.NoError(t, err)
+	assert.Empty(t, scores)
+
+	require.NoError(t, repo.SetScoringClosed(ctx, section.ID, true))
+	closed, err := competitionRepo.FindSection(ctx, section.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, closed.ScoringClosedAt)
+
+	// Removing a juror removes their scores
+	require.NoError(t, repo.RemoveJuror(ctx, section.ID, juror.ID))
+	scores, err = repo.ListScores(ctx, section.ID, "")
+	assert.NoError(t, err)
+	assert.Empty(t, scores)
+
+	err = repo.RemoveJuror(ctx, section.ID, juror.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM festival_editions WHERE id = ?", edition.ID)
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", juror.ID)
+	require.NoError(t, err)
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestLockoutEvents(t *testing.T) {
+	repo := repositories.NewLockoutRepository(testDB)
+	ctx := context.Background()
+
+	admin, err := createUserDummy()
+	require.NoError(t, err)
+
+	lockedUntil := time.Now().Add(15 * time.Minute)
+	lock := &models.LockoutEvent{
+		Scope:          models.LockoutScopeUser,
+		Subject:        "lockouttestdummy",
+		Action:         models.LockoutActionLock,
+		IPAddress:      "10.0.0.1",
+		FailedAttempts: 5,
+		LockedUntil:    &lockedUntil,
+	}
+	err = repo.RecordEvent(ctx, lock)
+	assert.NoError(t, err)
+	assert.NotZero(t, lock.ID)
+
+	unlock := &models.LockoutEvent{
+		Scope:   models.LockoutScopeUser,
+		Subject: "lockouttestdummy",
+		Action:  models.LockoutActionUnlock,
+		AdminID: admin.ID,
+	}
+	err = repo.RecordEvent(ctx, unlock)
+	assert.NoError(t, err)
+
+	events, err := repo.ListEvents(ctx, models.LockoutScopeUser, "lockouttestdummy", 10, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 2) {
+		// Newest first
+		assert.Equal(t, unlock.ID, events[0].ID)
+		assert.Equal(t, admin.ID, events[0].AdminID)
+		assert.Nil(t, events[0].LockedUntil)
+		assert.Equal(t, "10.0.0.1", events[1].IPAddress)
+		assert.Equal(t, 5, events[1].FailedAttempts)
+		assert.NotNil(t, events[1].LockedUntil)
+	}
+
+	events, err = repo.ListEvents(ctx, models.LockoutScopeIP, "lockouttestdummy", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM login_lockout_events WHERE subject = ?", "lockouttestdummy")
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", admin.ID)
+	require.NoError(t, err)
+}
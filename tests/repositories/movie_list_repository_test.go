@@ -0,0 +1,85 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestMovieListLifecycle(t *testing.T) {
+	repo := repositories.NewMovieListRepository(testDB)
+	ctx := context.Background()
+
+	first, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	second, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	for _, movie := range []*models.Movie{first, second} {
+		err = repo.AddItem(ctx, user.ID, models.MovieListWatchlist, movie.ID)
+		require.NoError(t, err)
+	}
+
+	items, err := repo.ListItems(ctx, user.ID, models.MovieListWatchlist)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, first.ID, items[0].Movie.ID)
+		assert.Equal(t, second.ID, items[1].Movie.ID)
+	}
+
+	// The favorites list is kept apart from the watchlist
+	items, err = repo.ListItems(ctx, user.ID, models.MovieListFavorites)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	err = repo.Reorder(ctx, user.ID, models.MovieListWatchlist, []string{second.ID, first.ID})
+	assert.NoError(t, err)
+
+	items, err = repo.ListItems(ctx, user.ID, models.MovieListWatchlist)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, second.ID, items[0].Movie.ID)
+		assert.Equal(t, first.ID, items[1].Movie.ID)
+	}
+
+	err = repo.SetWatched(ctx, user.ID, models.MovieListWatchlist, first.ID, true)
+	assert.NoError(t, err)
+
+	item, err := repo.FindItem(ctx, user.ID, models.MovieListWatchlist, first.ID)
+	assert.NoError(t, err)
+	require.NotNil(t, item)
+	assert.True(t, item.Watched)
+	assert.NotNil(t, item.WatchedAt)
+
+	err = repo.SetWatched(ctx, user.ID, models.MovieListFavorites, first.ID, true)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.RemoveItem(ctx, user.ID, models.MovieListWatchlist, first.ID)
+	assert.NoError(t, err)
+
+	item, err = repo.FindItem(ctx, user.ID, models.MovieListWatchlist, first.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, item)
+
+	err = repo.RemoveItem(ctx, user.ID, models.MovieListWatchlist, first.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	for _, movie := range []*models.Movie{first, second} {
+		err = cleanDummyData(movie)
+		require.NoError(t, err)
+	}
+
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+}
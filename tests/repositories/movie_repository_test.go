@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stwrtrio/movie-festival/internal/models"
 	"github.com/stwrtrio/movie-festival/internal/repositories"
@@ -215,7 +216,7 @@ func TestGetMostViewedMovie(t *testing.T) {
 	}
 
 	// Call the repository function
-	result, err := repo.GetMostViewedMovie(context.Background())
+	result, err := repo.GetMostViewedMovie(context.Background(), models.StatsFilter{})
 
 	// Assert the result
 	assert.NoError(t, err)
@@ -230,6 +231,237 @@ func TestGetMostViewedMovie(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTrackMovieViewRollup(t *testing.T) {
+	repo := repositories.NewMovieRepository(testDB)
+	ctx := context.Background()
+
+	movie, err := createMovieDummyData()
+	assert.NoError(t, err)
+
+	viewedAt := time.Now().Add(-2 * time.Hour).Truncate(time.Hour)
+	views := []models.ViewEvent{
+		{MovieID: movie.ID, UserID: "user-1", IPAddress: "10.0.0.1", WatchDuration: 60, ViewedAt: viewedAt},
+		{MovieID: movie.ID, UserID: "user-1", IPAddress: "10.0.0.1", WatchDuration: 30, ViewedAt: viewedAt.Add(10 * time.Minute)},
+		{MovieID: movie.ID, SessionID: "session-1", IPAddress: "10.0.0.1", WatchDuration: 10, ViewedAt: viewedAt.Add(20 * time.Minute)},
+		// Anonymous views without a session are told apart by their address
+		{MovieID: movie.ID, IPAddress: "10.0.0.2", WatchDuration: 20, ViewedAt: viewedAt.Add(30 * time.Minute)},
+	}
+	for _, view := range views {
+		err = repo.TrackMovieView(ctx, view)
+		assert.NoError(t, err)
+	}
+
+	// The all-time counter is written in batches, a replayed batch is applied once
+	batchID := uuid.NewString()
+	for i := 0; i < 2; i++ {
+		err = repo.AddViewCounts(ctx, batchID, map[string]int64{movie.ID: 4})
+		assert.NoError(t, err)
+	}
+
+	var viewCount int
+	err = testDB.QueryRow("SELECT view_count FROM movie_views WHERE movie_id = ?", movie.ID).Scan(&viewCount)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, viewCount)
+
+	// Rolling up twice must not double count
+	for i := 0; i < 2; i++ {
+		err = repo.RollupViewStats(ctx, viewedAt)
+		assert.NoError(t, err)
+	}
+
+	stats, err := repo.GetMovieViewStats(ctx, movie.ID, models.GranularityHour, models.StatsFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, stats.Buckets, 1) {
+		assert.Equal(t, int64(4), stats.Buckets[0].Views)
+		assert.Equal(t, int64(3), stats.Buckets[0].UniqueViewers)
+		assert.Equal(t, int64(120), stats.Buckets[0].WatchSeconds)
+	}
+	assert.Equal(t, int64(4), stats.TotalViews)
+	assert.Equal(t, int64(3), stats.UniqueViewers)
+
+	// The windowed most viewed movie reads the hourly aggregates
+	from := viewedAt
+	result, err := repo.GetMostViewedMovie(ctx, models.StatsFilter{From: &from})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+
+	// Three of the four views came from the same address
+	reports, err := repo.GetSuspectedViewInflation(ctx, models.StatsFilter{From: &from}, 3)
+	assert.NoError(t, err)
+	for _, report := range reports {
+		if report.MovieID == movie.ID {
+			assert.Equal(t, "10.0.0.1", report.IPAddress)
+			assert.Equal(t, int64(3), report.Views)
+			assert.Equal(t, 0.75, report.Share)
+		}
+	}
+
+	// Clean up
+	err = repo.SoftDelete(ctx, movie.ID)
+	require.NoError(t, err)
+	err = repo.Purge(ctx, movie.ID)
+	require.NoError(t, err)
+
+	_, err = testDB.Exec("DELETE FROM movie_view_flushes WHERE batch_id = ?", batchID)
+	require.NoError(t, err)
+}
+
+func TestGetMovieDetail(t *testing.T) {
+	repo := repositories.NewMovieRepository(testDB)
+	ctx := context.Background()
+
+	// Create Movie Dummy Data
+	movie, err := createMovieDummyData()
+	assert.NoError(t, err)
+
+	_, err = testDB.Exec(`
+		INSERT INTO movie_views (movie_id, view_count, last_viewed_at)
+		VALUES (?, 25, NOW())
+	`, movie.ID)
+	require.NoError(t, err)
+
+	t.Run("Existing movie", func(t *testing.T) {
+		result, err := repo.GetMovieDetail(ctx, movie.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, movie.Title, result.Title)
+		assert.Equal(t, 25, result.Views)
+		assert.Equal(t, 0, result.Votes)
+		assert.Len(t, result.Genres, len(movie.Genres))
+		assert.NotNil(t, result.Artists)
+	})
+
+	t.Run("Missing movie", func(t *testing.T) {
+		result, err := repo.GetMovieDetail(ctx, "invalid-id")
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, result)
+	})
+
+	// Clean up test data
+	_, err = testDB.Exec("DELETE FROM movie_views WHERE movie_id = ?", movie.ID)
+	require.NoError(t, err)
+
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
+
+func TestSoftDeleteRestorePurge(t *testing.T) {
+	repo := repositories.NewMovieRepository(testDB)
+	ctx := context.Background()
+
+	// Create Movie Dummy Data
+	movie, err := createMovieDummyData()
+	assert.NoError(t, err)
+
+	_, err = testDB.Exec(`
+		INSERT INTO movie_views (movie_id, view_count, last_viewed_at)
+		VALUES (?, 10, NOW())
+	`, movie.ID)
+	require.NoError(t, err)
+
+	// Purging an active movie is not allowed
+	err = repo.Purge(ctx, movie.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Soft delete hides the movie
+	err = repo.SoftDelete(ctx, movie.ID)
+	assert.NoError(t, err)
+
+	_, err = repo.GetMovieDetail(ctx, movie.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Deleting twice reports a missing movie
+	err = repo.SoftDelete(ctx, movie.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Restore makes it visible again
+	err = repo.Restore(ctx, movie.ID)
+	assert.NoError(t, err)
+
+	_, err = repo.GetMovieDetail(ctx, movie.ID)
+	assert.NoError(t, err)
+
+	// Purge removes the movie and its related rows
+	err = repo.SoftDelete(ctx, movie.ID)
+	assert.NoError(t, err)
+
+	err = repo.Purge(ctx, movie.ID)
+	assert.NoError(t, err)
+
+	var count int
+	err = testDB.QueryRow("SELECT COUNT(*) FROM movies WHERE id = ?", movie.ID).Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	err = testDB.QueryRow("SELECT COUNT(*) FROM movie_views WHERE movie_id = ?", movie.ID).Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	err = testDB.QueryRow("SELECT COUNT(*) FROM movie_genres WHERE movie_id = ?", movie.ID).Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestMovieCreditsSearch(t *testing.T) {
+	repo := repositories.NewMovieRepository(testDB)
+	ctx := context.Background()
+
+	movie := &models.Movie{
+		ID:          uuid.NewString(),
+		Title:       "Credited Test Movie",
+		Description: "A movie with credits",
+		Duration:    100,
+		WatchURL:    "http://example.com/credited.mp4",
+		Genres:      []models.Genre{{Name: "Drama"}},
+		Artists: []models.Artist{
+			{ID: uuid.NewString(), Name: "Credit Test Director", Role: models.ArtistRoleDirector, BillingOrder: 1},
+			{ID: uuid.NewString(), Name: "Credit Test Actor", Role: models.ArtistRoleActor, BillingOrder: 2},
+		},
+	}
+	err := repo.Create(ctx, movie)
+	require.NoError(t, err)
+
+	t.Run("Credits are returned in billing order", func(t *testing.T) {
+		artists, err := repo.FindArtistsByMovieID(ctx, movie.ID)
+
+		assert.NoError(t, err)
+		require.Len(t, artists, 2)
+		assert.Equal(t, models.ArtistRoleDirector, artists[0].Role)
+		assert.Equal(t, models.ArtistRoleActor, artists[1].Role)
+	})
+
+	t.Run("Search movies directed by artist", func(t *testing.T) {
+		filter := models.MovieSearchFilter{Artist: "Credit Test Director", Role: models.ArtistRoleDirector}
+		movies, err := repo.SearchMovies(ctx, filter, 10, 0)
+
+		assert.NoError(t, err)
+		require.Len(t, movies, 1)
+		assert.Equal(t, movie.ID, movies[0].ID)
+	})
+
+	t.Run("Actor is not matched as director", func(t *testing.T) {
+		filter := models.MovieSearchFilter{Artist: "Credit Test Actor", Role: models.ArtistRoleDirector}
+		movies, err := repo.SearchMovies(ctx, filter, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, movies)
+	})
+
+	t.Run("LIKE wildcards match themselves", func(t *testing.T) {
+		for _, filter := range []models.MovieSearchFilter{{Query: "Credited_Test"}, {Artist: "Credit%Director"}} {
+			movies, err := repo.SearchMovies(ctx, filter, 10, 0)
+
+			assert.NoError(t, err)
+			assert.Empty(t, movies)
+		}
+	})
+
+	// Clean up test data
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
+
 func TestUnvoteMovie(t *testing.T) {
 	// Create a new repository instance with the test database
 	repo := repositories.NewMovieRepository(testDB)
@@ -293,6 +525,8 @@ func TestGetMoviesByIDs(t *testing.T) {
 		assert.NoError(t, err, "Unexpected error")
 		assert.Len(t, movies, 1)
 		assert.Equal(t, "Test Movie", movies[0].Title)
+		assert.Equal(t, 120, movies[0].Duration)
+		assert.Len(t, movies[0].Genres, 2)
 	})
 
 	t.Run("Invalid IDs", func(t *testing.T) {
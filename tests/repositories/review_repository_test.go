@@ -0,0 +1,118 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestReviewLifecycle(t *testing.T) {
+	repo := repositories.NewReviewRepository(testDB)
+	ctx := context.Background()
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	readerID := uuid.NewString()
+	_, err = testDB.Exec("INSERT INTO users (id, username, password_hash, role) VALUES (?, ?, ?, 'user')",
+		readerID, "reviewreaderdummy", user.PasswordHash)
+	require.NoError(t, err)
+
+	review := &models.Review{ID: uuid.NewString(), MovieID: movie.ID, UserID: user.ID, Rating: 8, Body: "Great pacing", Status: models.ReviewStatusApproved}
+	err = repo.Create(ctx, review)
+	require.NoError(t, err)
+
+	found, err := repo.FindByMovieAndUser(ctx, movie.ID, user.ID)
+	assert.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, user.Username, found.Username)
+
+	// Marking twice counts once
+	for i := 0; i < 2; i++ {
+		err = repo.MarkHelpful(ctx, review.ID, readerID)
+		assert.NoError(t, err)
+	}
+	found, err = repo.FindByID(ctx, review.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, found.HelpfulCount)
+
+	review.Rating = 6
+	err = repo.Update(ctx, review)
+	assert.NoError(t, err)
+
+	summary, err := repo.GetRatingSummary(ctx, movie.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), summary.RatingCount)
+	assert.Equal(t, 6.0, summary.AverageRating)
+	assert.Equal(t, int64(1), summary.Histogram[6])
+	assert.Len(t, summary.Histogram, 10)
+
+	reviews, err := repo.ListByMovie(ctx, movie.ID, models.ReviewSortHelpful, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1)
+
+	err = repo.UnmarkHelpful(ctx, review.ID, readerID)
+	assert.NoError(t, err)
+
+	// A report sends the review back to the queue and hides it
+	err = repo.Report(ctx, review.ID, readerID, "Spoilers", 1)
+	assert.NoError(t, err)
+
+	queue, err := repo.ListByStatus(ctx, models.ReviewStatusPending, 100, 0)
+	assert.NoError(t, err)
+	assert.Contains(t, reviewIDs(queue), review.ID)
+
+	reviews, err = repo.ListByMovie(ctx, movie.ID, models.ReviewSortNewest, 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, reviews)
+
+	// Approving resolves the report and is recorded in the audit trail
+	err = repo.Moderate(ctx, review.ID, user.ID, models.ModerationActionApprove, "No spoilers found")
+	assert.NoError(t, err)
+
+	found, err = repo.FindByID(ctx, review.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReviewStatusApproved, found.Status)
+	assert.Equal(t, 0, found.ReportCount)
+
+	actions, err := repo.ListModerationActions(ctx, review.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 1) {
+		assert.Equal(t, user.ID, actions[0].AdminID)
+		assert.Equal(t, models.ModerationActionApprove, actions[0].Action)
+	}
+
+	err = repo.Delete(ctx, review.ID)
+	assert.NoError(t, err)
+
+	_, err = repo.FindByID(ctx, review.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+
+	_, err = testDB.Exec("DELETE FROM review_moderation_actions WHERE review_id = ?", review.ID)
+	require.NoError(t, err)
+
+	_, err = testDB.Exec("DELETE FROM users WHERE id IN (?, ?)", user.ID, readerID)
+	require.NoError(t, err)
+}
+
+func reviewIDs(reviews []models.Review) []string {
+	ids := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.ID)
+	}
+	return ids
+}
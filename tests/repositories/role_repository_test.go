@@ -0,0 +1,84 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestRoleLifecycle(t *testing.T) {
+	repo := repositories.NewRoleRepository(testDB)
+	ctx := context.Background()
+
+	role := &models.Role{
+		Name:        "roletestdummy",
+		Description: "Catalog and analytics",
+		Permissions: []string{models.PermissionMovieWrite, models.PermissionAnalyticsRead},
+	}
+	err := repo.Create(ctx, role)
+	require.NoError(t, err)
+
+	found, err := repo.FindByName(ctx, "roletestdummy")
+	assert.NoError(t, err)
+	assert.Equal(t, "Catalog and analytics", found.Description)
+	assert.ElementsMatch(t, role.Permissions, found.Permissions)
+
+	// Update replaces the permissions
+	role.Permissions = []string{models.PermissionReviewModerate}
+	err = repo.Update(ctx, role)
+	assert.NoError(t, err)
+
+	roles, err := repo.List(ctx)
+	assert.NoError(t, err)
+	for _, listed := range roles {
+		if listed.Name == "roletestdummy" {
+			assert.Equal(t, []string{models.PermissionReviewModerate}, listed.Permissions)
+		}
+		if listed.Name == models.RoleUser {
+			assert.Empty(t, listed.Permissions)
+		}
+	}
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	err = repo.AssignToUser(ctx, user.ID, "roletestdummy")
+	assert.NoError(t, err)
+
+	count, err := repo.CountUsers(ctx, "roletestdummy")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	err = repo.AssignToUser(ctx, "not-a-user", "roletestdummy")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	userRole, err := repo.FindUserRole(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "roletestdummy", userRole)
+
+	_, err = repo.FindUserRole(ctx, "not-a-user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// A role still assigned to a user cannot be deleted
+	err = repo.Delete(ctx, "roletestdummy")
+	assert.ErrorIs(t, err, repositories.ErrRoleAssigned)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+
+	err = repo.Delete(ctx, "roletestdummy")
+	assert.NoError(t, err)
+
+	_, err = repo.FindByName(ctx, "roletestdummy")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.Delete(ctx, "roletestdummy")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
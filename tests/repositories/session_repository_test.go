@@ -0,0 +1,80 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestSessionLifecycle(t *testing.T) {
+	repo := repositories.NewSessionRepository(testDB)
+	ctx := context.Background()
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	session := &models.Session{
+		ID:         uuid.NewString(),
+		UserID:     user.ID,
+		UserAgent:  "test-agent",
+		IPAddress:  "10.0.0.1",
+		LastUsedAt: time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	err = repo.CreateSession(ctx, session, "first-token-hash")
+	require.NoError(t, err)
+
+	token, err := repo.FindRefreshToken(ctx, "first-token-hash")
+	assert.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, session.ID, token.SessionID)
+	assert.Equal(t, "test-agent", token.Session.UserAgent)
+	assert.Nil(t, token.UsedAt)
+
+	err = repo.RotateRefreshToken(ctx, token, "second-token-hash", models.SessionDevice{IPAddress: "10.0.0.2"}, time.Now().Add(2*time.Hour))
+	assert.NoError(t, err)
+
+	// A used token cannot be rotated again
+	err = repo.RotateRefreshToken(ctx, token, "third-token-hash", models.SessionDevice{}, time.Now().Add(2*time.Hour))
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	token, err = repo.FindRefreshToken(ctx, "first-token-hash")
+	assert.NoError(t, err)
+	assert.NotNil(t, token.UsedAt)
+
+	sessions, err := repo.ListActiveSessions(ctx, user.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, "10.0.0.2", sessions[0].IPAddress)
+		assert.Equal(t, "test-agent", sessions[0].UserAgent)
+	}
+
+	err = repo.RevokeSession(ctx, user.ID, session.ID)
+	assert.NoError(t, err)
+
+	err = repo.RevokeSession(ctx, user.ID, session.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	sessions, err = repo.ListActiveSessions(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	revoked, err := repo.RevokeAllSessions(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revoked)
+
+	_, err = repo.FindRefreshToken(ctx, "unknown-token-hash")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up, sessions and refresh tokens are removed with the user
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+}
@@ -2,10 +2,13 @@ package repositories_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/stwrtrio/movie-festival/internal/models"
@@ -128,3 +131,161 @@ func TestGetUserByUsername(t *testing.T) {
 	})
 
 }
+
+func TestPasswordReset(t *testing.T) {
+	repo := repositories.NewUserRepository(testDB)
+	ctx := context.Background()
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	first := &models.PasswordResetToken{ID: uuid.NewString(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	err = repo.CreatePasswordResetToken(ctx, first, "first-reset-hash")
+	assert.NoError(t, err)
+
+	// A newer token replaces the unused ones
+	second := &models.PasswordResetToken{ID: uuid.NewString(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	err = repo.CreatePasswordResetToken(ctx, second, "second-reset-hash")
+	assert.NoError(t, err)
+
+	token, err := repo.FindPasswordResetToken(ctx, "first-reset-hash")
+	assert.NoError(t, err)
+	assert.NotNil(t, token.UsedAt)
+
+	err = repo.ResetPassword(ctx, token, "New-password1")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	token, err = repo.FindPasswordResetToken(ctx, "second-reset-hash")
+	assert.NoError(t, err)
+	assert.Nil(t, token.UsedAt)
+
+	err = repo.ResetPassword(ctx, token, "New-password1")
+	assert.NoError(t, err)
+
+	// A token resets the password once
+	err = repo.ResetPassword(ctx, token, "Other-password2")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	updated, err := repo.GetUserByID(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(updated.PasswordHash), []byte("New-password1")))
+
+	_, err = repo.FindPasswordResetToken(ctx, "unknown-reset-hash")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up, reset tokens are removed with the user
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+}
+
+func TestMFAEnrollment(t *testing.T) {
+	repo := repositories.NewUserRepository(testDB)
+	ctx := context.Background()
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	// Nothing to confirm before the setup
+	err = repo.EnableMFA(ctx, user.ID, []string{"code-hash-1"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.SetMFASecret(ctx, user.ID, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	assert.NoError(t, err)
+
+	err = repo.EnableMFA(ctx, user.ID, []string{"code-hash-1", "code-hash-2"})
+	assert.NoError(t, err)
+
+	enrolled, err := repo.GetUserByID(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", enrolled.MFASecret)
+	assert.True(t, enrolled.MFAEnabled())
+
+	// The secret of an enabled user cannot be replaced by a new setup
+	err = repo.SetMFASecret(ctx, user.ID, "JBSWY3DPEHPK3PXP")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.UseRecoveryCode(ctx, user.ID, "code-hash-1")
+	assert.NoError(t, err)
+
+	// A recovery code works once
+	err = repo.UseRecoveryCode(ctx, user.ID, "code-hash-1")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.ReplaceRecoveryCodes(ctx, user.ID, []string{"code-hash-3"})
+	assert.NoError(t, err)
+
+	err = repo.UseRecoveryCode(ctx, user.ID, "code-hash-2")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.DisableMFA(ctx, user.ID)
+	assert.NoError(t, err)
+
+	disabled, err := repo.GetUserByID(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, disabled.MFASecret)
+	assert.False(t, disabled.MFAEnabled())
+
+	err = repo.UseRecoveryCode(ctx, user.ID, "code-hash-3")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+}
+
+func TestUserAccountStatus(t *testing.T) {
+	repo := repositories.NewUserRepository(testDB)
+	ctx := context.Background()
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	err = repo.SetDisabled(ctx, user.ID, true)
+	assert.NoError(t, err)
+
+	// Disabling twice changes nothing
+	err = repo.SetDisabled(ctx, user.ID, true)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	users, err := repo.ListUsers(ctx, models.UserFilter{Query: "usertestdummy", Status: models.UserStatusDisabled, Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.True(t, users[0].Disabled())
+	}
+
+	users, err = repo.ListUsers(ctx, models.UserFilter{Query: "usertestdummy", Status: models.UserStatusActive, Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	// The LIKE wildcards of the query match themselves
+	users, err = repo.ListUsers(ctx, models.UserFilter{Query: "usertest_ummy", Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	users, err = repo.ListUsers(ctx, models.UserFilter{Query: "%", Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	err = repo.SetDisabled(ctx, user.ID, false)
+	assert.NoError(t, err)
+
+	err = repo.RequirePasswordReset(ctx, user.ID)
+	assert.NoError(t, err)
+
+	detail, err := repo.GetUserDetail(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, detail.Disabled())
+	assert.True(t, detail.PasswordResetRequired)
+	assert.Zero(t, detail.Votes)
+	assert.Nil(t, detail.LastActiveAt)
+
+	votes, err := repo.ListUserVotes(ctx, user.ID, 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, votes)
+
+	_, err = repo.GetUserDetail(ctx, "not-a-user")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+}
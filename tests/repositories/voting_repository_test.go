@@ -0,0 +1,215 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestVotingPeriods(t *testing.T) {
+	editionRepo := repositories.NewEditionRepository(testDB)
+	competitionRepo := repositories.NewCompetitionRepository(testDB)
+	movieRepo := repositories.NewMovieRepository(testDB)
+	repo := repositories.NewVotingRepository(testDB)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	edition := &models.Edition{
+		ID:       uuid.NewString(),
+		Name:     "votingtestdummy",
+		StartsOn: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+		Status:   models.EditionStatusOpen,
+	}
+	require.NoError(t, editionRepo.Create(ctx, edition))
+	section := &models.Section{ID: uuid.NewString(), EditionID: edition.ID, Name: "Main Competition"}
+	require.NoError(t, competitionRepo.CreateSection(ctx, section))
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+	require.NoError(t, editionRepo.EnterMovies(ctx, edition.ID, []string{movie.ID}))
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	budget := 1
+	editionPeriod := &models.VotingPeriod{ID: uuid.NewString(), EditionID: edition.ID, OpensAt: now.Add(-time.Hour), ClosesAt: now.Add(time.Hour),
+		BallotMode: models.BallotModeApproval, VoteBudget: &budget}
+	sectionPeriod := &models.VotingPeriod{ID: uuid.NewString(), EditionID: edition.ID, SectionID: section.ID, OpensAt: now.Add(-time.Hour), ClosesAt: now.Add(2 * time.Hour),
+		BallotMode: models.BallotModeApproval}
+	require.NoError(t, repo.CreatePeriod(ctx, editionPeriod))
+	require.NoError(t, repo.CreatePeriod(ctx, sectionPeriod))
+
+	// The section period only covers its nominees
+	periods, err := repo.ListOpenPeriodsForMovie(ctx, movie.ID, now)
+	assert.NoError(t, err)
+	require.Len(t, periods, 1)
+	assert.Equal(t, editionPeriod.ID, periods[0].ID)
+	require.NotNil(t, periods[0].VoteBudget)
+	assert.Equal(t, 1, *periods[0].VoteBudget)
+
+	require.NoError(t, competitionRepo.NominateMovies(ctx, section.ID, []string{movie.ID}))
+	periods, err = repo.ListOpenPeriodsForMovie(ctx, movie.ID, now)
+	assert.NoError(t, err)
+	require.Len(t, periods, 2)
+	assert.Equal(t, sectionPeriod.ID, periods[0].ID)
+	assert.Equal(t, "Main Competition", periods[0].SectionName)
+
+	periods, err = repo.ListOpenPeriodsForMovie(ctx, movie.ID, now.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, periods)
+
+	// The budget stops the second vote in the period
+	cast, err := repo.CastVote(ctx, user.ID, movie.ID, editionPeriod)
+	require.NoError(t, err)
+	assert.True(t, cast)
+	cast, err = repo.CastVote(ctx, user.ID, movie.ID, editionPeriod)
+	assert.NoError(t, err)
+	assert.False(t, cast)
+
+	used, err := repo.CountVotes(ctx, editionPeriod.ID, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, used)
+
+	// A user votes for a movie once per period
+	vote, err := repo.FindVote(ctx, user.ID, movie.ID, []string{sectionPeriod.ID, editionPeriod.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, editionPeriod.ID, vote.VotingPeriodID)
+	_, err = repo.FindVote(ctx, user.ID, movie.ID, []string{sectionPeriod.ID})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	cast, err = repo.CastVote(ctx, user.ID, movie.ID, sectionPeriod)
+	require.NoError(t, err)
+	assert.True(t, cast)
+	_, err = repo.CastVote(ctx, user.ID, movie.ID, sectionPeriod)
+	assert.ErrorIs(t, err, repositories.ErrVoteExists)
+	_, err = testDB.Exec("DELETE FROM votes WHERE voting_period_id = ?", sectionPeriod.ID)
+	require.NoError(t, err)
+
+	approvals, err := repo.ListApprovals(ctx, editionPeriod.ID)
+	assert.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, models.TallyResult{MovieID: movie.ID, Title: movie.Title, Score: 1}, approvals[0])
+
+	vote, err = movieRepo.GetVoteByUserAndMovie(ctx, user.ID, movie.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, editionPeriod.ID, vote.VotingPeriodID)
+
+	editionPeriod.VoteBudget = nil
+	require.NoError(t, repo.UpdatePeriod(ctx, editionPeriod))
+	found, err := repo.FindPeriod(ctx, editionPeriod.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found.VoteBudget)
+
+	// Deleting a period keeps its votes
+	require.NoError(t, repo.DeletePeriod(ctx, editionPeriod.ID))
+	err = repo.DeletePeriod(ctx, editionPeriod.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	vote, err = movieRepo.GetVoteByUserAndMovie(ctx, user.ID, movie.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vote.ID)
+	assert.Empty(t, vote.VotingPeriodID)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM votes WHERE user_id = ?", user.ID)
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM festival_editions WHERE id = ?", edition.ID)
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
+
+func TestRankedBallots(t *testing.T) {
+	editionRepo := repositories.NewEditionRepository(testDB)
+	repo := repositories.NewVotingRepository(testDB)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	edition := &models.Edition{
+		ID:       uuid.NewString(),
+		Name:     "ballottestdummy",
+		StartsOn: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+		Status:   models.EditionStatusOpen,
+	}
+	require.NoError(t, editionRepo.Create(ctx, edition))
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+	require.NoError(t, editionRepo.EnterMovies(ctx, edition.ID, []string{movie.ID}))
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	period := &models.VotingPeriod{ID: uuid.NewString(), EditionID: edition.ID, OpensAt: now.Add(-time.Hour), ClosesAt: now.Add(time.Hour),
+		BallotMode: models.BallotModeRanked, MaxRankings: 3}
+	require.NoError(t, repo.CreatePeriod(ctx, period))
+
+	found, err := repo.FindPeriod(ctx, period.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BallotModeRanked, found.BallotMode)
+	assert.Equal(t, 3, found.MaxRankings)
+
+	// Ranked periods do not take approval votes
+	periods, err := repo.ListOpenPeriodsForMovie(ctx, movie.ID, now)
+	assert.NoError(t, err)
+	assert.Empty(t, periods)
+
+	eligible, err := repo.CountEligibleMovies(ctx, period.ID, []string{movie.ID, "unknown-movie"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, eligible)
+
+	cast, err := repo.HasBallots(ctx, period.ID)
+	assert.NoError(t, err)
+	assert.False(t, cast)
+
+	// Casting the ballot again replaces it
+	ballot := &models.Ballot{VotingPeriodID: period.ID, UserID: user.ID, Rankings: []models.BallotRanking{{MovieID: movie.ID}}}
+	require.NoError(t, repo.SaveBallot(ctx, ballot))
+	require.NoError(t, repo.SaveBallot(ctx, ballot))
+
+	saved, err := repo.FindBallot(ctx, period.ID, user.ID)
+	require.NoError(t, err)
+	require.Len(t, saved.Rankings, 1)
+	assert.Equal(t, 1, saved.Rankings[0].Position)
+	assert.Equal(t, movie.Title, saved.Rankings[0].Title)
+
+	ballots, err := repo.ListBallots(ctx, period.ID)
+	assert.NoError(t, err)
+	require.Len(t, ballots, 1)
+	assert.Equal(t, saved.ID, ballots[0].ID)
+
+	cast, err = repo.HasBallots(ctx, period.ID)
+	assert.NoError(t, err)
+	assert.True(t, cast)
+
+	// A withdrawn movie no longer counts in the tally
+	require.NoError(t, editionRepo.WithdrawMovie(ctx, edition.ID, movie.ID))
+	ballots, err = repo.ListBallots(ctx, period.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, ballots)
+
+	require.NoError(t, repo.DeleteBallot(ctx, period.ID, user.ID))
+	_, err = repo.FindBallot(ctx, period.ID, user.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	err = repo.DeleteBallot(ctx, period.ID, user.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Clean up
+	_, err = testDB.Exec("DELETE FROM festival_editions WHERE id = ?", edition.ID)
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+	err = cleanDummyData(movie)
+	require.NoError(t, err)
+}
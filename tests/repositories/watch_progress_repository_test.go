@@ -0,0 +1,79 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+)
+
+func TestWatchProgressLifecycle(t *testing.T) {
+	repo := repositories.NewWatchProgressRepository(testDB)
+	movieRepo := repositories.NewMovieRepository(testDB)
+	ctx := context.Background()
+
+	movie, err := createMovieDummyData()
+	require.NoError(t, err)
+
+	user, err := createUserDummy()
+	require.NoError(t, err)
+
+	progress, err := repo.FindProgress(ctx, user.ID, movie.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, progress)
+
+	err = repo.SaveProgress(ctx, &models.WatchProgress{UserID: user.ID, MovieID: movie.ID, PositionSeconds: 600})
+	assert.NoError(t, err)
+
+	entries, err := repo.ListInProgress(ctx, user.ID, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, movie.ID, entries[0].MovieID)
+		assert.Equal(t, 600, entries[0].PositionSeconds)
+	}
+
+	// Reporting the completion twice records it once
+	startedAt := time.Now().Add(-time.Minute)
+	for _, position := range []int{6600, 7000} {
+		err = repo.SaveProgress(ctx, &models.WatchProgress{UserID: user.ID, MovieID: movie.ID, PositionSeconds: position, Completed: true})
+		assert.NoError(t, err)
+	}
+
+	progress, err = repo.FindProgress(ctx, user.ID, movie.ID)
+	assert.NoError(t, err)
+	require.NotNil(t, progress)
+	assert.True(t, progress.Completed)
+	assert.Equal(t, 7000, progress.PositionSeconds)
+
+	entries, err = repo.ListInProgress(ctx, user.ID, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Seeking back below the threshold and forward again does not record another completion
+	err = repo.SaveProgress(ctx, &models.WatchProgress{UserID: user.ID, MovieID: movie.ID, PositionSeconds: 3000})
+	assert.NoError(t, err)
+	err = repo.SaveProgress(ctx, &models.WatchProgress{UserID: user.ID, MovieID: movie.ID, PositionSeconds: 6800, Completed: true})
+	assert.NoError(t, err)
+
+	// Completions feed the view statistics
+	err = movieRepo.RollupViewStats(ctx, startedAt)
+	assert.NoError(t, err)
+
+	stats, err := movieRepo.GetMovieViewStats(ctx, movie.ID, models.GranularityDay, models.StatsFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Completions)
+
+	// Clean up
+	err = movieRepo.SoftDelete(ctx, movie.ID)
+	require.NoError(t, err)
+	err = movieRepo.Purge(ctx, movie.ID)
+	require.NoError(t, err)
+
+	_, err = testDB.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+}
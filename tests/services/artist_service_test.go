@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCreateArtist(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name          string
+		request       models.ArtistRequest
+		mockSetup     func(mockRepo *mocks.MockArtistRepository)
+		expectedError error
+	}{
+		{
+			name: "Success - Artist created",
+			request: models.ArtistRequest{
+				Name:        "Christopher Nolan",
+				BirthDate:   "1970-07-30",
+				ExternalIDs: map[string]string{"imdb": "nm0634240"},
+			},
+			mockSetup: func(mockRepo *mocks.MockArtistRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Christopher Nolan").Return(nil, nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Failure - Artist name already exists",
+			request: models.ArtistRequest{Name: "Christopher Nolan"},
+			mockSetup: func(mockRepo *mocks.MockArtistRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Christopher Nolan").Return(&models.ArtistProfile{ID: "artist1"}, nil)
+			},
+			expectedError: services.ErrArtistExists,
+		},
+		{
+			name:    "Failure - Invalid birth date",
+			request: models.ArtistRequest{Name: "Christopher Nolan", BirthDate: "30-07-1970"},
+			mockSetup: func(mockRepo *mocks.MockArtistRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Christopher Nolan").Return(nil, nil)
+			},
+			expectedError: services.ErrInvalidArtistDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new gomock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock repository
+			mockRepo := mocks.NewMockArtistRepository(ctrl)
+			tt.mockSetup(mockRepo)
+
+			// Create the service
+			artistService := services.NewArtistService(mockRepo)
+
+			// Execute the service method
+			artist, err := artistService.CreateArtist(context.TODO(), tt.request)
+
+			// Assert the result
+			if tt.expectedError != nil {
+				assert.Nil(t, artist)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, artist.ID)
+				assert.Equal(t, tt.request.Name, artist.Name)
+				assert.NotNil(t, artist.BirthDate)
+			}
+		})
+	}
+}
+
+func TestUpdateArtist(t *testing.T) {
+	t.Run("Renaming onto another artist is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockArtistRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), "Jane Doe").Return(&models.ArtistProfile{ID: "artist2"}, nil)
+
+		artistService := services.NewArtistService(mockRepo)
+		_, err := artistService.UpdateArtist(context.TODO(), "artist1", models.ArtistRequest{Name: "Jane Doe"})
+		assert.ErrorIs(t, err, services.ErrArtistExists)
+	})
+
+	t.Run("Unknown artist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockArtistRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), "Jane Doe").Return(nil, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		artistService := services.NewArtistService(mockRepo)
+		_, err := artistService.UpdateArtist(context.TODO(), "missing", models.ArtistRequest{Name: "Jane Doe"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestMergeArtists(t *testing.T) {
+	t.Run("Success - Duplicates merged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockArtistRepository(ctrl)
+		mockRepo.EXPECT().Merge(gomock.Any(), "artist1", []string{"artist2", "artist3"}).Return(nil)
+
+		artistService := services.NewArtistService(mockRepo)
+		err := artistService.MergeArtists(context.TODO(), "artist1", []string{"artist2", "artist3"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure - Merge into itself", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockArtistRepository(ctrl)
+
+		artistService := services.NewArtistService(mockRepo)
+		err := artistService.MergeArtists(context.TODO(), "artist1", []string{"artist1"})
+		assert.ErrorIs(t, err, services.ErrMergeSelf)
+	})
+}
+
+func TestGetArtistFilmography(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockArtistRepository(ctrl)
+	mockRepo.EXPECT().FindByID(gomock.Any(), "artist1").Return(&models.ArtistProfile{ID: "artist1", Name: "Christopher Nolan"}, nil)
+	mockRepo.EXPECT().GetFilmography(gomock.Any(), "artist1").Return([]models.FilmographyItem{
+		{MovieID: "movie1", Title: "Inception", Roles: []string{"director", "writer"}, Views: 100, Votes: 10},
+		{MovieID: "movie2", Title: "Interstellar", Roles: []string{"director"}, Views: 50, Votes: 5},
+	}, nil)
+
+	artistService := services.NewArtistService(mockRepo)
+	filmography, err := artistService.GetArtistFilmography(context.TODO(), "artist1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Christopher Nolan", filmography.Name)
+	assert.Len(t, filmography.Movies, 2)
+	assert.Equal(t, int64(150), filmography.TotalViews)
+	assert.Equal(t, int64(15), filmography.TotalVotes)
+}
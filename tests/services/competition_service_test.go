@@ -0,0 +1,181 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCreateSection(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		editionID     string
+		mockSetup     func(mockRepo *mocks.MockCompetitionRepository, mockEditionRepo *mocks.MockEditionRepository)
+		expectedError error
+	}{
+		{
+			name:      "Success - Section created",
+			editionID: "edition1",
+			mockSetup: func(mockRepo *mocks.MockCompetitionRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusOpen}, nil)
+				mockRepo.EXPECT().FindSectionByName(gomock.Any(), "edition1", "Shorts").Return(nil, nil)
+				mockRepo.EXPECT().CreateSection(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, section *models.Section) error {
+					assert.Equal(t, "edition1", section.EditionID)
+					return nil
+				})
+				mockRepo.EXPECT().FindSection(gomock.Any(), gomock.Any()).Return(&models.Section{EditionID: "edition1", Name: "Shorts"}, nil)
+			},
+		},
+		{
+			name:      "Failure - Name taken in the edition",
+			editionID: "edition1",
+			mockSetup: func(mockRepo *mocks.MockCompetitionRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusDraft}, nil)
+				mockRepo.EXPECT().FindSectionByName(gomock.Any(), "edition1", "Shorts").Return(&models.Section{ID: "section1"}, nil)
+			},
+			expectedError: services.ErrSectionExists,
+		},
+		{
+			name:      "Failure - Edition closed",
+			editionID: "edition2",
+			mockSetup: func(mockRepo *mocks.MockCompetitionRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition2").Return(&models.Edition{ID: "edition2", Status: models.EditionStatusClosed}, nil)
+			},
+			expectedError: services.ErrEditionClosed,
+		},
+		{
+			name:      "Failure - Edition not found",
+			editionID: "edition3",
+			mockSetup: func(mockRepo *mocks.MockCompetitionRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition3").Return(nil, sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockCompetitionRepository(ctrl)
+			mockEditionRepo := mocks.NewMockEditionRepository(ctrl)
+			tc.mockSetup(mockRepo, mockEditionRepo)
+
+			competitionService := services.NewCompetitionService(mockRepo, mockEditionRepo)
+			section, err := competitionService.CreateSection(context.Background(), tc.editionID, models.SectionRequest{Name: "Shorts"})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, section)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Shorts", section.Name)
+			}
+		})
+	}
+}
+
+func TestNominateMovies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCompetitionRepository(ctrl)
+	mockEditionRepo := mocks.NewMockEditionRepository(ctrl)
+	competitionService := services.NewCompetitionService(mockRepo, mockEditionRepo)
+	ctx := context.Background()
+
+	section := &models.Section{ID: "section1", EditionID: "edition1"}
+	open := &models.Edition{ID: "edition1", Status: models.EditionStatusOpen}
+	closed := &models.Edition{ID: "edition1", Status: models.EditionStatusClosed}
+
+	mockRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(section, nil)
+	mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().NominateMovies(gomock.Any(), "section1", []string{"movie1"}).Return(nil)
+	assert.NoError(t, competitionService.NominateMovies(ctx, "section1", []string{"movie1"}))
+
+	// Only the entries of the edition can be nominated
+	mockRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(section, nil)
+	mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().NominateMovies(gomock.Any(), "section1", []string{"movie2"}).Return(sql.ErrNoRows)
+	assert.ErrorIs(t, competitionService.NominateMovies(ctx, "section1", []string{"movie2"}), services.ErrMovieNotEntered)
+
+	mockRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(section, nil)
+	mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(closed, nil)
+	assert.ErrorIs(t, competitionService.NominateMovies(ctx, "section1", []string{"movie1"}), services.ErrEditionClosed)
+
+	mockRepo.EXPECT().FindSection(gomock.Any(), "section2").Return(nil, sql.ErrNoRows)
+	assert.ErrorIs(t, competitionService.NominateMovies(ctx, "section2", []string{"movie1"}), services.ErrSectionNotExists)
+
+	mockRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(section, nil)
+	mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().RemoveNomination(gomock.Any(), "section1", "movie2").Return(sql.ErrNoRows)
+	assert.ErrorIs(t, competitionService.RemoveNomination(ctx, "section1", "movie2"), services.ErrMovieNotNominated)
+}
+
+func TestAddWinner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCompetitionRepository(ctrl)
+	mockEditionRepo := mocks.NewMockEditionRepository(ctrl)
+	competitionService := services.NewCompetitionService(mockRepo, mockEditionRepo)
+	ctx := context.Background()
+
+	category := &models.AwardCategory{ID: "category1", SectionID: "section1"}
+
+	mockRepo.EXPECT().FindCategory(gomock.Any(), "category1").Return(category, nil)
+	mockRepo.EXPECT().AddWinner(gomock.Any(), "category1", "movie1").Return(nil)
+	assert.NoError(t, competitionService.AddWinner(ctx, "category1", "movie1"))
+
+	mockRepo.EXPECT().FindCategory(gomock.Any(), "category1").Return(category, nil)
+	mockRepo.EXPECT().AddWinner(gomock.Any(), "category1", "movie2").Return(sql.ErrNoRows)
+	assert.ErrorIs(t, competitionService.AddWinner(ctx, "category1", "movie2"), services.ErrMovieNotNominated)
+
+	mockRepo.EXPECT().FindCategory(gomock.Any(), "category2").Return(nil, sql.ErrNoRows)
+	assert.ErrorIs(t, competitionService.AddWinner(ctx, "category2", "movie1"), services.ErrAwardCategoryNotExists)
+
+	mockRepo.EXPECT().FindCategory(gomock.Any(), "category1").Return(category, nil)
+	mockRepo.EXPECT().RemoveWinner(gomock.Any(), "category1", "movie2").Return(sql.ErrNoRows)
+	assert.ErrorIs(t, competitionService.RemoveWinner(ctx, "category1", "movie2"), services.ErrMovieNotAwarded)
+}
+
+func TestListAwards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCompetitionRepository(ctrl)
+	mockEditionRepo := mocks.NewMockEditionRepository(ctrl)
+	competitionService := services.NewCompetitionService(mockRepo, mockEditionRepo)
+	ctx := context.Background()
+
+	winners := []models.AwardWinner{
+		{EditionID: "edition2", SectionID: "main", CategoryID: "best-film", Winner: models.Winner{MovieID: "movie1"}},
+		{EditionID: "edition2", SectionID: "main", CategoryID: "jury-prize", Winner: models.Winner{MovieID: "movie2"}},
+		{EditionID: "edition2", SectionID: "main", CategoryID: "jury-prize", Winner: models.Winner{MovieID: "movie3"}},
+		{EditionID: "edition2", SectionID: "shorts", CategoryID: "best-short", Winner: models.Winner{MovieID: "movie4"}},
+		{EditionID: "edition1", SectionID: "main-2025", CategoryID: "best-film-2025", Winner: models.Winner{MovieID: "movie5"}},
+	}
+	mockRepo.EXPECT().ListWinners(gomock.Any(), "", models.EditionStatusOpen, models.EditionStatusClosed).Return(winners, nil)
+
+	awards, err := competitionService.ListAwards(ctx, "")
+	assert.NoError(t, err)
+	assert.Len(t, awards, 2)
+	assert.Len(t, awards[0].Sections, 2)
+	assert.Len(t, awards[0].Sections[0].Awards, 2)
+	assert.Len(t, awards[0].Sections[0].Awards[1].Winners, 2)
+	assert.Equal(t, "best-short", awards[0].Sections[1].Awards[0].CategoryID)
+	assert.Equal(t, "movie5", awards[1].Sections[0].Awards[0].Winners[0].MovieID)
+
+	// Drafts are hidden from the audience
+	mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition3").Return(&models.Edition{ID: "edition3", Status: models.EditionStatusDraft}, nil)
+	_, err = competitionService.ListAwards(ctx, "edition3")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
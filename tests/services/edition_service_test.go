@@ -0,0 +1,176 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCreateEdition(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		request       models.EditionRequest
+		mockSetup     func(mockRepo *mocks.MockEditionRepository)
+		expectedError error
+	}{
+		{
+			name:    "Success - Draft edition created",
+			request: models.EditionRequest{Name: "Movie Festival 2026", StartsOn: "2026-11-01", EndsOn: "2026-11-10"},
+			mockSetup: func(mockRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Movie Festival 2026").Return(nil, nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, edition *models.Edition) error {
+					assert.Equal(t, models.EditionStatusDraft, edition.Status)
+					assert.Equal(t, time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC), edition.EndsOn)
+					return nil
+				})
+				mockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&models.Edition{Name: "Movie Festival 2026", Status: models.EditionStatusDraft}, nil)
+			},
+		},
+		{
+			name:    "Failure - Name taken",
+			request: models.EditionRequest{Name: "Movie Festival 2026", StartsOn: "2026-11-01", EndsOn: "2026-11-10"},
+			mockSetup: func(mockRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Movie Festival 2026").Return(&models.Edition{ID: "edition1"}, nil)
+			},
+			expectedError: services.ErrEditionExists,
+		},
+		{
+			name:    "Failure - Ends before it starts",
+			request: models.EditionRequest{Name: "Movie Festival 2026", StartsOn: "2026-11-10", EndsOn: "2026-11-01"},
+			mockSetup: func(mockRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Movie Festival 2026").Return(nil, nil)
+			},
+			expectedError: services.ErrInvalidEditionDates,
+		},
+		{
+			name:    "Failure - Invalid date",
+			request: models.EditionRequest{Name: "Movie Festival 2026", StartsOn: "01/11/2026", EndsOn: "2026-11-10"},
+			mockSetup: func(mockRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Movie Festival 2026").Return(nil, nil)
+			},
+			expectedError: services.ErrInvalidEditionDate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockEditionRepository(ctrl)
+			tc.mockSetup(mockRepo)
+
+			editionService := services.NewEditionService(mockRepo)
+			edition, err := editionService.CreateEdition(context.Background(), tc.request)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, edition)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.EditionStatusDraft, edition.Status)
+			}
+		})
+	}
+}
+
+func TestOpenAndCloseEdition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEditionRepository(ctrl)
+	editionService := services.NewEditionService(mockRepo)
+	ctx := context.Background()
+
+	draft := &models.Edition{ID: "edition1", Status: models.EditionStatusDraft}
+	open := &models.Edition{ID: "edition1", Status: models.EditionStatusOpen}
+	closed := &models.Edition{ID: "edition1", Status: models.EditionStatusClosed}
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(draft, nil)
+	mockRepo.EXPECT().SetStatus(gomock.Any(), "edition1", models.EditionStatusOpen).Return(nil)
+	assert.NoError(t, editionService.OpenEdition(ctx, "edition1"))
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	assert.ErrorIs(t, editionService.OpenEdition(ctx, "edition1"), services.ErrEditionAlreadyOpen)
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().SetStatus(gomock.Any(), "edition1", models.EditionStatusClosed).Return(nil)
+	assert.NoError(t, editionService.CloseEdition(ctx, "edition1"))
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(draft, nil)
+	assert.ErrorIs(t, editionService.CloseEdition(ctx, "edition1"), services.ErrEditionNotOpen)
+
+	// A closed edition can be reopened
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(closed, nil)
+	mockRepo.EXPECT().SetStatus(gomock.Any(), "edition1", models.EditionStatusOpen).Return(nil)
+	assert.NoError(t, editionService.OpenEdition(ctx, "edition1"))
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition2").Return(nil, sql.ErrNoRows)
+	assert.ErrorIs(t, editionService.OpenEdition(ctx, "edition2"), sql.ErrNoRows)
+}
+
+func TestEnterMovies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEditionRepository(ctrl)
+	editionService := services.NewEditionService(mockRepo)
+	ctx := context.Background()
+
+	open := &models.Edition{ID: "edition1", Status: models.EditionStatusOpen}
+	closed := &models.Edition{ID: "edition2", Status: models.EditionStatusClosed}
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().EnterMovies(gomock.Any(), "edition1", []string{"movie1", "movie2"}).Return(nil)
+	assert.NoError(t, editionService.EnterMovies(ctx, "edition1", []string{"movie1", "movie2"}))
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().EnterMovies(gomock.Any(), "edition1", []string{"movie3"}).Return(sql.ErrNoRows)
+	assert.ErrorIs(t, editionService.EnterMovies(ctx, "edition1", []string{"movie3"}), services.ErrEditionMovieNotExists)
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition2").Return(closed, nil)
+	assert.ErrorIs(t, editionService.EnterMovies(ctx, "edition2", []string{"movie1"}), services.ErrEditionClosed)
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(open, nil)
+	mockRepo.EXPECT().WithdrawMovie(gomock.Any(), "edition1", "movie3").Return(sql.ErrNoRows)
+	assert.ErrorIs(t, editionService.WithdrawMovie(ctx, "edition1", "movie3"), services.ErrMovieNotEntered)
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition2").Return(closed, nil)
+	assert.ErrorIs(t, editionService.WithdrawMovie(ctx, "edition2", "movie1"), services.ErrEditionClosed)
+}
+
+func TestGetPublishedEdition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEditionRepository(ctrl)
+	editionService := services.NewEditionService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusOpen}, nil)
+	mockRepo.EXPECT().ListEntries(gomock.Any(), "edition1").Return([]models.Movie{{ID: "movie1"}}, nil)
+	edition, err := editionService.GetPublishedEdition(ctx, "edition1")
+	assert.NoError(t, err)
+	assert.Len(t, edition.Entries, 1)
+
+	// Drafts are hidden from the audience
+	mockRepo.EXPECT().FindByID(gomock.Any(), "edition2").Return(&models.Edition{ID: "edition2", Status: models.EditionStatusDraft}, nil)
+	_, err = editionService.GetPublishedEdition(ctx, "edition2")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	mockRepo.EXPECT().List(gomock.Any(), models.EditionStatusOpen, models.EditionStatusClosed).Return([]models.Edition{{ID: "edition1"}}, nil)
+	editions, err := editionService.ListPublishedEditions(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, editions, 1)
+
+	_, err = editionService.ListEditions(ctx, "archived")
+	assert.ErrorIs(t, err, services.ErrInvalidEditionStatus)
+}
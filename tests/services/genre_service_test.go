@@ -0,0 +1,149 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCreateGenre(t *testing.T) {
+	parentID := int64(1)
+	subgenreParentID := int64(2)
+
+	// Define test cases
+	tests := []struct {
+		name          string
+		request       models.GenreRequest
+		mockSetup     func(mockRepo *mocks.MockGenreRepository)
+		expectedError error
+	}{
+		{
+			name:    "Success - Top-level genre created",
+			request: models.GenreRequest{Name: "Science Fiction"},
+			mockSetup: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Science Fiction").Return(nil, nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Success - Subgenre created",
+			request: models.GenreRequest{Name: "Cyberpunk", ParentID: &parentID},
+			mockSetup: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Cyberpunk").Return(nil, nil)
+				mockRepo.EXPECT().FindByID(gomock.Any(), parentID).Return(&models.Genre{ID: parentID, Name: "Science Fiction"}, nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Failure - Parent is a subgenre",
+			request: models.GenreRequest{Name: "Biopunk", ParentID: &subgenreParentID},
+			mockSetup: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Biopunk").Return(nil, nil)
+				mockRepo.EXPECT().FindByID(gomock.Any(), subgenreParentID).Return(&models.Genre{ID: subgenreParentID, ParentID: &parentID}, nil)
+			},
+			expectedError: services.ErrGenreParent,
+		},
+		{
+			name:    "Failure - Genre already exists",
+			request: models.GenreRequest{Name: "Sci-Fi"},
+			mockSetup: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "Sci-Fi").Return(&models.Genre{ID: 3, Name: "Sci-Fi"}, nil)
+			},
+			expectedError: services.ErrGenreExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new gomock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock repository
+			mockRepo := mocks.NewMockGenreRepository(ctrl)
+			tt.mockSetup(mockRepo)
+
+			// Create the service
+			genreService := services.NewGenreService(mockRepo)
+
+			// Execute the service method
+			genre, err := genreService.CreateGenre(context.TODO(), tt.request)
+
+			// Assert the result
+			if tt.expectedError != nil {
+				assert.Nil(t, genre)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.request.Name, genre.Name)
+			}
+		})
+	}
+}
+
+func TestUpdateGenre(t *testing.T) {
+	parentID := int64(1)
+
+	t.Run("Success - Genre renamed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockGenreRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), "Science Fiction").Return(nil, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), &models.Genre{ID: 3, Name: "Science Fiction"}).Return(nil)
+
+		genreService := services.NewGenreService(mockRepo)
+		genre, err := genreService.UpdateGenre(context.TODO(), 3, models.GenreRequest{Name: "Science Fiction"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Science Fiction", genre.Name)
+	})
+
+	t.Run("Failure - Genre with subgenres cannot get a parent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockGenreRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), "Thriller").Return(&models.Genre{ID: 4, Name: "Thriller"}, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), parentID).Return(&models.Genre{ID: parentID}, nil)
+		mockRepo.EXPECT().CountChildren(gomock.Any(), int64(4)).Return(2, nil)
+
+		genreService := services.NewGenreService(mockRepo)
+		_, err := genreService.UpdateGenre(context.TODO(), 4, models.GenreRequest{Name: "Thriller", ParentID: &parentID})
+		assert.ErrorIs(t, err, services.ErrGenreHasChildren)
+	})
+}
+
+func TestMergeGenres(t *testing.T) {
+	t.Run("Success - Duplicated genre merged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockGenreRepository(ctrl)
+		mockRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&models.Genre{ID: 1, Name: "Science Fiction"}, nil)
+		mockRepo.EXPECT().Merge(gomock.Any(), int64(1), []int64{2}).Return(nil)
+
+		genreService := services.NewGenreService(mockRepo)
+		err := genreService.MergeGenres(context.TODO(), 1, []int64{2})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure - Merge into itself", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockGenreRepository(ctrl)
+		mockRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&models.Genre{ID: 1}, nil)
+
+		genreService := services.NewGenreService(mockRepo)
+		err := genreService.MergeGenres(context.TODO(), 1, []int64{1})
+		assert.ErrorIs(t, err, services.ErrGenreMergeSelf)
+	})
+}
@@ -0,0 +1,259 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+// fakeRoleService grants the permissions of the roles it knows
+type fakeRoleService struct {
+	services.RoleService
+	permissions map[string][]string
+}
+
+func (f *fakeRoleService) RolePermissions(ctx context.Context, role string) ([]string, error) {
+	return f.permissions[role], nil
+}
+
+func (f *fakeRoleService) CheckOutranked(ctx context.Context, actor *helpers.Claims, role string) error {
+	for _, permission := range f.permissions[role] {
+		if !slices.Contains(f.permissions[actor.Role], permission) {
+			return services.ErrRoleEscalation
+		}
+	}
+	return nil
+}
+
+func newJuryService(ctrl *gomock.Controller) (services.JuryService, *mocks.MockJuryRepository, *mocks.MockCompetitionRepository, *mocks.MockUserRepository) {
+	mockRepo := mocks.NewMockJuryRepository(ctrl)
+	mockCompetitionRepo := mocks.NewMockCompetitionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	roles := &fakeRoleService{permissions: map[string][]string{"jury": {models.PermissionJuryScore}}}
+
+	return services.NewJuryService(mockRepo, mockCompetitionRepo, mockUserRepo, roles), mockRepo, mockCompetitionRepo, mockUserRepo
+}
+
+func TestAssignJuror(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		mockSetup     func(mockRepo *mocks.MockJuryRepository, mockCompetitionRepo *mocks.MockCompetitionRepository, mockUserRepo *mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name: "Success - Juror assigned",
+			mockSetup: func(mockRepo *mocks.MockJuryRepository, mockCompetitionRepo *mocks.MockCompetitionRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1"}, nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1", Role: "jury"}, nil)
+				mockRepo.EXPECT().AssignJuror(gomock.Any(), "section1", "user1").Return(nil)
+			},
+		},
+		{
+			name: "Failure - Role does not grant jury:score",
+			mockSetup: func(mockRepo *mocks.MockJuryRepository, mockCompetitionRepo *mocks.MockCompetitionRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1"}, nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1", Role: models.RoleUser}, nil)
+			},
+			expectedError: services.ErrNotJuryMember,
+		},
+		{
+			name: "Failure - User not found",
+			mockSetup: func(mockRepo *mocks.MockJuryRepository, mockCompetitionRepo *mocks.MockCompetitionRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1"}, nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(nil, nil)
+			},
+			expectedError: services.ErrJurorNotExists,
+		},
+		{
+			name: "Failure - Section not found",
+			mockSetup: func(mockRepo *mocks.MockJuryRepository, mockCompetitionRepo *mocks.MockCompetitionRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(nil, sql.ErrNoRows)
+			},
+			expectedError: services.ErrSectionNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			juryService, mockRepo, mockCompetitionRepo, mockUserRepo := newJuryService(ctrl)
+			tc.mockSetup(mockRepo, mockCompetitionRepo, mockUserRepo)
+
+			err := juryService.AssignJuror(context.Background(), "section1", "user1")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubmitScores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	juryService, mockRepo, mockCompetitionRepo, _ := newJuryService(ctrl)
+	ctx := context.Background()
+
+	closedAt := time.Now()
+	open := &models.Section{ID: "section1"}
+	closed := &models.Section{ID: "section1", ScoringClosedAt: &closedAt}
+	nominees := []models.Nominee{{MovieID: "movie1"}}
+	criteria := []models.ScoringCriterion{{ID: "direction", Weight: 2}, {ID: "screenplay", Weight: 1}}
+	scores := []models.CriterionScore{{CriterionID: "direction", Score: 8}, {CriterionID: "screenplay", Score: 6.5}}
+
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(open, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror1").Return(true, nil)
+	mockCompetitionRepo.EXPECT().ListNominees(gomock.Any(), "section1").Return(nominees, nil)
+	mockRepo.EXPECT().ListCriteria(gomock.Any(), "section1").Return(criteria, nil)
+	mockRepo.EXPECT().SaveScores(gomock.Any(), "juror1", "movie1", scores).Return(nil)
+	err := juryService.SubmitScores(ctx, "juror1", "section1", models.JuryScoresRequest{MovieID: "movie1", Scores: scores})
+	assert.NoError(t, err)
+
+	// Only the jurors of the section can score its nominees
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(open, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror2").Return(false, nil)
+	err = juryService.SubmitScores(ctx, "juror2", "section1", models.JuryScoresRequest{MovieID: "movie1", Scores: scores})
+	assert.ErrorIs(t, err, services.ErrNotSectionJuror)
+
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(open, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror1").Return(true, nil)
+	mockCompetitionRepo.EXPECT().ListNominees(gomock.Any(), "section1").Return(nominees, nil)
+	err = juryService.SubmitScores(ctx, "juror1", "section1", models.JuryScoresRequest{MovieID: "movie2", Scores: scores})
+	assert.ErrorIs(t, err, services.ErrMovieNotNominated)
+
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(open, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror1").Return(true, nil)
+	mockCompetitionRepo.EXPECT().ListNominees(gomock.Any(), "section1").Return(nominees, nil)
+	mockRepo.EXPECT().ListCriteria(gomock.Any(), "section1").Return(criteria, nil)
+	err = juryService.SubmitScores(ctx, "juror1", "section1", models.JuryScoresRequest{MovieID: "movie1", Scores: []models.CriterionScore{{CriterionID: "acting", Score: 7}}})
+	assert.ErrorIs(t, err, services.ErrUnknownCriterion)
+
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(closed, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror1").Return(true, nil)
+	err = juryService.SubmitScores(ctx, "juror1", "section1", models.JuryScoresRequest{MovieID: "movie1", Scores: scores})
+	assert.ErrorIs(t, err, services.ErrScoringClosed)
+}
+
+func TestGetJurySheet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	juryService, mockRepo, mockCompetitionRepo, _ := newJuryService(ctrl)
+	ctx := context.Background()
+
+	// Jurors only see their own scores while scoring is open
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1"}, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror1").Return(true, nil)
+	mockCompetitionRepo.EXPECT().ListNominees(gomock.Any(), "section1").Return([]models.Nominee{{MovieID: "movie1"}}, nil)
+	mockRepo.EXPECT().ListCriteria(gomock.Any(), "section1").Return([]models.ScoringCriterion{{ID: "direction"}}, nil)
+	mockRepo.EXPECT().ListScores(gomock.Any(), "section1", "juror1").Return([]models.JuryScore{{UserID: "juror1"}}, nil)
+	sheet, err := juryService.GetJurySheet(ctx, "juror1", "section1")
+	assert.NoError(t, err)
+	assert.Len(t, sheet.Nominees, 1)
+	assert.Len(t, sheet.Scores, 1)
+
+	// Every score is visible once scoring is closed
+	closedAt := time.Now()
+	mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1", ScoringClosedAt: &closedAt}, nil)
+	mockRepo.EXPECT().IsJuror(gomock.Any(), "section1", "juror1").Return(true, nil)
+	mockCompetitionRepo.EXPECT().ListNominees(gomock.Any(), "section1").Return([]models.Nominee{{MovieID: "movie1"}}, nil)
+	mockRepo.EXPECT().ListCriteria(gomock.Any(), "section1").Return([]models.ScoringCriterion{{ID: "direction"}}, nil)
+	mockRepo.EXPECT().ListScores(gomock.Any(), "section1", "").Return([]models.JuryScore{{UserID: "juror1"}, {UserID: "juror2"}}, nil)
+	sheet, err = juryService.GetJurySheet(ctx, "juror1", "section1")
+	assert.NoError(t, err)
+	assert.Len(t, sheet.Scores, 2)
+}
+
+func TestGetRanking(t *testing.T) {
+	criteria := []models.ScoringCriterion{{ID: "direction", Weight: 3}, {ID: "screenplay", Weight: 1}}
+
+	// sheet returns the scores a juror gave a movie on every criterion
+	sheet := func(movieID, userID string, direction, screenplay float64) []models.JuryScore {
+		return []models.JuryScore{
+			{CriterionID: "direction", MovieID: movieID, Title: movieID, UserID: userID, Score: direction},
+			{CriterionID: "screenplay", MovieID: movieID, Title: movieID, UserID: userID, Score: screenplay},
+		}
+	}
+
+	var scores []models.JuryScore
+	// Juror scores of movie1: 9, 8, 2 and 7
+	scores = append(scores, sheet("movie1", "juror1", 9, 9)...)
+	scores = append(scores, sheet("movie1", "juror2", 8, 8)...)
+	scores = append(scores, sheet("movie1", "juror3", 2, 2)...)
+	scores = append(scores, sheet("movie1", "juror4", 6, 10)...)
+	// Juror scores of movie2: 7, 7, 7 and 6.25
+	scores = append(scores, sheet("movie2", "juror1", 7, 7)...)
+	scores = append(scores, sheet("movie2", "juror2", 7, 7)...)
+	scores = append(scores, sheet("movie2", "juror3", 7, 7)...)
+	scores = append(scores, sheet("movie2", "juror4", 5, 10)...)
+	// An incomplete sheet does not count
+	scores = append(scores, models.JuryScore{CriterionID: "direction", MovieID: "movie3", Title: "movie3", UserID: "juror1", Score: 10})
+
+	testCases := []struct {
+		aggregation string
+		expected    []models.RankedMovie
+	}{
+		{
+			aggregation: "",
+			expected: []models.RankedMovie{
+				{Rank: 1, MovieID: "movie2", Title: "movie2", Score: 6.81, Jurors: 4},
+				{Rank: 2, MovieID: "movie1", Title: "movie1", Score: 6.5, Jurors: 4},
+			},
+		},
+		{
+			aggregation: models.JuryAggregationTrimmedMean,
+			expected: []models.RankedMovie{
+				{Rank: 1, MovieID: "movie1", Title: "movie1", Score: 7.5, Jurors: 4},
+				{Rank: 2, MovieID: "movie2", Title: "movie2", Score: 7, Jurors: 4},
+			},
+		},
+		{
+			aggregation: models.JuryAggregationMedian,
+			expected: []models.RankedMovie{
+				{Rank: 1, MovieID: "movie1", Title: "movie1", Score: 7.5, Jurors: 4},
+				{Rank: 2, MovieID: "movie2", Title: "movie2", Score: 7, Jurors: 4},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Aggregation "+tc.aggregation, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			juryService, mockRepo, mockCompetitionRepo, _ := newJuryService(ctrl)
+			mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1"}, nil)
+			mockRepo.EXPECT().ListCriteria(gomock.Any(), "section1").Return(criteria, nil)
+			mockRepo.EXPECT().ListScores(gomock.Any(), "section1", "").Return(scores, nil)
+
+			ranking, err := juryService.GetRanking(context.Background(), "section1", tc.aggregation)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ranking.Ranking)
+		})
+	}
+
+	t.Run("Invalid aggregation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		juryService, _, _, _ := newJuryService(ctrl)
+		_, err := juryService.GetRanking(context.Background(), "section1", "max")
+		assert.ErrorIs(t, err, services.ErrInvalidJuryAggregation)
+	})
+}
@@ -0,0 +1,210 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCheckLogin(t *testing.T) {
+	// Define test cases
+	testCases := []struct {
+		name          string
+		mockSetup     func(mockRedis redismock.ClientMock)
+		expectedError error
+		expectedRetry time.Duration
+	}{
+		{
+			name: "Success - Nothing locked",
+			mockSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectPTTL("login:lock:user:user123").SetVal(-2)
+				mockRedis.ExpectPTTL("login:lock:ip:10.0.0.1").SetVal(-2)
+				mockRedis.ExpectPTTL("login:backoff:user:user123").SetVal(-2)
+			},
+		},
+		{
+			name: "Failure - Account locked",
+			mockSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectPTTL("login:lock:user:user123").SetVal(10 * time.Minute)
+			},
+			expectedError: services.ErrAccountLocked,
+			expectedRetry: 10 * time.Minute,
+		},
+		{
+			name: "Failure - Client address locked",
+			mockSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectPTTL("login:lock:user:user123").SetVal(-2)
+				mockRedis.ExpectPTTL("login:lock:ip:10.0.0.1").SetVal(5 * time.Minute)
+			},
+			expectedError: services.ErrTooManyAttempts,
+			expectedRetry: 5 * time.Minute,
+		},
+		{
+			name: "Failure - Waiting out the backoff",
+			mockSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectPTTL("login:lock:user:user123").SetVal(-2)
+				mockRedis.ExpectPTTL("login:lock:ip:10.0.0.1").SetVal(-2)
+				mockRedis.ExpectPTTL("login:backoff:user:user123").SetVal(2 * time.Second)
+			},
+			expectedError: services.ErrTooManyAttempts,
+			expectedRetry: 2 * time.Second,
+		},
+		{
+			name: "Success - Redis unavailable does not block logins",
+			mockSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectPTTL("login:lock:user:user123").SetErr(errors.New("connection refused"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRedis)
+
+			lockoutService := services.NewLockoutService(mocks.NewMockLockoutRepository(ctrl), mockRedisClient)
+
+			// Usernames are matched case-insensitively like the users table
+			err := lockoutService.CheckLogin(context.Background(), "User123", "10.0.0.1")
+
+			if tc.expectedError != nil {
+				var blocked *services.LoginBlockedError
+				assert.ErrorIs(t, err, tc.expectedError)
+				if assert.ErrorAs(t, err, &blocked) {
+					assert.Equal(t, tc.expectedRetry, blocked.RetryAfter)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRecordFailedLogin(t *testing.T) {
+	t.Setenv("LOGIN_MAX_ATTEMPTS", "3")
+	t.Setenv("LOGIN_MAX_ATTEMPTS_PER_IP", "10")
+	t.Setenv("LOGIN_FAILURE_WINDOW", "15m")
+	t.Setenv("LOGIN_BACKOFF_BASE", "1s")
+	t.Setenv("LOGIN_LOCKOUT_DURATION", "15m")
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		mockSetup     func(mockRepo *mocks.MockLockoutRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name: "First failure waits the base backoff",
+			mockSetup: func(mockRepo *mocks.MockLockoutRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectIncr("login:failed:user:user123").SetVal(1)
+				mockRedis.ExpectExpire("login:failed:user:user123", 15*time.Minute).SetVal(true)
+				mockRedis.ExpectSet("login:backoff:user:user123", 1, time.Second).SetVal("OK")
+				mockRedis.ExpectIncr("login:failed:ip:10.0.0.1").SetVal(1)
+				mockRedis.ExpectExpire("login:failed:ip:10.0.0.1", 15*time.Minute).SetVal(true)
+			},
+		},
+		{
+			name: "Backoff doubles with every failure",
+			mockSetup: func(mockRepo *mocks.MockLockoutRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectIncr("login:failed:user:user123").SetVal(2)
+				mockRedis.ExpectSet("login:backoff:user:user123", 2, 2*time.Second).SetVal("OK")
+				mockRedis.ExpectIncr("login:failed:ip:10.0.0.1").SetVal(2)
+			},
+		},
+		{
+			name: "Reaching the maximum locks the account and records the event",
+			mockSetup: func(mockRepo *mocks.MockLockoutRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectIncr("login:failed:user:user123").SetVal(3)
+				mockRedis.ExpectSet("login:lock:user:user123", 3, 15*time.Minute).SetVal("OK")
+				mockRedis.ExpectDel("login:failed:user:user123", "login:backoff:user:user123").SetVal(2)
+				mockRepo.EXPECT().RecordEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event *models.LockoutEvent) error {
+						assert.Equal(t, models.LockoutScopeUser, event.Scope)
+						assert.Equal(t, "user123", event.Subject)
+						assert.Equal(t, models.LockoutActionLock, event.Action)
+						assert.Equal(t, "10.0.0.1", event.IPAddress)
+						assert.Equal(t, 3, event.FailedAttempts)
+						return nil
+					})
+				mockRedis.ExpectIncr("login:failed:ip:10.0.0.1").SetVal(3)
+			},
+			expectedError: services.ErrAccountLocked,
+		},
+		{
+			name: "Reaching the maximum of the address locks the address",
+			mockSetup: func(mockRepo *mocks.MockLockoutRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectIncr("login:failed:user:user123").SetVal(1)
+				mockRedis.ExpectExpire("login:failed:user:user123", 15*time.Minute).SetVal(true)
+				mockRedis.ExpectSet("login:backoff:user:user123", 1, time.Second).SetVal("OK")
+				mockRedis.ExpectIncr("login:failed:ip:10.0.0.1").SetVal(10)
+				mockRedis.ExpectSet("login:lock:ip:10.0.0.1", 10, 15*time.Minute).SetVal("OK")
+				mockRedis.ExpectDel("login:failed:ip:10.0.0.1").SetVal(1)
+				mockRepo.EXPECT().RecordEvent(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: services.ErrTooManyAttempts,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockLockoutRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRepo, mockRedis)
+
+			lockoutService := services.NewLockoutService(mockRepo, mockRedisClient)
+
+			err := lockoutService.RecordFailedLogin(context.Background(), "user123", "10.0.0.1")
+
+			if tc.expectedError != nil {
+				var blocked *services.LoginBlockedError
+				assert.ErrorIs(t, err, tc.expectedError)
+				if assert.ErrorAs(t, err, &blocked) {
+					assert.Equal(t, 15*time.Minute, blocked.RetryAfter)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUnlockLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLockoutRepository(ctrl)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+
+	mockRedis.ExpectDel("login:lock:user:user123", "login:failed:user:user123", "login:backoff:user:user123").SetVal(1)
+	mockRedis.ExpectDel("login:lock:ip:10.0.0.1", "login:failed:ip:10.0.0.1", "login:backoff:ip:10.0.0.1").SetVal(1)
+	mockRepo.EXPECT().RecordEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event *models.LockoutEvent) error {
+			assert.Equal(t, models.LockoutActionUnlock, event.Action)
+			assert.Equal(t, "admin1", event.AdminID)
+			return nil
+		}).Times(2)
+
+	lockoutService := services.NewLockoutService(mockRepo, mockRedisClient)
+
+	err := lockoutService.Unlock(context.Background(), "admin1", models.UnlockLoginRequest{Username: "User123", IPAddress: "10.0.0.1"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
@@ -0,0 +1,246 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestGetMovieList(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name           string
+		list           string
+		mockSetup      func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository)
+		expectedTitles []string
+		expectedError  error
+	}{
+		{
+			name: "Success - Movies hydrated in list order",
+			list: models.MovieListWatchlist,
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListWatchlist).Return([]models.MovieListItem{
+					{Movie: models.Movie{ID: "movie2"}, Position: 1},
+					{Movie: models.Movie{ID: "movie1"}, Position: 2},
+				}, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie2", "movie1"}).Return([]models.Movie{
+					{ID: "movie1", Title: "Movie 1", Genres: []models.Genre{{ID: 1, Name: "Action"}}},
+					{ID: "movie2", Title: "Movie 2"},
+				}, nil)
+			},
+			expectedTitles: []string{"Movie 2", "Movie 1"},
+		},
+		{
+			name: "Success - Deleted movies are left out",
+			list: models.MovieListFavorites,
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return([]models.MovieListItem{
+					{Movie: models.Movie{ID: "movie1"}, Position: 1},
+					{Movie: models.Movie{ID: "movie2"}, Position: 2},
+				}, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return([]models.Movie{
+					{ID: "movie2", Title: "Movie 2"},
+				}, nil)
+			},
+			expectedTitles: []string{"Movie 2"},
+		},
+		{
+			name: "Success - Empty list",
+			list: models.MovieListWatchlist,
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListWatchlist).Return([]models.MovieListItem{}, nil)
+			},
+			expectedTitles: []string{},
+		},
+		{
+			name:          "Failure - Unknown list",
+			list:          "seen",
+			mockSetup:     func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {},
+			expectedError: services.ErrInvalidMovieList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockMovieListRepository(ctrl)
+			mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockSetup(mockRepo, mockMovieRepo)
+
+			movieListService := services.NewMovieListService(mockRepo, mockMovieRepo)
+
+			items, err := movieListService.GetList(context.TODO(), "user1", tt.list)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			titles := []string{}
+			for i, item := range items {
+				titles = append(titles, item.Movie.Title)
+				assert.Equal(t, i+1, item.Position)
+			}
+			assert.Equal(t, tt.expectedTitles, titles)
+		})
+	}
+}
+
+func TestAddMovieToList(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name          string
+		mockSetup     func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository)
+		expectedError error
+	}{
+		{
+			name: "Success - Movie added",
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1"}, nil)
+				mockRepo.EXPECT().FindItem(gomock.Any(), "user1", models.MovieListWatchlist, "movie1").Return(nil, nil)
+				mockRepo.EXPECT().AddItem(gomock.Any(), "user1", models.MovieListWatchlist, "movie1").Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Failure - Movie does not exist",
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{}, sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			name: "Failure - Movie already in list",
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1"}, nil)
+				mockRepo.EXPECT().FindItem(gomock.Any(), "user1", models.MovieListWatchlist, "movie1").Return(&models.MovieListItem{}, nil)
+			},
+			expectedError: services.ErrMovieAlreadyInList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockMovieListRepository(ctrl)
+			mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockSetup(mockRepo, mockMovieRepo)
+
+			movieListService := services.NewMovieListService(mockRepo, mockMovieRepo)
+
+			err := movieListService.AddMovie(context.TODO(), "user1", models.MovieListWatchlist, "movie1")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReorderMovieList(t *testing.T) {
+	current := []models.MovieListItem{
+		{Movie: models.Movie{ID: "movie1"}},
+		{Movie: models.Movie{ID: "movie2"}},
+	}
+	movies := []models.Movie{{ID: "movie1"}, {ID: "movie2"}}
+
+	// Define test cases
+	tests := []struct {
+		name          string
+		movieIDs      []string
+		mockSetup     func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository)
+		expectedError error
+	}{
+		{
+			name:     "Success - List reordered",
+			movieIDs: []string{"movie2", "movie1"},
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return(current, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return(movies, nil)
+				mockRepo.EXPECT().Reorder(gomock.Any(), "user1", models.MovieListFavorites, []string{"movie2", "movie1"}).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Success - Deleted movie moved to the end",
+			movieIDs: []string{"movie2"},
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return(current, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return(movies[1:], nil)
+				mockRepo.EXPECT().Reorder(gomock.Any(), "user1", models.MovieListFavorites, []string{"movie2", "movie1"}).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Failure - Deleted movie in the order",
+			movieIDs: []string{"movie1", "movie2"},
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return(current, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return(movies[1:], nil)
+			},
+			expectedError: services.ErrInvalidMovieListOrder,
+		},
+		{
+			name:     "Failure - Movie missing from the order",
+			movieIDs: []string{"movie2"},
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return(current, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return(movies, nil)
+			},
+			expectedError: services.ErrInvalidMovieListOrder,
+		},
+		{
+			name:     "Failure - Movie listed twice",
+			movieIDs: []string{"movie2", "movie2"},
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return(current, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return(movies, nil)
+			},
+			expectedError: services.ErrInvalidMovieListOrder,
+		},
+		{
+			name:     "Failure - Movie not in list",
+			movieIDs: []string{"movie2", "movie3"},
+			mockSetup: func(mockRepo *mocks.MockMovieListRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().ListItems(gomock.Any(), "user1", models.MovieListFavorites).Return(current, nil)
+				mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie1", "movie2"}).Return(movies, nil)
+			},
+			expectedError: services.ErrInvalidMovieListOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockMovieListRepository(ctrl)
+			mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockSetup(mockRepo, mockMovieRepo)
+
+			movieListService := services.NewMovieListService(mockRepo, mockMovieRepo)
+
+			err := movieListService.Reorder(context.TODO(), "user1", models.MovieListFavorites, tt.movieIDs)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-redis/redismock/v8"
 	"github.com/golang/mock/gomock"
@@ -200,7 +201,7 @@ func TestGetMostViewedMovie(t *testing.T) {
 			name: "Success - Most viewed movie retrieved",
 			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					GetMostViewedMovie(gomock.Any()).
+					GetMostViewedMovie(gomock.Any(), models.StatsFilter{}).
 					Return(&models.Movie{
 						ID:          "movie1",
 						Title:       "Most Viewed Movie",
@@ -232,7 +233,7 @@ func TestGetMostViewedMovie(t *testing.T) {
 			name: "Failure - Repository returns an error",
 			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					GetMostViewedMovie(gomock.Any()).
+					GetMostViewedMovie(gomock.Any(), models.StatsFilter{}).
 					Return(nil, errors.New("repository error"))
 			},
 			expectedMovie: nil,
@@ -254,7 +255,7 @@ func TestGetMostViewedMovie(t *testing.T) {
 			movieService := services.NewMovieService(mockRepo, nil)
 
 			// Execute the service method
-			movie, err := movieService.GetMostViewedMovie(context.TODO())
+			movie, err := movieService.GetMostViewedMovie(context.TODO(), models.StatsFilter{})
 
 			// Assert the result
 			if tt.expectedError != nil {
@@ -269,6 +270,32 @@ func TestGetMostViewedMovie(t *testing.T) {
 	}
 }
 
+func TestGetMostVotedMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMovieRepository(ctrl)
+	movieService := services.NewMovieService(mockRepo, nil)
+
+	// Scoped to the entries of an edition
+	filter := models.StatsFilter{EditionID: "edition1"}
+	mockRepo.EXPECT().GetMostVotedMovie(gomock.Any(), filter).Return(&models.Movie{ID: "movie1", Votes: 12}, nil)
+	movie, err := movieService.GetMostVotedMovie(context.TODO(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, movie.Votes)
+
+	// No votes yet
+	mockRepo.EXPECT().GetMostVotedMovie(gomock.Any(), models.StatsFilter{}).Return(nil, sql.ErrNoRows)
+	movie, err = movieService.GetMostVotedMovie(context.TODO(), models.StatsFilter{})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, movie)
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	_, err = movieService.GetMostVotedMovie(context.TODO(), models.StatsFilter{From: &from, To: &to})
+	assert.ErrorIs(t, err, services.ErrInvalidTimeWindow)
+}
+
 func TestGetMostViewedGenre(t *testing.T) {
 	// Define test cases
 	tests := []struct {
@@ -276,6 +303,7 @@ func TestGetMostViewedGenre(t *testing.T) {
 		page           int
 		pageSize       int
 		sortOrder      string
+		filter         models.StatsFilter
 		mockSetup      func(mockRepo *mocks.MockMovieRepository)
 		expectedResult []models.GenreView
 		expectedError  error
@@ -287,7 +315,7 @@ func TestGetMostViewedGenre(t *testing.T) {
 			sortOrder: "DESC",
 			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC").
+					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC", models.StatsFilter{}).
 					Return([]models.GenreView{
 						{Name: "Action", ViewCount: 150},
 						{Name: "Adventure", ViewCount: 120},
@@ -306,7 +334,7 @@ func TestGetMostViewedGenre(t *testing.T) {
 			sortOrder: "ASC",
 			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					GetMostViewedGenre(gomock.Any(), 2, 3, "ASC").
+					GetMostViewedGenre(gomock.Any(), 2, 3, "ASC", models.StatsFilter{}).
 					Return([]models.GenreView{
 						{Name: "Horror", ViewCount: 50},
 						{Name: "Comedy", ViewCount: 30},
@@ -325,7 +353,7 @@ func TestGetMostViewedGenre(t *testing.T) {
 			sortOrder: "INVALID",
 			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC").
+					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC", models.StatsFilter{}).
 					Return([]models.GenreView{
 						{Name: "Sci-Fi", ViewCount: 100},
 					}, nil)
@@ -335,6 +363,24 @@ func TestGetMostViewedGenre(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:      "Success - Subgenres rolled up into parent genres",
+			page:      1,
+			pageSize:  5,
+			sortOrder: "DESC",
+			filter:    models.StatsFilter{RollupGenres: true},
+			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().
+					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC", models.StatsFilter{RollupGenres: true}).
+					Return([]models.GenreView{
+						{Name: "Science Fiction", ViewCount: 250},
+					}, nil)
+			},
+			expectedResult: []models.GenreView{
+				{Name: "Science Fiction", ViewCount: 250},
+			},
+			expectedError: nil,
+		},
 		{
 			name:      "Failure - Repository returns error",
 			page:      1,
@@ -342,7 +388,7 @@ func TestGetMostViewedGenre(t *testing.T) {
 			sortOrder: "DESC",
 			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC").
+					GetMostViewedGenre(gomock.Any(), 1, 5, "DESC", models.StatsFilter{}).
 					Return(nil, errors.New("repository error"))
 			},
 			expectedResult: nil,
@@ -364,7 +410,7 @@ func TestGetMostViewedGenre(t *testing.T) {
 			movieService := services.NewMovieService(mockRepo, nil)
 
 			// Execute the service method
-			result, err := movieService.GetMostViewedGenre(context.TODO(), tt.page, tt.pageSize, tt.sortOrder)
+			result, err := movieService.GetMostViewedGenre(context.TODO(), tt.page, tt.pageSize, tt.sortOrder, tt.filter)
 
 			// Assert the result
 			if tt.expectedError != nil {
@@ -504,7 +550,7 @@ func TestSearchMoviesService(t *testing.T) {
 			offset: 0,
 			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					SearchMovies(gomock.Any(), "action", 5, 0).
+					SearchMovies(gomock.Any(), models.MovieSearchFilter{Query: "action"}, 5, 0).
 					Return([]models.Movie{
 						{
 							ID:          "movie1",
@@ -547,7 +593,7 @@ func TestSearchMoviesService(t *testing.T) {
 			offset: 0,
 			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					SearchMovies(gomock.Any(), "nonexistent", 5, 0).
+					SearchMovies(gomock.Any(), models.MovieSearchFilter{Query: "nonexistent"}, 5, 0).
 					Return([]models.Movie{}, nil) // Return an empty slice
 			},
 			expectedResult: []models.Movie{}, // Expect an empty slice
@@ -560,7 +606,7 @@ func TestSearchMoviesService(t *testing.T) {
 			offset: 0,
 			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
 				mockRepo.EXPECT().
-					SearchMovies(gomock.Any(), "action", 5, 0).
+					SearchMovies(gomock.Any(), models.MovieSearchFilter{Query: "action"}, 5, 0).
 					Return(nil, errors.New("repository error"))
 			},
 			expectedResult: nil,
@@ -582,7 +628,7 @@ func TestSearchMoviesService(t *testing.T) {
 			movieService := services.NewMovieService(mockRepo, nil) // Assuming no Redis for now
 
 			// Execute the service method
-			result, err := movieService.SearchMovies(context.TODO(), tt.query, tt.limit, tt.offset)
+			result, err := movieService.SearchMovies(context.TODO(), models.MovieSearchFilter{Query: tt.query}, tt.limit, tt.offset)
 
 			// Assert the results
 			if tt.expectedError != nil {
@@ -598,31 +644,105 @@ func TestSearchMoviesService(t *testing.T) {
 }
 
 func TestTrackMovieViewService(t *testing.T) {
+	t.Setenv("VIEW_DEDUP_WINDOW", "30m")
+
 	// Define test cases
 	tests := []struct {
-		name          string
-		movieID       string
-		mockRepoSetup func(mockRepo *mocks.MockMovieRepository)
-		expectedError error
+		name           string
+		view           models.ViewEvent
+		mockRepoSetup  func(mockRepo *mocks.MockMovieRepository)
+		mockRedisSetup func(mockRedis redismock.ClientMock)
+		expectedError  error
 	}{
 		{
-			name:    "Success - Movie view tracked",
-			movieID: "movie123",
+			name: "Success - Movie view tracked",
+			view: models.ViewEvent{MovieID: "movie123"},
 			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "movie123").Return(models.Movie{ID: "movie123"}, nil)
 				mockRepo.EXPECT().
-					TrackMovieView(gomock.Any(), "movie123").
+					TrackMovieView(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, view models.ViewEvent) {
+						assert.Equal(t, "movie123", view.MovieID)
+						assert.False(t, view.ViewedAt.IsZero())
+					}).
 					Return(nil) // No error on success
 			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectHIncrBy("movie_views:pending", "movie123", 1).SetVal(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success - Counter written directly when Redis fails",
+			view: models.ViewEvent{MovieID: "movie123"},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "movie123").Return(models.Movie{ID: "movie123"}, nil)
+				mockRepo.EXPECT().TrackMovieView(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().AddViewCounts(gomock.Any(), gomock.Any(), map[string]int64{"movie123": 1}).Return(nil)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectHIncrBy("movie_views:pending", "movie123", 1).SetErr(errors.New("redis down"))
+			},
 			expectedError: nil,
 		},
 		{
-			name:    "Error - Movie view tracking fails",
-			movieID: "movie456",
+			name: "Success - Anonymous session keyed on the address",
+			view: models.ViewEvent{MovieID: "movie123", SessionID: "session-2", IPAddress: "10.0.0.2"},
 			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "movie123").Return(models.Movie{ID: "movie123"}, nil)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectSetNX("view:dedup:movie123:ip:10.0.0.2", 1, 30*time.Minute).SetVal(false)
+			},
+			expectedError: services.ErrViewAlreadyCounted,
+		},
+		{
+			name: "Success - First view of a session",
+			view: models.ViewEvent{MovieID: "movie123", SessionID: "session-1"},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "movie123").Return(models.Movie{ID: "movie123"}, nil)
+				mockRepo.EXPECT().TrackMovieView(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectSetNX("view:dedup:movie123:s:session-1", 1, 30*time.Minute).SetVal(true)
+				mockRedis.ExpectHIncrBy("movie_views:pending", "movie123", 1).SetVal(1)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error - Repeated view of a user is counted once",
+			view: models.ViewEvent{MovieID: "movie123", UserID: "user-1", SessionID: "session-1"},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "movie123").Return(models.Movie{ID: "movie123"}, nil)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectSetNX("view:dedup:movie123:u:user-1", 1, 30*time.Minute).SetVal(false)
+			},
+			expectedError: services.ErrViewAlreadyCounted,
+		},
+		{
+			name: "Error - Movie does not exist",
+			view: models.ViewEvent{MovieID: "unknown", IPAddress: "10.0.0.1"},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "unknown").Return(models.Movie{}, sql.ErrNoRows)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {},
+			expectedError:  sql.ErrNoRows,
+		},
+		{
+			name: "Error - Movie view tracking fails",
+			view: models.ViewEvent{MovieID: "movie456", IPAddress: "10.0.0.1"},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().FindMovieByID(gomock.Any(), "movie456").Return(models.Movie{ID: "movie456"}, nil)
 				mockRepo.EXPECT().
-					TrackMovieView(gomock.Any(), "movie456").
+					TrackMovieView(gomock.Any(), gomock.Any()).
 					Return(errors.New("repository error")) // Return error from repository
 			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectSetNX("view:dedup:movie456:ip:10.0.0.1", 1, 30*time.Minute).SetVal(true)
+				// The dedup key is released so the view can be retried
+				mockRedis.ExpectDel("view:dedup:movie456:ip:10.0.0.1").SetVal(1)
+			},
 			expectedError: errors.New("repository error"),
 		},
 	}
@@ -637,11 +757,15 @@ func TestTrackMovieViewService(t *testing.T) {
 			mockRepo := mocks.NewMockMovieRepository(ctrl)
 			tt.mockRepoSetup(mockRepo)
 
+			// Mock Redis client
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tt.mockRedisSetup(mockRedis)
+
 			// Create the service
-			movieService := services.NewMovieService(mockRepo, nil) // Assuming no Redis for now
+			movieService := services.NewMovieService(mockRepo, mockRedisClient)
 
 			// Execute the service method
-			err := movieService.TrackMovieView(context.TODO(), tt.movieID)
+			err := movieService.TrackMovieView(context.TODO(), tt.view)
 
 			// Assert the results
 			if tt.expectedError != nil {
@@ -650,85 +774,188 @@ func TestTrackMovieViewService(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestUnvoteMovie(t *testing.T) {
+func TestFlushViewCounts(t *testing.T) {
 	// Define test cases
-	testCases := []struct {
-		name        string
-		userID      string
-		movieID     string
-		mockSetup   func(repo *mocks.MockMovieRepository)
-		expectedErr error
+	tests := []struct {
+		name           string
+		mockRepoSetup  func(mockRepo *mocks.MockMovieRepository)
+		mockRedisSetup func(mockRedis redismock.ClientMock)
+		expectedError  error
 	}{
 		{
-			name:    "Success Case",
-			userID:  "test-user-id",
-			movieID: "test-movie-id",
-			mockSetup: func(repo *mocks.MockMovieRepository) {
-				// Setup for checking if the vote exists
-				repo.EXPECT().
-					GetVoteByUserAndMovie(context.Background(), "test-user-id", "test-movie-id").
-					Return(&models.Vote{ID: "test-vote-id"}, nil)
-
-				repo.EXPECT().
-					DeleteVote(context.Background(), "test-vote-id").Return(nil)
+			name:          "Success - Nothing buffered",
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectExists("movie_views:pending").SetVal(0)
+				mockRedis.ExpectHGetAll("movie_views:flushing").SetVal(map[string]string{})
 			},
-			expectedErr: nil,
+			expectedError: nil,
+		},
+		{
+			name: "Success - Buffered counts flushed as a new batch",
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().
+					AddViewCounts(gomock.Any(), "batch-1", map[string]int64{"movie123": 5, "movie456": 2}).
+					Return(nil)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectExists("movie_views:pending").SetVal(1)
+				mockRedis.ExpectRenameNX("movie_views:pending", "movie_views:flushing").SetVal(true)
+				mockRedis.ExpectHGetAll("movie_views:flushing").SetVal(map[string]string{"movie123": "5", "movie456": "2"})
+				mockRedis.Regexp().ExpectHSetNX("movie_views:flushing", "_batch", `.+`).SetVal(true)
+				mockRedis.ExpectHGet("movie_views:flushing", "_batch").SetVal("batch-1")
+				mockRedis.ExpectDel("movie_views:flushing").SetVal(1)
+			},
+			expectedError: nil,
 		},
 		{
-			name:    "Vote Not Found",
-			userID:  "test-user-id",
-			movieID: "test-movie-id",
-			mockSetup: func(repo *mocks.MockMovieRepository) {
-				// Setup for vote not existing
-				repo.EXPECT().
-					GetVoteByUserAndMovie(context.Background(), "test-user-id", "test-movie-id").Return(&models.Vote{}, nil)
+			name: "Success - Batch left behind by a crash is replayed with its ID",
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().
+					AddViewCounts(gomock.Any(), "batch-0", map[string]int64{"movie123": 3}).
+					Return(nil)
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectExists("movie_views:pending").SetVal(1)
+				mockRedis.ExpectRenameNX("movie_views:pending", "movie_views:flushing").SetVal(false)
+				mockRedis.ExpectHGetAll("movie_views:flushing").SetVal(map[string]string{"movie123": "3", "_batch": "batch-0"})
+				mockRedis.ExpectDel("movie_views:flushing").SetVal(1)
 			},
-			expectedErr: errors.New("you haven't voted for this movie yet"),
+			expectedError: nil,
 		},
 		{
-			name:    "Repository Error",
-			userID:  "test-user-id",
-			movieID: "test-movie-id",
-			mockSetup: func(repo *mocks.MockMovieRepository) {
-				// Setup for checking if the vote exists
-				repo.EXPECT().
-					GetVoteByUserAndMovie(context.Background(), "test-user-id", "test-movie-id").
-					Return(&models.Vote{ID: "test-vote-id"}, nil)
-
-				repo.EXPECT().
-					DeleteVote(context.Background(), "test-vote-id").Return(errors.New("repository error"))
+			name: "Error - Write fails and the batch is kept",
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().
+					AddViewCounts(gomock.Any(), "batch-0", map[string]int64{"movie123": 3}).
+					Return(errors.New("repository error"))
+			},
+			mockRedisSetup: func(mockRedis redismock.ClientMock) {
+				mockRedis.ExpectExists("movie_views:pending").SetVal(1)
+				mockRedis.ExpectRenameNX("movie_views:pending", "movie_views:flushing").SetVal(true)
+				mockRedis.ExpectHGetAll("movie_views:flushing").SetVal(map[string]string{"movie123": "3", "_batch": "batch-0"})
 			},
-			expectedErr: errors.New("repository error"),
+			expectedError: errors.New("repository error"),
 		},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Initialize mock controller
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			// Mock dependencies
 			mockRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockRepoSetup(mockRepo)
 
-			// Setup mocks based on test case
-			tc.mockSetup(mockRepo)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tt.mockRedisSetup(mockRedis)
 
-			// Initialize service
-			movieService := services.NewMovieService(mockRepo, nil)
+			movieService := services.NewMovieService(mockRepo, mockRedisClient)
 
-			// Act
-			err := movieService.UnvoteMovie(context.Background(), tc.userID, tc.movieID)
+			err := movieService.FlushViewCounts(context.TODO())
 
-			// Assert
-			if tc.expectedErr == nil {
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
 				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetSuspectedViewInflation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMovieRepository(ctrl)
+	mockRepo.EXPECT().
+		GetSuspectedViewInflation(gomock.Any(), gomock.Any(), 20).
+		DoAndReturn(func(_ context.Context, filter models.StatsFilter, _ int) ([]models.SuspectedViewInflation, error) {
+			// Defaults to the last day
+			assert.NotNil(t, filter.From)
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), *filter.From, time.Minute)
+			return []models.SuspectedViewInflation{{MovieID: "movie123", IPAddress: "10.0.0.1", Views: 50}}, nil
+		})
+
+	movieService := services.NewMovieService(mockRepo, nil)
+
+	reports, err := movieService.GetSuspectedViewInflation(context.TODO(), models.StatsFilter{}, 20)
+	assert.NoError(t, err)
+	assert.Len(t, reports, 1)
+}
+
+func TestGetMovieViewStats(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	// Define test cases
+	tests := []struct {
+		name          string
+		granularity   string
+		filter        models.StatsFilter
+		mockRepoSetup func(mockRepo *mocks.MockMovieRepository)
+		expectedError error
+	}{
+		{
+			name:        "Success - Defaults to daily buckets",
+			granularity: "",
+			filter:      models.StatsFilter{From: &from, To: &to},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().
+					GetMovieViewStats(gomock.Any(), "movie123", models.GranularityDay, models.StatsFilter{From: &from, To: &to}).
+					Return(&models.MovieViewStats{MovieID: "movie123", Granularity: models.GranularityDay}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:        "Success - Hourly buckets",
+			granularity: models.GranularityHour,
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().
+					GetMovieViewStats(gomock.Any(), "movie123", models.GranularityHour, models.StatsFilter{}).
+					Return(&models.MovieViewStats{MovieID: "movie123", Granularity: models.GranularityHour}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - Invalid granularity",
+			granularity:   "week",
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {},
+			expectedError: services.ErrInvalidGranularity,
+		},
+		{
+			name:          "Error - From is after to",
+			granularity:   models.GranularityDay,
+			filter:        models.StatsFilter{From: &to, To: &from},
+			mockRepoSetup: func(mockRepo *mocks.MockMovieRepository) {},
+			expectedError: services.ErrInvalidTimeWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockRepoSetup(mockRepo)
+
+			movieService := services.NewMovieService(mockRepo, nil)
+
+			stats, err := movieService.GetMovieViewStats(context.TODO(), "movie123", tt.granularity, tt.filter)
+
+			if tt.expectedError != nil {
+				assert.Nil(t, stats)
+				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
-				assert.EqualError(t, err, tc.expectedErr.Error())
+				assert.NoError(t, err)
+				assert.Equal(t, "movie123", stats.MovieID)
 			}
 		})
 	}
@@ -829,3 +1056,265 @@ func TestGetUserVotedMovies(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMovieDetail(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name          string
+		movieID       string
+		mockSetup     func(mockRepo *mocks.MockMovieRepository, mockRedis redismock.ClientMock)
+		expectedMovie *models.Movie
+		expectedError error
+	}{
+		{
+			name:    "Success - Movie detail retrieved",
+			movieID: "movie1",
+			mockSetup: func(mockRepo *mocks.MockMovieRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().
+					GetMovieDetail(gomock.Any(), "movie1").
+					Return(&models.Movie{
+						ID:    "movie1",
+						Title: "Detailed Movie",
+						Views: 42,
+						Votes: 7,
+						Genres: []models.Genre{
+							{ID: 1, Name: "Action"},
+							{ID: 2, Name: "Drama"},
+						},
+						Artists: []models.Artist{
+							{ID: "artist1", Name: "John Doe"},
+							{ID: "artist2", Name: "Jane Doe"},
+						},
+					}, nil)
+				// Views waiting for the next flush are counted too
+				mockRedis.ExpectHGet("movie_views:pending", "movie1").SetVal("3")
+			},
+			expectedMovie: &models.Movie{
+				ID:    "movie1",
+				Title: "Detailed Movie",
+				Views: 45,
+				Votes: 7,
+				Genres: []models.Genre{
+					{ID: 1, Name: "Action"},
+					{ID: 2, Name: "Drama"},
+				},
+				Artists: []models.Artist{
+					{ID: "artist1", Name: "John Doe"},
+					{ID: "artist2", Name: "Jane Doe"},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Success - Nothing buffered",
+			movieID: "movie1",
+			mockSetup: func(mockRepo *mocks.MockMovieRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetMovieDetail(gomock.Any(), "movie1").Return(&models.Movie{ID: "movie1", Views: 42}, nil)
+				mockRedis.ExpectHGet("movie_views:pending", "movie1").RedisNil()
+			},
+			expectedMovie: &models.Movie{ID: "movie1", Views: 42},
+			expectedError: nil,
+		},
+		{
+			name:    "Success - Flushed views shown while Redis is unavailable",
+			movieID: "movie1",
+			mockSetup: func(mockRepo *mocks.MockMovieRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetMovieDetail(gomock.Any(), "movie1").Return(&models.Movie{ID: "movie1", Views: 42}, nil)
+				mockRedis.ExpectHGet("movie_views:pending", "movie1").SetErr(errors.New("redis down"))
+			},
+			expectedMovie: &models.Movie{ID: "movie1", Views: 42},
+			expectedError: nil,
+		},
+		{
+			name:    "Failure - Movie not found",
+			movieID: "missing",
+			mockSetup: func(mockRepo *mocks.MockMovieRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().
+					GetMovieDetail(gomock.Any(), "missing").
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedMovie: nil,
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new gomock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock repository
+			mockRepo := mocks.NewMockMovieRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tt.mockSetup(mockRepo, mockRedis)
+
+			// Create the service
+			movieService := services.NewMovieService(mockRepo, mockRedisClient)
+
+			// Execute the service method
+			movie, err := movieService.GetMovieDetail(context.TODO(), tt.movieID)
+
+			// Assert the result
+			if tt.expectedError != nil {
+				assert.Nil(t, movie)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMovie, movie)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteRestorePurgeMovie(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name          string
+		action        func(s services.MovieService) error
+		mockSetup     func(mockRepo *mocks.MockMovieRepository)
+		expectedError error
+	}{
+		{
+			name: "Success - Movie soft-deleted",
+			action: func(s services.MovieService) error {
+				return s.DeleteMovie(context.TODO(), "movie1")
+			},
+			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().SoftDelete(gomock.Any(), "movie1").Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Failure - Delete unknown movie",
+			action: func(s services.MovieService) error {
+				return s.DeleteMovie(context.TODO(), "missing")
+			},
+			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().SoftDelete(gomock.Any(), "missing").Return(sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			name: "Success - Movie restored",
+			action: func(s services.MovieService) error {
+				return s.RestoreMovie(context.TODO(), "movie1")
+			},
+			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().Restore(gomock.Any(), "movie1").Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Success - Movie purged",
+			action: func(s services.MovieService) error {
+				return s.PurgeMovie(context.TODO(), "movie1")
+			},
+			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().Purge(gomock.Any(), "movie1").Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Failure - Purge active movie",
+			action: func(s services.MovieService) error {
+				return s.PurgeMovie(context.TODO(), "movie2")
+			},
+			mockSetup: func(mockRepo *mocks.MockMovieRepository) {
+				mockRepo.EXPECT().Purge(gomock.Any(), "movie2").Return(sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new gomock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock repository
+			mockRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockSetup(mockRepo)
+
+			// Create the service
+			movieService := services.NewMovieService(mockRepo, nil)
+
+			// Execute the service method
+			err := tt.action(movieService)
+
+			// Assert the result
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMovieCredits(t *testing.T) {
+	t.Run("Uncredited artists default to actor role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockMovieRepository(ctrl)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, movie *models.Movie) error {
+				assert.Equal(t, models.ArtistRoleDirector, movie.Artists[0].Role)
+				assert.Equal(t, models.ArtistRoleActor, movie.Artists[1].Role)
+				return nil
+			})
+
+		movieService := services.NewMovieService(mockRepo, nil)
+		err := movieService.CreateMovie(context.TODO(), &models.Movie{
+			Title: "Credited Movie",
+			Artists: []models.Artist{
+				{Name: "Jane Director", Role: models.ArtistRoleDirector, BillingOrder: 1},
+				{Name: "John Actor"},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unknown role is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockMovieRepository(ctrl)
+		movieService := services.NewMovieService(mockRepo, nil)
+
+		err := movieService.CreateMovie(context.TODO(), &models.Movie{
+			Title:   "Credited Movie",
+			Artists: []models.Artist{{Name: "Someone", Role: "producer"}},
+		})
+		assert.ErrorIs(t, err, services.ErrInvalidArtistRole)
+	})
+
+	t.Run("Search by director", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		filter := models.MovieSearchFilter{Artist: "Nolan", Role: models.ArtistRoleDirector}
+		mockRepo := mocks.NewMockMovieRepository(ctrl)
+		mockRepo.EXPECT().SearchMovies(gomock.Any(), filter, 10, 0).Return([]models.Movie{{ID: "movie1"}}, nil)
+
+		movieService := services.NewMovieService(mockRepo, nil)
+		movies, err := movieService.SearchMovies(context.TODO(), filter, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, movies, 1)
+	})
+
+	t.Run("Search with unknown role is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockMovieRepository(ctrl)
+		movieService := services.NewMovieService(mockRepo, nil)
+
+		movies, err := movieService.SearchMovies(context.TODO(), models.MovieSearchFilter{Role: "producer"}, 10, 0)
+		assert.Nil(t, movies)
+		assert.ErrorIs(t, err, services.ErrInvalidArtistRole)
+	})
+}
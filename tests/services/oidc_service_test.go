@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/oidc"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+// fakeProvider returns the identity it was given for any code
+type fakeProvider struct {
+	identity *oidc.Identity
+	err      error
+	nonce    string
+}
+
+func (p *fakeProvider) Name() string { return "mock" }
+
+func (p *fakeProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	return fmt.Sprintf("https://idp.example.com/authorize?state=%s&code_challenge=%s", state, codeChallenge), nil
+}
+
+func (p *fakeProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Identity, error) {
+	p.nonce = nonce
+	return p.identity, p.err
+}
+
+// fakeUserService records the user an OIDC callback logs in
+type fakeUserService struct {
+	services.UserService
+	loggedIn *models.User
+}
+
+func (s *fakeUserService) ExternalLogin(ctx context.Context, user *models.User, device models.SessionDevice) (*models.LoginResponse, error) {
+	s.loggedIn = user
+	return &models.LoginResponse{TokenPair: &models.TokenPair{Token: "access"}}, nil
+}
+
+func TestOIDCAuthorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+	providers := map[string]oidc.Provider{"mock": &fakeProvider{}}
+	oidcService := services.NewOIDCService(mocks.NewMockIdentityRepository(ctrl), mocks.NewMockUserRepository(ctrl), &fakeUserService{}, providers, mockRedisClient)
+
+	mockRedis.Regexp().ExpectSet(`oidc:state:.+`, `.+`, 10*time.Minute).SetVal("OK")
+
+	authorization, err := oidcService.Authorize(context.Background(), "mock", "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, authorization.State)
+	assert.Contains(t, authorization.AuthorizationURL, "state="+authorization.State)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+
+	_, err = oidcService.Authorize(context.Background(), "unknown", "")
+	assert.ErrorIs(t, err, services.ErrUnknownProvider)
+}
+
+func TestOIDCCallback(t *testing.T) {
+	stateKey := "oidc:state:" + helpers.HashToken("state1")
+	loginState := models.OIDCState{Provider: "mock", Nonce: "nonce1", CodeVerifier: "verifier1"}
+	linkState := models.OIDCState{Provider: "mock", Nonce: "nonce1", CodeVerifier: "verifier1", LinkUserID: "user1"}
+	identity := &oidc.Identity{Provider: "mock", Subject: "sub1", Email: "john@example.com", EmailVerified: true, PreferredUsername: "John Doe"}
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		state         *models.OIDCState
+		browserState  string
+		identity      *oidc.Identity
+		exchangeErr   error
+		mockSetup     func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository)
+		expectedUser  string
+		expectedError error
+	}{
+		{
+			name:     "Success - Linked identity logs in",
+			state:    &loginState,
+			identity: identity,
+			mockSetup: func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().FindIdentity(gomock.Any(), "mock", "sub1").Return(&models.UserIdentity{ID: "identity1", UserID: "user1"}, nil)
+				mockRepo.EXPECT().TouchIdentity(gomock.Any(), "identity1", "john@example.com").Return(nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1", Username: "johndoe"}, nil)
+			},
+			expectedUser: "johndoe",
+		},
+		{
+			name:     "Success - First login provisions a user",
+			state:    &loginState,
+			identity: identity,
+			mockSetup: func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().FindIdentity(gomock.Any(), "mock", "sub1").Return(nil, sql.ErrNoRows)
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "john@example.com").Return(nil, nil)
+				mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), "john_doe").Return(&models.User{ID: "user2"}, nil)
+				mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().CreateUserWithIdentity(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+						assert.Equal(t, models.RoleUser, user.Role)
+						assert.Equal(t, "john@example.com", user.Email)
+						assert.NotEmpty(t, user.PasswordHash)
+						assert.Equal(t, user.ID, identity.UserID)
+						assert.Equal(t, "sub1", identity.Subject)
+						user.Username = "provisioned"
+						return nil
+					})
+			},
+			expectedUser: "provisioned",
+		},
+		{
+			name:     "Success - Logged in user links the provider",
+			state:    &linkState,
+			identity: identity,
+			mockSetup: func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().FindIdentity(gomock.Any(), "mock", "sub1").Return(nil, sql.ErrNoRows)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1", Username: "johndoe"}, nil)
+				mockRepo.EXPECT().ListIdentities(gomock.Any(), "user1").Return([]models.UserIdentity{{Provider: "google"}}, nil)
+				mockRepo.EXPECT().LinkIdentity(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedUser: "johndoe",
+		},
+		{
+			name:     "Failure - Provider already linked",
+			state:    &linkState,
+			identity: identity,
+			mockSetup: func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().FindIdentity(gomock.Any(), "mock", "sub1").Return(nil, sql.ErrNoRows)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1"}, nil)
+				mockRepo.EXPECT().ListIdentities(gomock.Any(), "user1").Return([]models.UserIdentity{{Provider: "mock"}}, nil)
+			},
+			expectedError: services.ErrProviderAlreadyLinked,
+		},
+		{
+			name:     "Failure - Identity linked to another user",
+			state:    &linkState,
+			identity: identity,
+			mockSetup: func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().FindIdentity(gomock.Any(), "mock", "sub1").Return(&models.UserIdentity{ID: "identity1", UserID: "user2"}, nil)
+			},
+			expectedError: services.ErrIdentityLinked,
+		},
+		{
+			name:     "Failure - Email belongs to an existing user",
+			state:    &loginState,
+			identity: identity,
+			mockSetup: func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().FindIdentity(gomock.Any(), "mock", "sub1").Return(nil, sql.ErrNoRows)
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "john@example.com").Return(&models.User{ID: "user1"}, nil)
+			},
+			expectedError: services.ErrOIDCEmailExists,
+		},
+		{
+			name:          "Failure - ID token rejected",
+			state:         &loginState,
+			exchangeErr:   fmt.Errorf("%w: nonce mismatch", oidc.ErrInvalidIDToken),
+			mockSetup:     func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {},
+			expectedError: services.ErrOIDCLoginFailed,
+		},
+		{
+			name:          "Failure - Flow started in another browser",
+			state:         &linkState,
+			browserState:  "state2",
+			mockSetup:     func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {},
+			expectedError: services.ErrInvalidOIDCState,
+		},
+		{
+			name:          "Failure - Unknown or used state",
+			mockSetup:     func(mockRepo *mocks.MockIdentityRepository, mockUserRepo *mocks.MockUserRepository) {},
+			expectedError: services.ErrInvalidOIDCState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockIdentityRepository(ctrl)
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			provider := &fakeProvider{identity: tc.identity, err: tc.exchangeErr}
+			users := &fakeUserService{}
+			oidcService := services.NewOIDCService(mockRepo, mockUserRepo, users, map[string]oidc.Provider{"mock": provider}, mockRedisClient)
+
+			browserState := "state1"
+			switch {
+			case tc.browserState != "":
+				browserState = tc.browserState
+			case tc.state != nil:
+				data, _ := json.Marshal(tc.state)
+				mockRedis.ExpectGetDel(stateKey).SetVal(string(data))
+			default:
+				mockRedis.ExpectGetDel(stateKey).RedisNil()
+			}
+			tc.mockSetup(mockRepo, mockUserRepo)
+
+			res, err := oidcService.Callback(context.Background(), "mock", models.OIDCCallbackRequest{Code: "code1", State: "state1"}, browserState, models.SessionDevice{})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, users.loggedIn)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access", res.Token)
+				assert.Equal(t, tc.expectedUser, users.loggedIn.Username)
+				assert.Equal(t, "nonce1", provider.nonce)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCreateReview(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name          string
+		mockSetup     func(mockRepo *mocks.MockReviewRepository, mockMovieRepo *mocks.MockMovieRepository)
+		expectedError error
+	}{
+		{
+			name: "Success - Review created",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1"}, nil)
+				mockRepo.EXPECT().FindByMovieAndUser(gomock.Any(), "movie1", "user1").Return(nil, nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&models.Review{MovieID: "movie1", UserID: "user1", Rating: 9}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Failure - Movie does not exist",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{}, sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			name: "Failure - Movie already reviewed",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1"}, nil)
+				mockRepo.EXPECT().FindByMovieAndUser(gomock.Any(), "movie1", "user1").Return(&models.Review{ID: "review1"}, nil)
+			},
+			expectedError: services.ErrReviewExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockReviewRepository(ctrl)
+			mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockSetup(mockRepo, mockMovieRepo)
+
+			reviewService := services.NewReviewService(mockRepo, mockMovieRepo)
+
+			review, err := reviewService.CreateReview(context.TODO(), "user1", "movie1", models.ReviewRequest{Rating: 9})
+
+			if tt.expectedError != nil {
+				assert.Nil(t, review)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 9, review.Rating)
+			}
+		})
+	}
+}
+
+func TestUpdateReview(t *testing.T) {
+	// Define test cases
+	tests := []struct {
+		name          string
+		userID        string
+		mockSetup     func(mockRepo *mocks.MockReviewRepository)
+		expectedError error
+	}{
+		{
+			name:   "Success - Own review updated",
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1", Rating: 5, Status: models.ReviewStatusApproved}, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &models.Review{ID: "review1", UserID: "user1", Rating: 7, Body: "Better on rewatch", Status: models.ReviewStatusApproved}).
+					Return(nil)
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1", Rating: 7}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Success - Edited rejected review goes back to the queue",
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1", Status: models.ReviewStatusRejected}, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &models.Review{
+						ID:               "review1",
+						UserID:           "user1",
+						Rating:           7,
+						Body:             "Better on rewatch",
+						Status:           models.ReviewStatusPending,
+						ModerationReason: "edited after rejection",
+					}).
+					Return(nil)
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1", Status: models.ReviewStatusPending}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Success - Edited pending review stays in the queue",
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1", Status: models.ReviewStatusPending, ModerationReason: "reported by users"}, nil)
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &models.Review{
+						ID:               "review1",
+						UserID:           "user1",
+						Rating:           7,
+						Body:             "Better on rewatch",
+						Status:           models.ReviewStatusPending,
+						ModerationReason: "reported by users",
+					}).
+					Return(nil)
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1", Status: models.ReviewStatusPending}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Failure - Review of another user",
+			userID: "user2",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1"}, nil)
+			},
+			expectedError: services.ErrReviewNotOwner,
+		},
+		{
+			name:   "Failure - Review does not exist",
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(nil, sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockReviewRepository(ctrl)
+			tt.mockSetup(mockRepo)
+
+			reviewService := services.NewReviewService(mockRepo, nil)
+
+			_, err := reviewService.UpdateReview(context.TODO(), tt.userID, "review1", models.ReviewRequest{Rating: 7, Body: "Better on rewatch"})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReviewRepository(ctrl)
+	mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+
+	mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1"}, nil)
+	mockRepo.EXPECT().GetRatingSummary(gomock.Any(), "movie1").Return(&models.RatingSummary{AverageRating: 8, RatingCount: 2}, nil)
+	// An unknown sort falls back to newest
+	mockRepo.EXPECT().ListByMovie(gomock.Any(), "movie1", models.ReviewSortNewest, 10, 0).Return([]models.Review{{ID: "review1"}, {ID: "review2"}}, nil)
+
+	reviewService := services.NewReviewService(mockRepo, mockMovieRepo)
+
+	page, err := reviewService.ListReviews(context.TODO(), "movie1", "oldest", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, page.Rating.AverageRating)
+	assert.Len(t, page.Reviews, 2)
+}
+
+func TestMarkHelpfulOwnReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReviewRepository(ctrl)
+	mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1"}, nil)
+
+	reviewService := services.NewReviewService(mockRepo, nil)
+
+	err := reviewService.MarkHelpful(context.TODO(), "user1", "review1")
+	assert.ErrorIs(t, err, services.ErrReviewOwnHelpful)
+}
+
+func TestCreateReviewBannedWord(t *testing.T) {
+	t.Setenv("BANNED_WORDS", "spoilerbait, scam")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReviewRepository(ctrl)
+	mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+
+	mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1"}, nil)
+	mockRepo.EXPECT().FindByMovieAndUser(gomock.Any(), "movie1", "user1").Return(nil, nil)
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, review *models.Review) {
+			// Matched as a word, ignoring case and punctuation
+			assert.Equal(t, models.ReviewStatusPending, review.Status)
+			assert.Equal(t, "contains banned word: scam", review.ModerationReason)
+		}).
+		Return(nil)
+	mockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&models.Review{Status: models.ReviewStatusPending}, nil)
+
+	reviewService := services.NewReviewService(mockRepo, mockMovieRepo)
+
+	_, err := reviewService.CreateReview(context.TODO(), "user1", "movie1", models.ReviewRequest{Rating: 1, Body: "Total SCAM!"})
+	assert.NoError(t, err)
+}
+
+func TestReportReview(t *testing.T) {
+	t.Setenv("REVIEW_REPORT_THRESHOLD", "2")
+
+	// Define test cases
+	tests := []struct {
+		name          string
+		userID        string
+		mockSetup     func(mockRepo *mocks.MockReviewRepository)
+		expectedError error
+	}{
+		{
+			name:   "Success - Review reported",
+			userID: "user2",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1"}, nil)
+				mockRepo.EXPECT().Report(gomock.Any(), "review1", "user2", "Harassment", 2).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Failure - Own review",
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockReviewRepository) {
+				mockRepo.EXPECT().FindByID(gomock.Any(), "review1").Return(&models.Review{ID: "review1", UserID: "user1"}, nil)
+			},
+			expectedError: services.ErrReviewOwnReport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockReviewRepository(ctrl)
+			tt.mockSetup(mockRepo)
+
+			reviewService := services.NewReviewService(mockRepo, nil)
+
+			err := reviewService.ReportReview(context.TODO(), tt.userID, "review1", models.ReportReviewRequest{Reason: "Harassment"})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestModerationQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReviewRepository(ctrl)
+	mockRepo.EXPECT().ListByStatus(gomock.Any(), models.ReviewStatusPending, 10, 0).Return([]models.Review{{ID: "review1"}}, nil)
+	mockRepo.EXPECT().Moderate(gomock.Any(), "review1", "admin1", models.ModerationActionReject, "Spam").Return(nil)
+
+	reviewService := services.NewReviewService(mockRepo, nil)
+
+	// The queue defaults to pending reviews
+	reviews, err := reviewService.ListModerationQueue(context.TODO(), "", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1)
+
+	_, err = reviewService.ListModerationQueue(context.TODO(), "hidden", 10, 0)
+	assert.ErrorIs(t, err, services.ErrInvalidReviewStatus)
+
+	err = reviewService.ModerateReview(context.TODO(), "admin1", "review1", models.ModerationActionReject, models.ModerationRequest{Reason: "Spam"})
+	assert.NoError(t, err)
+
+	err = reviewService.ModerateReview(context.TODO(), "admin1", "review1", "delete", models.ModerationRequest{})
+	assert.ErrorIs(t, err, services.ErrInvalidModerationAction)
+}
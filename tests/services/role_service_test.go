@@ -0,0 +1,304 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestCreateRole(t *testing.T) {
+	admin := &helpers.Claims{UserID: "admin1", Role: models.RoleAdmin}
+	manager := &helpers.Claims{UserID: "admin2", Role: "manager"}
+
+	// Define test cases
+	tests := []struct {
+		name          string
+		actor         *helpers.Claims
+		request       models.RoleRequest
+		mockSetup     func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name:    "Success - Role created",
+			actor:   admin,
+			request: models.RoleRequest{Name: "curator", Permissions: []string{models.PermissionMovieWrite, models.PermissionMovieWrite}},
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectGet("role:permissions:admin").SetVal(`["movie:write","role:manage"]`)
+				mockRepo.EXPECT().FindByName(gomock.Any(), "curator").Return(nil, sql.ErrNoRows)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, role *models.Role) error {
+						// Duplicated permissions are dropped
+						assert.Equal(t, []string{models.PermissionMovieWrite}, role.Permissions)
+						return nil
+					})
+			},
+		},
+		{
+			name:    "Failure - Role grants permissions the admin does not have",
+			actor:   manager,
+			request: models.RoleRequest{Name: "curator", Permissions: []string{models.PermissionUserManage}},
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectGet("role:permissions:manager").SetVal(`["role:manage"]`)
+			},
+			expectedError: services.ErrRoleEscalation,
+		},
+		{
+			name:          "Failure - Invalid role name",
+			actor:         admin,
+			request:       models.RoleRequest{Name: "Curator Team"},
+			mockSetup:     func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {},
+			expectedError: services.ErrInvalidRoleName,
+		},
+		{
+			name:          "Failure - Unknown permission",
+			actor:         admin,
+			request:       models.RoleRequest{Name: "curator", Permissions: []string{"movie:delete"}},
+			mockSetup:     func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {},
+			expectedError: services.ErrUnknownPermission,
+		},
+		{
+			name:    "Failure - Role already exists",
+			actor:   admin,
+			request: models.RoleRequest{Name: "editor"},
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectGet("role:permissions:admin").SetVal(`["movie:write","role:manage"]`)
+				mockRepo.EXPECT().FindByName(gomock.Any(), "editor").Return(&models.Role{Name: "editor"}, nil)
+			},
+			expectedError: services.ErrRoleExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRoleRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tt.mockSetup(mockRepo, mockRedis)
+
+			roleService := services.NewRoleService(mockRepo, mockRedisClient)
+
+			_, err := roleService.CreateRole(context.Background(), tt.actor, tt.request)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateAndDeleteRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRoleRepository(ctrl)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+	roleService := services.NewRoleService(mockRepo, mockRedisClient)
+	ctx := context.Background()
+	admin := &helpers.Claims{UserID: "admin1", Role: models.RoleAdmin}
+	manager := &helpers.Claims{UserID: "admin2", Role: "manager"}
+
+	// The built-in roles cannot change
+	_, err := roleService.UpdateRole(ctx, admin, models.RoleAdmin, models.RoleRequest{Name: models.RoleAdmin})
+	assert.ErrorIs(t, err, services.ErrBuiltinRole)
+	err = roleService.DeleteRole(ctx, admin, models.RoleUser)
+	assert.ErrorIs(t, err, services.ErrBuiltinRole)
+
+	// A role cannot be given permissions the admin does not have
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	_, err = roleService.UpdateRole(ctx, manager, "manager", models.RoleRequest{Name: "manager", Permissions: []string{models.PermissionRoleManage, models.PermissionUserManage}})
+	assert.ErrorIs(t, err, services.ErrRoleEscalation)
+
+	// Nor can a role granting more than the admin be changed
+	mockRedis.ExpectGet("role:permissions:supervisor").SetVal(`["role:manage","user:manage"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	_, err = roleService.UpdateRole(ctx, manager, "supervisor", models.RoleRequest{Name: "supervisor"})
+	assert.ErrorIs(t, err, services.ErrRoleEscalation)
+
+	// Updating a role drops its cached permissions
+	mockRedis.ExpectGet("role:permissions:editor").SetVal(`["movie:write"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	mockRedis.ExpectDel("role:permissions:editor").SetVal(1)
+	_, err = roleService.UpdateRole(ctx, manager, "editor", models.RoleRequest{Name: "editor", Permissions: []string{models.PermissionMovieWrite}})
+	assert.NoError(t, err)
+
+	// Nor deleted
+	mockRedis.ExpectGet("role:permissions:supervisor").SetVal(`["role:manage","user:manage"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	err = roleService.DeleteRole(ctx, manager, "supervisor")
+	assert.ErrorIs(t, err, services.ErrRoleEscalation)
+
+	// A role still assigned to users cannot be deleted
+	mockRedis.ExpectGet("role:permissions:editor").SetVal(`["movie:write"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRepo.EXPECT().CountUsers(gomock.Any(), "editor").Return(2, nil)
+	err = roleService.DeleteRole(ctx, manager, "editor")
+	assert.ErrorIs(t, err, services.ErrRoleInUse)
+
+	// Even when it was assigned after the users were counted
+	mockRedis.ExpectGet("role:permissions:editor").SetVal(`["movie:write"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRepo.EXPECT().CountUsers(gomock.Any(), "editor").Return(0, nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), "editor").Return(repositories.ErrRoleAssigned)
+	err = roleService.DeleteRole(ctx, manager, "editor")
+	assert.ErrorIs(t, err, services.ErrRoleInUse)
+
+	mockRedis.ExpectGet("role:permissions:analyst").SetVal(`["movie:write"]`)
+	mockRedis.ExpectGet("role:permissions:manager").SetVal(`["movie:write","role:manage"]`)
+	mockRepo.EXPECT().CountUsers(gomock.Any(), "analyst").Return(0, nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), "analyst").Return(nil)
+	mockRedis.ExpectDel("role:permissions:analyst").SetVal(1)
+	err = roleService.DeleteRole(ctx, manager, "analyst")
+	assert.NoError(t, err)
+
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
+
+func TestAssignRole(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	moderator := &helpers.Claims{UserID: "admin1", Role: "moderator"}
+	admin := &helpers.Claims{UserID: "admin1", Role: models.RoleAdmin}
+
+	// Define test cases
+	tests := []struct {
+		name          string
+		actor         *helpers.Claims
+		userID        string
+		role          string
+		mockSetup     func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name:   "Success - Role assigned and old tokens rejected",
+			actor:  admin,
+			userID: "user2",
+			role:   "editor",
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "editor").Return(&models.Role{Name: "editor", Permissions: []string{models.PermissionMovieWrite}}, nil)
+				mockRedis.ExpectGet("role:permissions:admin").SetVal(`["movie:write","analytics:read","review:moderate","user:manage","role:manage"]`)
+				mockRepo.EXPECT().FindUserRole(gomock.Any(), "user2").Return(models.RoleUser, nil)
+				mockRedis.ExpectGet("role:permissions:user").SetVal(`[]`)
+				mockRepo.EXPECT().AssignToUser(gomock.Any(), "user2", "editor").Return(nil)
+				mockRedis.Regexp().ExpectSet("role:changed:user2", `^\d+$`, 15*time.Minute).SetVal("OK")
+			},
+		},
+		{
+			name:   "Failure - Role grants permissions the admin does not have",
+			actor:  moderator,
+			userID: "user2",
+			role:   "editor",
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "editor").Return(&models.Role{Name: "editor", Permissions: []string{models.PermissionMovieWrite}}, nil)
+				mockRedis.ExpectGet("role:permissions:moderator").SetVal(`["review:moderate"]`)
+			},
+			expectedError: services.ErrRoleEscalation,
+		},
+		{
+			name:   "Failure - User holds permissions the admin does not have",
+			actor:  moderator,
+			userID: "user2",
+			role:   models.RoleUser,
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), models.RoleUser).Return(&models.Role{Name: models.RoleUser}, nil)
+				mockRedis.ExpectGet("role:permissions:moderator").SetVal(`["review:moderate"]`)
+				mockRepo.EXPECT().FindUserRole(gomock.Any(), "user2").Return(models.RoleAdmin, nil)
+				mockRedis.ExpectGet("role:permissions:admin").SetVal(`["movie:write","analytics:read","review:moderate","user:manage","role:manage"]`)
+			},
+			expectedError: services.ErrRoleEscalation,
+		},
+		{
+			name:   "Failure - Unknown role",
+			actor:  admin,
+			userID: "user2",
+			role:   "superuser",
+			mockSetup: func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindByName(gomock.Any(), "superuser").Return(nil, sql.ErrNoRows)
+			},
+			expectedError: services.ErrUnknownRole,
+		},
+		{
+			name:          "Failure - Own role",
+			actor:         admin,
+			userID:        "admin1",
+			role:          models.RoleUser,
+			mockSetup:     func(mockRepo *mocks.MockRoleRepository, mockRedis redismock.ClientMock) {},
+			expectedError: services.ErrChangeOwnRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRoleRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tt.mockSetup(mockRepo, mockRedis)
+
+			roleService := services.NewRoleService(mockRepo, mockRedisClient)
+
+			err := roleService.AssignRole(context.Background(), tt.actor, tt.userID, tt.role)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRoleRepository(ctrl)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+	roleService := services.NewRoleService(mockRepo, mockRedisClient)
+	ctx := context.Background()
+
+	// Cached permissions skip the database
+	mockRedis.ExpectGet("role:permissions:analyst").SetVal(`["analytics:read"]`)
+	permissions, err := roleService.RolePermissions(ctx, "analyst")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.PermissionAnalyticsRead}, permissions)
+
+	// A cache miss loads the role and caches its permissions
+	mockRedis.ExpectGet("role:permissions:editor").RedisNil()
+	mockRepo.EXPECT().FindByName(gomock.Any(), "editor").Return(&models.Role{Name: "editor", Permissions: []string{models.PermissionMovieWrite}}, nil)
+	mockRedis.ExpectSet("role:permissions:editor", `["movie:write"]`, 10*time.Minute).SetVal("OK")
+	permissions, err = roleService.RolePermissions(ctx, "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.PermissionMovieWrite}, permissions)
+
+	// A deleted role grants nothing
+	mockRedis.ExpectGet("role:permissions:deleted").RedisNil()
+	mockRepo.EXPECT().FindByName(gomock.Any(), "deleted").Return(nil, sql.ErrNoRows)
+	mockRedis.ExpectSet("role:permissions:deleted", `[]`, 10*time.Minute).SetVal("OK")
+	permissions, err = roleService.RolePermissions(ctx, "deleted")
+	assert.NoError(t, err)
+	assert.Empty(t, permissions)
+
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
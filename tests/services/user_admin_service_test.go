@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRedisClient, _ := redismock.NewClientMock()
+	userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+	filter := models.UserFilter{Query: "john", Status: models.UserStatusDisabled, Limit: 10}
+	mockRepo.EXPECT().ListUsers(gomock.Any(), filter).Return([]models.User{{ID: "user1", Username: "johndoe"}}, nil)
+
+	users, err := userService.ListUsers(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	_, err = userService.ListUsers(context.Background(), models.UserFilter{Status: "banned", Limit: 10})
+	assert.ErrorIs(t, err, services.ErrInvalidUserStatus)
+}
+
+// adminRoles knows the admin role and a support role that only manages users
+var adminRoles = &fakeRoleService{permissions: map[string][]string{
+	models.RoleAdmin: {models.PermissionUserManage, models.PermissionRoleManage},
+	"support":        {models.PermissionUserManage},
+}}
+
+func TestDisableUser(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	admin := &helpers.Claims{UserID: "admin1", Role: models.RoleAdmin}
+	support := &helpers.Claims{UserID: "support1", Role: "support"}
+	disabledAt := time.Now()
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		actor         *helpers.Claims
+		userID        string
+		mockSetup     func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name:   "Success - User disabled and signed out",
+			actor:  admin,
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1"}, nil)
+				mockRepo.EXPECT().SetDisabled(gomock.Any(), "user1", true).Return(nil)
+				mockSessionRepo.EXPECT().RevokeAllSessions(gomock.Any(), "user1").Return([]string{"session1"}, nil)
+				mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+				mockRedis.Regexp().ExpectSet("user:signed_out:user1", `^\d+$`, 15*time.Minute).SetVal("OK")
+			},
+		},
+		{
+			name:   "Success - Disabled user is signed out again",
+			actor:  admin,
+			userID: "user1",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1", DisabledAt: &disabledAt}, nil)
+				mockSessionRepo.EXPECT().RevokeAllSessions(gomock.Any(), "user1").Return([]string{}, nil)
+				mockRedis.Regexp().ExpectSet("user:signed_out:user1", `^\d+$`, 15*time.Minute).SetVal("OK")
+			},
+		},
+		{
+			name:   "Failure - User has permissions the admin does not have",
+			actor:  support,
+			userID: "admin2",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "admin2").Return(&models.User{ID: "admin2", Role: models.RoleAdmin}, nil)
+			},
+			expectedError: services.ErrUserOutranks,
+		},
+		{
+			name:   "Failure - User not found",
+			actor:  admin,
+			userID: "user2",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user2").Return(nil, nil)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			name:   "Failure - Own account",
+			actor:  admin,
+			userID: "admin1",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+			},
+			expectedError: services.ErrDisableSelf,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRepo, mockSessionRepo, mockRedis)
+
+			userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, adminRoles)
+
+			err := userService.DisableUser(context.Background(), tc.actor, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+	notifier := &fakeNotifier{}
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").
+		Return(&models.User{ID: "user1", Username: "user123", Email: "user@example.com"}, nil)
+	mockRepo.EXPECT().RequirePasswordReset(gomock.Any(), "user1").Return(nil)
+	mockSessionRepo.EXPECT().RevokeAllSessions(gomock.Any(), "user1").Return([]string{"session1"}, nil)
+	mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+	mockRedis.Regexp().ExpectSet("user:signed_out:user1", `^\d+$`, 15*time.Minute).SetVal("OK")
+	mockRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, notifier, &fakeLockoutService{}, adminRoles)
+	support := &helpers.Claims{UserID: "support1", Role: "support"}
+
+	err := userService.ForcePasswordReset(context.Background(), support, "user1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+	if assert.Len(t, notifier.messages, 1) {
+		assert.Equal(t, "user@example.com", notifier.messages[0].Email)
+		assert.Contains(t, notifier.messages[0].Body, "An administrator asked you")
+	}
+
+	// An admin cannot be reset by a user with fewer permissions
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "admin2").Return(&models.User{ID: "admin2", Role: models.RoleAdmin}, nil)
+	err = userService.ForcePasswordReset(context.Background(), support, "admin2")
+	assert.ErrorIs(t, err, services.ErrUserOutranks)
+}
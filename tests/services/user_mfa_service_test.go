@@ -0,0 +1,417 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/helpers"
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+// The SHA-1 test secret of RFC 6238
+const testMFASecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// mfaRoles knows the admin role and a custom role granting an administrative permission
+var mfaRoles = &fakeRoleService{permissions: map[string][]string{
+	models.RoleAdmin: {models.PermissionUserManage, models.PermissionRoleManage},
+	"organizer":      {models.PermissionFestivalManage},
+}}
+
+func TestLoginMFAChallenge(t *testing.T) {
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	passwordHash := "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS" // bcrypt hash for "password123"
+
+	// Define test cases
+	testCases := []struct {
+		name               string
+		user               *models.User
+		requireAdminMFA    string
+		expectedEnrollment bool
+	}{
+		{
+			name:               "User with MFA enabled gets a challenge",
+			user:               &models.User{ID: "user1", Username: "user123", PasswordHash: passwordHash, Role: "user", MFASecret: testMFASecret, MFAEnabledAt: &enabledAt},
+			requireAdminMFA:    "false",
+			expectedEnrollment: false,
+		},
+		{
+			name:               "Admin without MFA has to enroll when it is required",
+			user:               &models.User{ID: "admin1", Username: "admin123", PasswordHash: passwordHash, Role: "admin"},
+			requireAdminMFA:    "true",
+			expectedEnrollment: true,
+		},
+		{
+			name:               "Custom role with admin permissions has to enroll when it is required",
+			user:               &models.User{ID: "organizer1", Username: "organizer123", PasswordHash: passwordHash, Role: "organizer"},
+			requireAdminMFA:    "true",
+			expectedEnrollment: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("MFA_REQUIRED_FOR_ADMIN", tc.requireAdminMFA)
+			t.Setenv("MFA_CHALLENGE_TTL", "5m")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().GetUserByUsername(gomock.Any(), tc.user.Username).Return(tc.user, nil)
+
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			mockRedis.Regexp().ExpectSet(`^mfa:challenge:[0-9a-f]{64}$`, `.*`, 5*time.Minute).SetVal("OK")
+
+			lockouts := &fakeLockoutService{}
+			// No session is opened before the second factor is given
+			userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, mfaRoles)
+
+			res, err := userService.Login(context.Background(), tc.user.Username, "password123", models.SessionDevice{})
+
+			assert.NoError(t, err)
+			assert.True(t, res.MFARequired)
+			assert.NotEmpty(t, res.MFAToken)
+			assert.Equal(t, tc.expectedEnrollment, res.MFAEnrollmentRequired)
+			assert.Nil(t, res.TokenPair)
+			assert.Zero(t, lockouts.successes)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCompleteMFALogin(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+	t.Setenv("MFA_CHALLENGE_TTL", "5m")
+
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	enrolled := &models.User{ID: "user1", Username: "user123", Role: "user", MFASecret: testMFASecret, MFAEnabledAt: &enabledAt}
+	enrolling := &models.User{ID: "user1", Username: "user123", Role: "admin", MFASecret: testMFASecret}
+
+	challengeKey := "mfa:challenge:" + helpers.HashToken("mfa-token")
+	challenge, _ := json.Marshal(models.MFAChallenge{UserID: "user1"})
+
+	code, err := helpers.TOTPCode(testMFASecret, time.Now())
+	require.NoError(t, err)
+
+	// expectChallenge sets up the lookup of the challenge and the count of its attempts
+	expectChallenge := func(mockRepo *mocks.MockUserRepository, mockRedis redismock.ClientMock, user *models.User) {
+		mockRedis.ExpectGet(challengeKey).SetVal(string(challenge))
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+		mockRedis.ExpectIncr(challengeKey + ":attempts").SetVal(1)
+		mockRedis.ExpectExpire(challengeKey+":attempts", 5*time.Minute).SetVal(true)
+	}
+
+	// Define test cases
+	testCases := []struct {
+		name                  string
+		code                  string
+		mockSetup             func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock)
+		expectedError         error
+		expectedRecoveryCodes int
+		expectedFailures      int
+	}{
+		{
+			name: "Success - TOTP code",
+			code: code,
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expectChallenge(mockRepo, mockRedis, enrolled)
+				mockRedis.Regexp().ExpectSetNX(`^mfa:used:user1:\d+$`, "true", 2*time.Minute).SetVal(true)
+				mockRedis.ExpectDel(challengeKey, challengeKey+":attempts").SetVal(2)
+				mockSessionRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Success - Recovery code",
+			code: "ABCD-EFGH",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expectChallenge(mockRepo, mockRedis, enrolled)
+				mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), "user1", helpers.HashToken("abcd-efgh")).Return(nil)
+				mockRedis.ExpectDel(challengeKey, challengeKey+":attempts").SetVal(2)
+				mockSessionRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Success - Enrollment during the login returns the recovery codes",
+			code: code,
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expectChallenge(mockRepo, mockRedis, enrolling)
+				mockRedis.Regexp().ExpectSetNX(`^mfa:used:user1:\d+$`, "true", 2*time.Minute).SetVal(true)
+				mockRepo.EXPECT().EnableMFA(gomock.Any(), "user1", gomock.Len(10)).Return(nil)
+				mockRedis.ExpectDel(challengeKey, challengeKey+":attempts").SetVal(2)
+				mockSessionRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedRecoveryCodes: 10,
+		},
+		{
+			name: "Failure - Replayed TOTP code",
+			code: code,
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expectChallenge(mockRepo, mockRedis, enrolled)
+				mockRedis.Regexp().ExpectSetNX(`^mfa:used:user1:\d+$`, "true", 2*time.Minute).SetVal(false)
+				mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), "user1", gomock.Any()).Return(sql.ErrNoRows)
+			},
+			expectedError:    services.ErrInvalidMFACode,
+			expectedFailures: 1,
+		},
+		{
+			name: "Failure - Wrong code counts as a failed login",
+			code: "000000",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expectChallenge(mockRepo, mockRedis, enrolled)
+				mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), "user1", gomock.Any()).Return(sql.ErrNoRows)
+			},
+			expectedError:    services.ErrInvalidMFACode,
+			expectedFailures: 1,
+		},
+		{
+			name: "Failure - Unknown MFA token",
+			code: code,
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectGet(challengeKey).RedisNil()
+			},
+			expectedError: services.ErrInvalidMFAToken,
+		},
+		{
+			name: "Failure - Too many attempts end the challenge",
+			code: code,
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRedis.ExpectGet(challengeKey).SetVal(string(challenge))
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(enrolled, nil)
+				mockRedis.ExpectIncr(challengeKey + ":attempts").SetVal(6)
+				mockRedis.ExpectDel(challengeKey, challengeKey+":attempts").SetVal(2)
+			},
+			expectedError: services.ErrInvalidMFAToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRepo, mockSessionRepo, mockRedis)
+
+			lockouts := &fakeLockoutService{}
+			userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+			res, err := userService.CompleteMFALogin(context.Background(), models.MFALoginRequest{MFAToken: "mfa-token", Code: tc.code}, models.SessionDevice{})
+
+			if tc.expectedError != nil {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, res.Token)
+				assert.NotEmpty(t, res.RefreshToken)
+				assert.Len(t, res.RecoveryCodes, tc.expectedRecoveryCodes)
+				assert.Equal(t, 1, lockouts.successes)
+			}
+			assert.Equal(t, tc.expectedFailures, lockouts.failures)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEnableMFA(t *testing.T) {
+	code, err := helpers.TOTPCode(testMFASecret, time.Now())
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(&models.User{ID: "user1", Username: "user123", MFASecret: testMFASecret}, nil)
+	mockRepo.EXPECT().EnableMFA(gomock.Any(), "user1", gomock.Len(10)).Return(nil)
+
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+	mockRedis.ExpectIncr("mfa:attempts:user1").SetVal(1)
+	mockRedis.ExpectExpire("mfa:attempts:user1", 5*time.Minute).SetVal(true)
+	mockRedis.Regexp().ExpectSetNX(`^mfa:used:user1:\d+$`, "true", 2*time.Minute).SetVal(true)
+	mockRedis.ExpectDel("mfa:attempts:user1").SetVal(1)
+
+	userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+	codes, err := userService.EnableMFA(context.Background(), &helpers.Claims{UserID: "user1"}, code, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
+
+func TestRegenerateRecoveryCodesAttempts(t *testing.T) {
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	user := &models.User{ID: "user1", Username: "user123", MFASecret: testMFASecret, MFAEnabledAt: &enabledAt}
+
+	t.Run("Failure - Wrong code counts as a failed login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+		mockRedis.ExpectIncr("mfa:attempts:user1").SetVal(2)
+
+		lockouts := &fakeLockoutService{}
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		_, err := userService.RegenerateRecoveryCodes(context.Background(), &helpers.Claims{UserID: "user1"}, "000000", "10.0.0.1")
+
+		assert.ErrorIs(t, err, services.ErrInvalidMFACode)
+		assert.Equal(t, 1, lockouts.failures)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+
+	t.Run("Failure - Too many codes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+		mockRedis.ExpectIncr("mfa:attempts:user1").SetVal(6)
+		mockRedis.ExpectTTL("mfa:attempts:user1").SetVal(3 * time.Minute)
+
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+		_, err := userService.RegenerateRecoveryCodes(context.Background(), &helpers.Claims{UserID: "user1"}, "000000", "10.0.0.1")
+
+		var blocked *services.LoginBlockedError
+		require.ErrorAs(t, err, &blocked)
+		assert.ErrorIs(t, err, services.ErrTooManyAttempts)
+		assert.Equal(t, 3*time.Minute, blocked.RetryAfter)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+
+	t.Run("Failure - Locked login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+
+		lockouts := &fakeLockoutService{checkErr: &services.LoginBlockedError{Err: services.ErrAccountLocked, RetryAfter: time.Minute}}
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		_, err := userService.RegenerateRecoveryCodes(context.Background(), &helpers.Claims{UserID: "user1"}, "000000", "10.0.0.1")
+
+		assert.ErrorIs(t, err, services.ErrAccountLocked)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+}
+
+func TestDisableMFA(t *testing.T) {
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	admin := &models.User{
+		ID:           "admin1",
+		Username:     "admin123",
+		PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS", // bcrypt hash for "password123"
+		Role:         "admin",
+		MFASecret:    testMFASecret,
+		MFAEnabledAt: &enabledAt,
+	}
+
+	t.Run("Failure - Required for admins", func(t *testing.T) {
+		t.Setenv("MFA_REQUIRED_FOR_ADMIN", "true")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "admin1").Return(admin, nil)
+		mockRedisClient, _ := redismock.NewClientMock()
+
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, mfaRoles)
+
+		err := userService.DisableMFA(context.Background(), &helpers.Claims{UserID: "admin1"}, models.DisableMFARequest{Password: "password123", Code: "abcd-efgh"}, "10.0.0.1")
+
+		assert.ErrorIs(t, err, services.ErrMFARequired)
+	})
+
+	t.Run("Success - Password and recovery code", func(t *testing.T) {
+		t.Setenv("MFA_REQUIRED_FOR_ADMIN", "false")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "admin1").Return(admin, nil)
+		mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), "admin1", helpers.HashToken("abcd-efgh")).Return(nil)
+		mockRepo.EXPECT().DisableMFA(gomock.Any(), "admin1").Return(nil)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+		mockRedis.ExpectIncr("mfa:attempts:admin1").SetVal(1)
+		mockRedis.ExpectExpire("mfa:attempts:admin1", 5*time.Minute).SetVal(true)
+		mockRedis.ExpectDel("mfa:attempts:admin1").SetVal(1)
+
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+		err := userService.DisableMFA(context.Background(), &helpers.Claims{UserID: "admin1"}, models.DisableMFARequest{Password: "password123", Code: "abcd-efgh"}, "10.0.0.1")
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+
+	t.Run("Failure - Wrong password counts as a failed login", func(t *testing.T) {
+		t.Setenv("MFA_REQUIRED_FOR_ADMIN", "false")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "admin1").Return(admin, nil)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+
+		lockouts := &fakeLockoutService{}
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		err := userService.DisableMFA(context.Background(), &helpers.Claims{UserID: "admin1"}, models.DisableMFARequest{Password: "wrongpassword", Code: "abcd-efgh"}, "10.0.0.1")
+
+		assert.ErrorIs(t, err, services.ErrInvalidCurrentPassword)
+		assert.Equal(t, 1, lockouts.failures)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+
+	t.Run("Failure - Repeated wrong codes lock the account", func(t *testing.T) {
+		t.Setenv("MFA_REQUIRED_FOR_ADMIN", "false")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "admin1").Return(admin, nil).Times(4)
+		mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), "admin1", gomock.Any()).Return(sql.ErrNoRows).Times(3)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+		mockRedis.ExpectIncr("mfa:attempts:admin1").SetVal(1)
+		mockRedis.ExpectExpire("mfa:attempts:admin1", 5*time.Minute).SetVal(true)
+		mockRedis.ExpectIncr("mfa:attempts:admin1").SetVal(2)
+		mockRedis.ExpectIncr("mfa:attempts:admin1").SetVal(3)
+
+		lockouts := &fakeLockoutService{lockAfter: 3}
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		disable := func() error {
+			return userService.DisableMFA(context.Background(), &helpers.Claims{UserID: "admin1"}, models.DisableMFARequest{Password: "password123", Code: "wxyz-0000"}, "10.0.0.1")
+		}
+
+		assert.ErrorIs(t, disable(), services.ErrInvalidMFACode)
+		assert.ErrorIs(t, disable(), services.ErrInvalidMFACode)
+		assert.ErrorIs(t, disable(), services.ErrAccountLocked)
+		// The locked account is refused before the code is checked
+		assert.ErrorIs(t, disable(), services.ErrAccountLocked)
+		assert.Equal(t, 3, lockouts.failures)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+}
@@ -2,22 +2,79 @@ package services_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-redis/redismock/v8"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/stwrtrio/movie-festival/internal/helpers"
 	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/notifiers"
 	"github.com/stwrtrio/movie-festival/internal/services"
 	"github.com/stwrtrio/movie-festival/tests/mocks"
 )
 
+// fakeNotifier keeps the sent messages for the assertions
+type fakeNotifier struct {
+	messages []notifiers.Message
+}
+
+func (n *fakeNotifier) Send(ctx context.Context, msg notifiers.Message) error {
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// fakeLockoutService counts the recorded logins and blocks them with the configured errors
+type fakeLockoutService struct {
+	checkErr  error // returned by CheckLogin
+	failedErr error // returned by RecordFailedLogin
+	lockAfter int   // locks the account once this many failures are recorded, when set
+	failures  int
+	successes int
+}
+
+func (f *fakeLockoutService) CheckLogin(ctx context.Context, username, ipAddress string) error {
+	if f.locked() {
+		return &services.LoginBlockedError{Err: services.ErrAccountLocked, RetryAfter: 15 * time.Minute}
+	}
+	return f.checkErr
+}
+
+func (f *fakeLockoutService) RecordFailedLogin(ctx context.Context, username, ipAddress string) error {
+	f.failures++
+	if f.locked() {
+		return &services.LoginBlockedError{Err: services.ErrAccountLocked, RetryAfter: 15 * time.Minute}
+	}
+	return f.failedErr
+}
+
+func (f *fakeLockoutService) locked() bool {
+	return f.lockAfter > 0 && f.failures >= f.lockAfter
+}
+
+func (f *fakeLockoutService) RecordSuccessfulLogin(ctx context.Context, username string) error {
+	f.successes++
+	return nil
+}
+
+func (f *fakeLockoutService) Unlock(ctx context.Context, adminID string, req models.UnlockLoginRequest) error {
+	return nil
+}
+
+func (f *fakeLockoutService) ListEvents(ctx context.Context, scope, subject string, limit, offset int) ([]models.LockoutEvent, error) {
+	return nil, nil
+}
+
 func TestMain(m *testing.M) {
 	// Load .env file
 	if err := godotenv.Load("../../.env"); err != nil {
@@ -41,7 +98,7 @@ func TestRegister(t *testing.T) {
 			name: "Successful registration",
 			request: models.RegisterRequest{
 				Username: "newuser",
-				Password: "securepassword",
+				Password: "Secure-password1",
 			},
 			mockSetup: func(mockRepo *mocks.MockUserRepository) {
 				// Mock GetUserByUsername to return nil, indicating the username does not exist
@@ -56,7 +113,7 @@ func TestRegister(t *testing.T) {
 			name: "Username already exists",
 			request: models.RegisterRequest{
 				Username: "existinguser",
-				Password: "securepassword",
+				Password: "Secure-password1",
 			},
 			mockSetup: func(mockRepo *mocks.MockUserRepository) {
 				// Mock GetUserByUsername to return an existing user
@@ -69,11 +126,46 @@ func TestRegister(t *testing.T) {
 			},
 			expectedError: "username already exists",
 		},
+		{
+			name: "Weak password",
+			request: models.RegisterRequest{
+				Username: "newuser",
+				Password: "password",
+			},
+			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "newuser").Return(nil, nil)
+			},
+			expectedError: services.ErrWeakPassword.Error(),
+		},
+		{
+			name: "Password contains the username",
+			request: models.RegisterRequest{
+				Username: "newuser",
+				Password: "NewUser-2026",
+			},
+			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "newuser").Return(nil, nil)
+			},
+			expectedError: "must not contain the username",
+		},
+		{
+			name: "Email already exists",
+			request: models.RegisterRequest{
+				Username: "newuser",
+				Email:    "taken@example.com",
+				Password: "Secure-password1",
+			},
+			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+				mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "newuser").Return(nil, nil)
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "taken@example.com").Return(&models.User{ID: "user2"}, nil)
+			},
+			expectedError: services.ErrEmailExists.Error(),
+		},
 		{
 			name: "Repository error on CreateUser",
 			request: models.RegisterRequest{
 				Username: "newuser",
-				Password: "securepassword",
+				Password: "Secure-password1",
 			},
 			mockSetup: func(mockRepo *mocks.MockUserRepository) {
 				// Mock GetUserByUsername to return nil
@@ -103,7 +195,7 @@ func TestRegister(t *testing.T) {
 			mockRedisClient, _ := redismock.NewClientMock()
 
 			// Create the service
-			userService := services.NewUserService(mockRepo, mockRedisClient)
+			userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
 
 			// Call the service method
 			err := userService.Register(context.Background(), tc.request)
@@ -126,7 +218,7 @@ func TestUserLogin(t *testing.T) {
 		username      string
 		password      string
 		mockUser      *models.User
-		mockSetup     func(mockRepo *mocks.MockUserRepository)
+		mockSetup     func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository)
 		mockError     error
 		expectedToken string
 		expectedError string
@@ -141,7 +233,7 @@ func TestUserLogin(t *testing.T) {
 				PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS", // bcrypt hash for "password123"
 				Role:         "user",
 			},
-			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository) {
 				mockRepo.EXPECT().
 					GetUserByUsername(gomock.Any(), "user123").
 					Return(&models.User{
@@ -150,6 +242,9 @@ func TestUserLogin(t *testing.T) {
 						PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS", // bcrypt hash for "password123"
 						Role:         "user",
 					}, nil)
+
+				// Mock CreateSession to open the session of the login
+				mockSessionRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedToken: "non-empty", // Check for a non-empty token
 			expectedError: "",
@@ -164,7 +259,7 @@ func TestUserLogin(t *testing.T) {
 				PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS",
 				Role:         "user",
 			},
-			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository) {
 				mockRepo.EXPECT().
 					GetUserByUsername(gomock.Any(), "user123").
 					Return(&models.User{
@@ -177,12 +272,49 @@ func TestUserLogin(t *testing.T) {
 			expectedToken: "",
 			expectedError: "invalid credentials",
 		},
+		{
+			name:     "Disabled account",
+			username: "user123",
+			password: "password123",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				disabledAt := time.Now()
+				mockRepo.EXPECT().
+					GetUserByUsername(gomock.Any(), "user123").
+					Return(&models.User{
+						ID:           uuid.NewString(),
+						Username:     "user123",
+						PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS",
+						Role:         "user",
+						DisabledAt:   &disabledAt,
+					}, nil)
+			},
+			expectedToken: "",
+			expectedError: services.ErrAccountDisabled.Error(),
+		},
+		{
+			name:     "Password reset required",
+			username: "user123",
+			password: "password123",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository) {
+				mockRepo.EXPECT().
+					GetUserByUsername(gomock.Any(), "user123").
+					Return(&models.User{
+						ID:                    uuid.NewString(),
+						Username:              "user123",
+						PasswordHash:          "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS",
+						Role:                  "user",
+						PasswordResetRequired: true,
+					}, nil)
+			},
+			expectedToken: "",
+			expectedError: services.ErrPasswordResetRequired.Error(),
+		},
 		{
 			name:     "User not found",
 			username: "user123",
 			password: "password123",
 			mockUser: nil,
-			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository) {
 				mockRepo.EXPECT().
 					GetUserByUsername(gomock.Any(), "user123").
 					Return(nil, nil)
@@ -195,7 +327,7 @@ func TestUserLogin(t *testing.T) {
 			username: "user123",
 			password: "password123",
 			mockUser: nil,
-			mockSetup: func(mockRepo *mocks.MockUserRepository) {
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository) {
 				mockRepo.EXPECT().
 					GetUserByUsername(gomock.Any(), "user123").
 					Return(nil, errors.New("database error"))
@@ -212,20 +344,21 @@ func TestUserLogin(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			// Create a mock repository
+			// Create the mock repositories
 			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 
 			// Setup the mock behavior for the current test case
-			tc.mockSetup(mockRepo)
+			tc.mockSetup(mockRepo, mockSessionRepo)
 
 			// Mock Redis client
 			mockRedisClient, _ := redismock.NewClientMock()
 
 			// Create the service
-			userService := services.NewUserService(mockRepo, mockRedisClient)
+			userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
 
 			// Call the service method
-			token, err := userService.Login(context.Background(), tc.username, tc.password)
+			tokens, err := userService.Login(context.Background(), tc.username, tc.password, models.SessionDevice{UserAgent: "test-agent", IPAddress: "10.0.0.1"})
 
 			// Validate results
 			if tc.expectedError != "" {
@@ -236,10 +369,534 @@ func TestUserLogin(t *testing.T) {
 			}
 
 			if tc.expectedToken == "non-empty" {
-				assert.NotEmpty(t, token)
+				assert.NotEmpty(t, tokens.Token)
+				assert.NotEmpty(t, tokens.RefreshToken)
 			} else {
-				assert.Equal(t, tc.expectedToken, token)
+				assert.Nil(t, tokens)
+			}
+		})
+	}
+}
+
+func TestUserLoginLockout(t *testing.T) {
+	user := &models.User{
+		ID:           "user1",
+		Username:     "user123",
+		PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS", // bcrypt hash for "password123"
+		Role:         "user",
+	}
+	device := models.SessionDevice{IPAddress: "10.0.0.1"}
+
+	t.Run("Locked account is refused before checking the password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRedisClient, _ := redismock.NewClientMock()
+		lockouts := &fakeLockoutService{checkErr: &services.LoginBlockedError{Err: services.ErrAccountLocked, RetryAfter: time.Minute}}
+		userService := services.NewUserService(mocks.NewMockUserRepository(ctrl), mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		tokens, err := userService.Login(context.Background(), "user123", "password123", device)
+
+		assert.Nil(t, tokens)
+		assert.ErrorIs(t, err, services.ErrAccountLocked)
+		assert.Zero(t, lockouts.failures)
+	})
+
+	t.Run("Failure that locks the account returns the lockout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "user123").Return(user, nil)
+
+		mockRedisClient, _ := redismock.NewClientMock()
+		lockouts := &fakeLockoutService{failedErr: &services.LoginBlockedError{Err: services.ErrAccountLocked, RetryAfter: 15 * time.Minute}}
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		tokens, err := userService.Login(context.Background(), "user123", "wrongpassword", device)
+
+		assert.Nil(t, tokens)
+		var blocked *services.LoginBlockedError
+		if assert.ErrorAs(t, err, &blocked) {
+			assert.Equal(t, 15*time.Minute, blocked.RetryAfter)
+		}
+		assert.Equal(t, 1, lockouts.failures)
+	})
+
+	t.Run("Unknown username counts as a failed login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "nobody").Return(nil, nil)
+
+		mockRedisClient, _ := redismock.NewClientMock()
+		lockouts := &fakeLockoutService{}
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		_, err := userService.Login(context.Background(), "nobody", "password123", device)
+
+		assert.EqualError(t, err, "invalid credentials")
+		assert.Equal(t, 1, lockouts.failures)
+	})
+
+	t.Run("Successful login clears the failed logins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "user123").Return(user, nil)
+		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+		mockSessionRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		mockRedisClient, _ := redismock.NewClientMock()
+		lockouts := &fakeLockoutService{}
+		userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+		tokens, err := userService.Login(context.Background(), "user123", "password123", device)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, tokens)
+		assert.Equal(t, 1, lockouts.successes)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	user := &models.User{ID: "user1", Username: "user123", Role: "user"}
+	activeSession := models.Session{ID: "session1", UserID: "user1", ExpiresAt: time.Now().Add(time.Hour)}
+	usedAt := time.Now().Add(-time.Minute)
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		mockSetup     func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name: "Success - Token rotated",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockSessionRepo.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).
+					Return(&models.RefreshToken{ID: "token1", SessionID: "session1", Session: activeSession}, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+				mockSessionRepo.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Failure - Unknown token",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockSessionRepo.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			expectedError: services.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Failure - Session expired",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expired := activeSession
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mockSessionRepo.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).
+					Return(&models.RefreshToken{ID: "token1", SessionID: "session1", Session: expired}, nil)
+			},
+			expectedError: services.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Failure - Reused token revokes the session",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockSessionRepo.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).
+					Return(&models.RefreshToken{ID: "token1", SessionID: "session1", UsedAt: &usedAt, Session: activeSession}, nil)
+				mockSessionRepo.EXPECT().RevokeSession(gomock.Any(), "user1", "session1").Return(nil)
+				mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+			},
+			expectedError: services.ErrRefreshTokenReused,
+		},
+		{
+			name: "Failure - Concurrent rotation revokes the session",
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockSessionRepo.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).
+					Return(&models.RefreshToken{ID: "token1", SessionID: "session1", Session: activeSession}, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+				mockSessionRepo.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+				mockSessionRepo.EXPECT().RevokeSession(gomock.Any(), "user1", "session1").Return(nil)
+				mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+			},
+			expectedError: services.ErrRefreshTokenReused,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRepo, mockSessionRepo, mockRedis)
+
+			userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+			tokens, err := userService.Refresh(context.Background(), "refresh-token", models.SessionDevice{})
+
+			if tc.expectedError != nil {
+				assert.Nil(t, tokens)
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.Token)
+				assert.NotEqual(t, "refresh-token", tokens.RefreshToken)
+				assert.Equal(t, 900, tokens.ExpiresIn)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+
+	mockSessionRepo.EXPECT().RevokeAllSessions(gomock.Any(), "user1").Return([]string{"session1", "session2"}, nil)
+	mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+	mockRedis.ExpectSet("session:revoked:session2", "true", 15*time.Minute).SetVal("OK")
+	mockRedis.Regexp().ExpectSet("user:signed_out:user1", `^\d+$`, 15*time.Minute).SetVal("OK")
+
+	userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+	err := userService.RevokeAllSessions(context.Background(), "user1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
+
+func TestLogout(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+
+	claims := &helpers.Claims{UserID: "user1", JTI: "jti1", SessionID: "session1"}
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(10 * time.Minute))
+
+	// The TTL of the blacklisted JTI depends on the clock, only the key is checked
+	mockRedis.CustomMatch(func(expected, actual []interface{}) error {
+		if actual[1] != expected[1] {
+			return fmt.Errorf("expected key %v, got %v", expected[1], actual[1])
+		}
+		return nil
+	}).ExpectSet("jti1", "true", 10*time.Minute).SetVal("OK")
+	mockSessionRepo.EXPECT().RevokeSession(gomock.Any(), "user1", "session1").Return(nil)
+	mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+
+	userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+	err := userService.Logout(context.Background(), claims)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	user := &models.User{
+		ID:           "user1",
+		Username:     "user123",
+		PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS", // bcrypt hash for "password123"
+		Role:         "user",
+	}
+	claims := &helpers.Claims{UserID: "user1", Username: "user123", JTI: "jti1", SessionID: "session1"}
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		request       models.ChangePasswordRequest
+		mockSetup     func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name:    "Success - Sessions signed out and a new session opened",
+			request: models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+				mockRepo.EXPECT().UpdatePassword(gomock.Any(), "user1", "New-password1").Return(nil)
+				mockSessionRepo.EXPECT().RevokeAllSessions(gomock.Any(), "user1").Return([]string{"session1"}, nil)
+				mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+				mockRedis.Regexp().ExpectSet("user:signed_out:user1", `^\d+$`, 15*time.Minute).SetVal("OK")
+				mockSessionRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Failure - Wrong current password",
+			request: models.ChangePasswordRequest{CurrentPassword: "wrongpassword", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+			},
+			expectedError: services.ErrInvalidCurrentPassword,
+		},
+		{
+			name:    "Failure - Same password",
+			request: models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "password123"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+			},
+			expectedError: services.ErrSamePassword,
+		},
+		{
+			name:    "Failure - Weak new password",
+			request: models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "short"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+			},
+			expectedError: services.ErrWeakPassword,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRepo, mockSessionRepo, mockRedis)
+
+			userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+			tokens, err := userService.ChangePassword(context.Background(), claims, tc.request, models.SessionDevice{})
+
+			if tc.expectedError != nil {
+				assert.Nil(t, tokens)
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.Token)
+				assert.NotEmpty(t, tokens.RefreshToken)
+			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChangePasswordLockout(t *testing.T) {
+	user := &models.User{
+		ID:           "user1",
+		Username:     "user123",
+		PasswordHash: "$2a$10$7zOGb5S4F0TAMvuIEXJxH.yGjkoQ2I6ES4.l8P0e.mXJaX5aiRlYS", // bcrypt hash for "password123"
+		Role:         "user",
+	}
+	claims := &helpers.Claims{UserID: "user1", Username: "user123", JTI: "jti1", SessionID: "session1"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil).Times(4)
+	mockRedisClient, mockRedis := redismock.NewClientMock()
+
+	lockouts := &fakeLockoutService{lockAfter: 3}
+	userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, &fakeNotifier{}, lockouts, &fakeRoleService{})
+
+	changePassword := func(currentPassword string) error {
+		req := models.ChangePasswordRequest{CurrentPassword: currentPassword, NewPassword: "New-password1"}
+		_, err := userService.ChangePassword(context.Background(), claims, req, models.SessionDevice{IPAddress: "10.0.0.1"})
+		return err
+	}
+
+	assert.ErrorIs(t, changePassword("wrongpassword"), services.ErrInvalidCurrentPassword)
+	assert.ErrorIs(t, changePassword("wrongpassword"), services.ErrInvalidCurrentPassword)
+	assert.ErrorIs(t, changePassword("wrongpassword"), services.ErrAccountLocked)
+	// The locked account is refused even with the right password
+	assert.ErrorIs(t, changePassword("password123"), services.ErrAccountLocked)
+	assert.Equal(t, 3, lockouts.failures)
+	assert.NoError(t, mockRedis.ExpectationsWereMet())
+}
+
+func TestForgotPassword(t *testing.T) {
+	t.Run("Success - Token sent to the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+		mockRedis.ExpectIncr("password:forgot:user1").SetVal(1)
+		mockRedis.ExpectExpire("password:forgot:user1", time.Hour).SetVal(true)
+		notifier := &fakeNotifier{}
+
+		var tokenHash string
+		mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "user123").
+			Return(&models.User{ID: "user1", Username: "user123", Email: "user@example.com"}, nil)
+		mockRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, token *models.PasswordResetToken, hash string) error {
+				assert.Equal(t, "user1", token.UserID)
+				assert.WithinDuration(t, time.Now().Add(30*time.Minute), token.ExpiresAt, time.Minute)
+				tokenHash = hash
+				return nil
+			})
+
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, notifier, &fakeLockoutService{}, &fakeRoleService{})
+
+		err := userService.ForgotPassword(context.Background(), "user123")
+
+		assert.NoError(t, err)
+		if assert.Len(t, notifier.messages, 1) {
+			msg := notifier.messages[0]
+			assert.Equal(t, "user@example.com", msg.Email)
+			// Only the hash is stored, the token itself is in the message
+			assert.NotContains(t, msg.Body, tokenHash)
+		}
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Requests past the limit send nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRedisClient, mockRedis := redismock.NewClientMock()
+		mockRedis.ExpectIncr("password:forgot:user1").SetVal(4)
+		notifier := &fakeNotifier{}
+
+		mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "user123").
+			Return(&models.User{ID: "user1", Username: "user123", Email: "user@example.com"}, nil)
+
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, notifier, &fakeLockoutService{}, &fakeRoleService{})
+
+		err := userService.ForgotPassword(context.Background(), "user123")
+
+		assert.NoError(t, err)
+		assert.Empty(t, notifier.messages)
+		assert.NoError(t, mockRedis.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Unknown username sends nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockUserRepository(ctrl)
+		mockRedisClient, _ := redismock.NewClientMock()
+		notifier := &fakeNotifier{}
+
+		mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "nobody").Return(nil, nil)
+
+		userService := services.NewUserService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockRedisClient, notifier, &fakeLockoutService{}, &fakeRoleService{})
+
+		err := userService.ForgotPassword(context.Background(), "nobody")
+
+		assert.NoError(t, err)
+		assert.Empty(t, notifier.messages)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "15m")
+
+	user := &models.User{ID: "user1", Username: "user123", Role: "user"}
+	validToken := &models.PasswordResetToken{ID: "reset1", UserID: "user1", ExpiresAt: time.Now().Add(time.Minute)}
+	usedAt := time.Now().Add(-time.Minute)
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		request       models.ResetPasswordRequest
+		mockSetup     func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock)
+		expectedError error
+	}{
+		{
+			name:    "Success - Password reset and sessions signed out",
+			request: models.ResetPasswordRequest{Token: "reset-token", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindPasswordResetToken(gomock.Any(), helpers.HashToken("reset-token")).Return(validToken, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+				mockRepo.EXPECT().ResetPassword(gomock.Any(), validToken, "New-password1").Return(nil)
+				mockSessionRepo.EXPECT().RevokeAllSessions(gomock.Any(), "user1").Return([]string{"session1"}, nil)
+				mockRedis.ExpectSet("session:revoked:session1", "true", 15*time.Minute).SetVal("OK")
+				mockRedis.Regexp().ExpectSet("user:signed_out:user1", `^\d+$`, 15*time.Minute).SetVal("OK")
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Failure - Unknown token",
+			request: models.ResetPasswordRequest{Token: "reset-token", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindPasswordResetToken(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			expectedError: services.ErrInvalidResetToken,
+		},
+		{
+			name:    "Failure - Used token",
+			request: models.ResetPasswordRequest{Token: "reset-token", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				used := *validToken
+				used.UsedAt = &usedAt
+				mockRepo.EXPECT().FindPasswordResetToken(gomock.Any(), gomock.Any()).Return(&used, nil)
+			},
+			expectedError: services.ErrInvalidResetToken,
+		},
+		{
+			name:    "Failure - Expired token",
+			request: models.ResetPasswordRequest{Token: "reset-token", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				expired := *validToken
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mockRepo.EXPECT().FindPasswordResetToken(gomock.Any(), gomock.Any()).Return(&expired, nil)
+			},
+			expectedError: services.ErrInvalidResetToken,
+		},
+		{
+			name:    "Failure - Token used concurrently",
+			request: models.ResetPasswordRequest{Token: "reset-token", NewPassword: "New-password1"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindPasswordResetToken(gomock.Any(), gomock.Any()).Return(validToken, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+				mockRepo.EXPECT().ResetPassword(gomock.Any(), validToken, "New-password1").Return(sql.ErrNoRows)
+			},
+			expectedError: services.ErrInvalidResetToken,
+		},
+		{
+			name:    "Failure - Weak password",
+			request: models.ResetPasswordRequest{Token: "reset-token", NewPassword: "user123user"},
+			mockSetup: func(mockRepo *mocks.MockUserRepository, mockSessionRepo *mocks.MockSessionRepository, mockRedis redismock.ClientMock) {
+				mockRepo.EXPECT().FindPasswordResetToken(gomock.Any(), gomock.Any()).Return(validToken, nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), "user1").Return(user, nil)
+			},
+			expectedError: services.ErrWeakPassword,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+			mockRedisClient, mockRedis := redismock.NewClientMock()
+			tc.mockSetup(mockRepo, mockSessionRepo, mockRedis)
+
+			userService := services.NewUserService(mockRepo, mockSessionRepo, mockRedisClient, &fakeNotifier{}, &fakeLockoutService{}, &fakeRoleService{})
+
+			err := userService.ResetPassword(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
 			}
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
 		})
 	}
 }
@@ -0,0 +1,556 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/repositories"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func newVotingService(ctrl *gomock.Controller) (services.VotingService, *mocks.MockVotingRepository, *mocks.MockMovieRepository, *mocks.MockEditionRepository, *mocks.MockCompetitionRepository) {
+	mockRepo := mocks.NewMockVotingRepository(ctrl)
+	mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+	mockEditionRepo := mocks.NewMockEditionRepository(ctrl)
+	mockCompetitionRepo := mocks.NewMockCompetitionRepository(ctrl)
+
+	return services.NewVotingService(mockRepo, mockMovieRepo, mockEditionRepo, mockCompetitionRepo), mockRepo, mockMovieRepo, mockEditionRepo, mockCompetitionRepo
+}
+
+func TestVoteMovie(t *testing.T) {
+	budget := 1
+	sectionPeriod := models.VotingPeriod{ID: "period1", SectionID: "section1", VoteBudget: &budget}
+	editionPeriod := models.VotingPeriod{ID: "period2"}
+	candidates := []string{"period1", "period2"}
+
+	// Define test cases
+	testCases := []struct {
+		name        string
+		mockSetup   func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository)
+		expectedErr error
+	}{
+		{
+			name: "Success - Vote cast in the section period",
+			mockSetup: func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository) {
+				repo.EXPECT().ListOpenPeriodsForMovie(gomock.Any(), "movie1", gomock.Any()).Return([]models.VotingPeriod{sectionPeriod, editionPeriod}, nil)
+				repo.EXPECT().FindVote(gomock.Any(), "user1", "movie1", candidates).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().CastVote(gomock.Any(), "user1", "movie1", &sectionPeriod).Return(true, nil)
+			},
+		},
+		{
+			name: "Success - Vote falls back to the edition period when the section budget is spent",
+			mockSetup: func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository) {
+				repo.EXPECT().ListOpenPeriodsForMovie(gomock.Any(), "movie1", gomock.Any()).Return([]models.VotingPeriod{sectionPeriod, editionPeriod}, nil)
+				repo.EXPECT().FindVote(gomock.Any(), "user1", "movie1", candidates).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().CastVote(gomock.Any(), "user1", "movie1", &sectionPeriod).Return(false, nil)
+				repo.EXPECT().CastVote(gomock.Any(), "user1", "movie1", &editionPeriod).Return(true, nil)
+			},
+		},
+		{
+			name: "Failure - Already voted in an open period",
+			mockSetup: func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository) {
+				repo.EXPECT().ListOpenPeriodsForMovie(gomock.Any(), "movie1", gomock.Any()).Return([]models.VotingPeriod{sectionPeriod, editionPeriod}, nil)
+				repo.EXPECT().FindVote(gomock.Any(), "user1", "movie1", candidates).Return(&models.Vote{ID: "vote1", VotingPeriodID: "period2"}, nil)
+			},
+			expectedErr: services.ErrAlreadyVoted,
+		},
+		{
+			name: "Failure - Concurrent vote cast first",
+			mockSetup: func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository) {
+				repo.EXPECT().ListOpenPeriodsForMovie(gomock.Any(), "movie1", gomock.Any()).Return([]models.VotingPeriod{sectionPeriod}, nil)
+				repo.EXPECT().FindVote(gomock.Any(), "user1", "movie1", []string{"period1"}).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().CastVote(gomock.Any(), "user1", "movie1", &sectionPeriod).Return(false, repositories.ErrVoteExists)
+			},
+			expectedErr: services.ErrAlreadyVoted,
+		},
+		{
+			name: "Failure - No voting period open for the movie",
+			mockSetup: func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository) {
+				repo.EXPECT().ListOpenPeriodsForMovie(gomock.Any(), "movie1", gomock.Any()).Return([]models.VotingPeriod{}, nil)
+			},
+			expectedErr: services.ErrVotingClosed,
+		},
+		{
+			name: "Failure - Budget exhausted",
+			mockSetup: func(repo *mocks.MockVotingRepository, movieRepo *mocks.MockMovieRepository) {
+				repo.EXPECT().ListOpenPeriodsForMovie(gomock.Any(), "movie1", gomock.Any()).Return([]models.VotingPeriod{sectionPeriod}, nil)
+				repo.EXPECT().FindVote(gomock.Any(), "user1", "movie1", []string{"period1"}).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().CastVote(gomock.Any(), "user1", "movie1", &sectionPeriod).Return(false, nil)
+			},
+			expectedErr: services.ErrVoteBudgetExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			votingService, mockRepo, mockMovieRepo, _, _ := newVotingService(ctrl)
+			tc.mockSetup(mockRepo, mockMovieRepo)
+
+			err := votingService.VoteMovie(context.Background(), "user1", "movie1")
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestUnvoteMovie(t *testing.T) {
+	openPeriods := []models.VotingPeriod{{ID: "period1"}}
+
+	// Define test cases
+	testCases := []struct {
+		name        string
+		userID      string
+		movieID     string
+		mockSetup   func(repo *mocks.MockMovieRepository, votingRepo *mocks.MockVotingRepository)
+		expectedErr error
+	}{
+		{
+			name:    "Success Case",
+			userID:  "test-user-id",
+			movieID: "test-movie-id",
+			mockSetup: func(repo *mocks.MockMovieRepository, votingRepo *mocks.MockVotingRepository) {
+				votingRepo.EXPECT().ListOpenPeriodsForMovie(context.Background(), "test-movie-id", gomock.Any()).Return(nil, nil)
+				votingRepo.EXPECT().FindVote(context.Background(), "test-user-id", "test-movie-id", []string{}).Return(nil, sql.ErrNoRows)
+				// A vote cast before voting periods existed
+				repo.EXPECT().
+					GetVoteByUserAndMovie(context.Background(), "test-user-id", "test-movie-id").
+					Return(&models.Vote{ID: "test-vote-id"}, nil)
+
+				repo.EXPECT().
+					DeleteVote(context.Background(), "test-vote-id").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:    "Success - Voting period still open",
+			userID:  "test-user-id",
+			movieID: "test-movie-id",
+			mockSetup: func(repo *mocks.MockMovieRepository, votingRepo *mocks.MockVotingRepository) {
+				votingRepo.EXPECT().ListOpenPeriodsForMovie(context.Background(), "test-movie-id", gomock.Any()).Return(openPeriods, nil)
+				votingRepo.EXPECT().FindVote(context.Background(), "test-user-id", "test-movie-id", []string{"period1"}).
+					Return(&models.Vote{ID: "test-vote-id", VotingPeriodID: "period1"}, nil)
+
+				repo.EXPECT().
+					DeleteVote(context.Background(), "test-vote-id").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:    "Voting Period Closed",
+			userID:  "test-user-id",
+			movieID: "test-movie-id",
+			mockSetup: func(repo *mocks.MockMovieRepository, votingRepo *mocks.MockVotingRepository) {
+				votingRepo.EXPECT().ListOpenPeriodsForMovie(context.Background(), "test-movie-id", gomock.Any()).Return(openPeriods, nil)
+				votingRepo.EXPECT().FindVote(context.Background(), "test-user-id", "test-movie-id", []string{"period1"}).Return(nil, sql.ErrNoRows)
+				repo.EXPECT().
+					GetVoteByUserAndMovie(context.Background(), "test-user-id", "test-movie-id").
+					Return(&models.Vote{ID: "test-vote-id", VotingPeriodID: "period0"}, nil)
+			},
+			expectedErr: services.ErrVotingClosed,
+		},
+		{
+			name:    "Vote Not Found",
+			userID:  "test-user-id",
+			movieID: "test-movie-id",
+			mockSetup: func(repo *mocks.MockMovieRepository, votingRepo *mocks.MockVotingRepository) {
+				votingRepo.EXPECT().ListOpenPeriodsForMovie(context.Background(), "test-movie-id", gomock.Any()).Return(openPeriods, nil)
+				votingRepo.EXPECT().FindVote(context.Background(), "test-user-id", "test-movie-id", []string{"period1"}).Return(nil, sql.ErrNoRows)
+				// Setup for vote not existing
+				repo.EXPECT().
+					GetVoteByUserAndMovie(context.Background(), "test-user-id", "test-movie-id").Return(&models.Vote{}, nil)
+			},
+			expectedErr: errors.New("you haven't voted for this movie yet"),
+		},
+		{
+			name:    "Repository Error",
+			userID:  "test-user-id",
+			movieID: "test-movie-id",
+			mockSetup: func(repo *mocks.MockMovieRepository, votingRepo *mocks.MockVotingRepository) {
+				votingRepo.EXPECT().ListOpenPeriodsForMovie(context.Background(), "test-movie-id", gomock.Any()).Return(openPeriods, nil)
+				votingRepo.EXPECT().FindVote(context.Background(), "test-user-id", "test-movie-id", []string{"period1"}).
+					Return(&models.Vote{ID: "test-vote-id", VotingPeriodID: "period1"}, nil)
+
+				repo.EXPECT().
+					DeleteVote(context.Background(), "test-vote-id").Return(errors.New("repository error"))
+			},
+			expectedErr: errors.New("repository error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Initialize mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Initialize service
+			votingService, mockVotingRepo, mockRepo, _, _ := newVotingService(ctrl)
+
+			// Setup mocks based on test case
+			tc.mockSetup(mockRepo, mockVotingRepo)
+
+			// Act
+			err := votingService.UnvoteMovie(context.Background(), tc.userID, tc.movieID)
+
+			// Assert
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestCreateVotingPeriod(t *testing.T) {
+	opensAt := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+	closesAt := opensAt.Add(48 * time.Hour)
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		req           models.VotingPeriodRequest
+		mockSetup     func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository)
+		expectedError error
+	}{
+		{
+			name: "Success - Section voting period created",
+			req:  models.VotingPeriodRequest{SectionID: "section1", OpensAt: opensAt, ClosesAt: closesAt},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusOpen}, nil)
+				mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1", EditionID: "edition1"}, nil)
+				mockRepo.EXPECT().CreatePeriod(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), gomock.Any()).Return(&models.VotingPeriod{ID: "period1"}, nil)
+			},
+		},
+		{
+			name: "Failure - Closes before it opens",
+			req:  models.VotingPeriodRequest{OpensAt: closesAt, ClosesAt: opensAt},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository) {
+			},
+			expectedError: services.ErrInvalidVotingPeriod,
+		},
+		{
+			name: "Failure - Ranked period without max_rankings",
+			req:  models.VotingPeriodRequest{OpensAt: opensAt, ClosesAt: closesAt, BallotMode: models.BallotModeRanked},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository) {
+			},
+			expectedError: services.ErrInvalidRankedPeriod,
+		},
+		{
+			name: "Failure - Edition closed",
+			req:  models.VotingPeriodRequest{OpensAt: opensAt, ClosesAt: closesAt},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusClosed}, nil)
+			},
+			expectedError: services.ErrEditionClosed,
+		},
+		{
+			name: "Failure - Section of another edition",
+			req:  models.VotingPeriodRequest{SectionID: "section1", OpensAt: opensAt, ClosesAt: closesAt},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusDraft}, nil)
+				mockCompetitionRepo.EXPECT().FindSection(gomock.Any(), "section1").Return(&models.Section{ID: "section1", EditionID: "edition2"}, nil)
+			},
+			expectedError: services.ErrSectionNotExists,
+		},
+		{
+			name: "Failure - Edition not found",
+			req:  models.VotingPeriodRequest{OpensAt: opensAt, ClosesAt: closesAt},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository, mockCompetitionRepo *mocks.MockCompetitionRepository) {
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(nil, sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			votingService, mockRepo, _, mockEditionRepo, mockCompetitionRepo := newVotingService(ctrl)
+			tc.mockSetup(mockRepo, mockEditionRepo, mockCompetitionRepo)
+
+			period, err := votingService.CreatePeriod(context.Background(), "edition1", tc.req)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, period)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "period1", period.ID)
+			}
+		})
+	}
+}
+
+func TestListUserVotingPeriods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+
+	budget := 2
+	mockRepo.EXPECT().ListOpenPeriods(gomock.Any(), gomock.Any()).Return([]models.VotingPeriod{
+		{ID: "period1", VoteBudget: &budget},
+		{ID: "period2"},
+	}, nil)
+	mockRepo.EXPECT().CountVotes(gomock.Any(), "period1", "user1").Return(3, nil)
+	mockRepo.EXPECT().CountVotes(gomock.Any(), "period2", "user1").Return(5, nil)
+
+	periods, err := votingService.ListUserPeriods(context.Background(), "user1")
+	require.NoError(t, err)
+	require.Len(t, periods, 2)
+
+	// Votes cast before the budget was lowered never make the votes left negative
+	assert.Equal(t, 3, periods[0].VotesUsed)
+	require.NotNil(t, periods[0].VotesLeft)
+	assert.Equal(t, 0, *periods[0].VotesLeft)
+	assert.Equal(t, 5, periods[1].VotesUsed)
+	assert.Nil(t, periods[1].VotesLeft)
+}
+
+func rankedBallots(count int, movieIDs ...string) []models.Ballot {
+	ballots := make([]models.Ballot, count)
+	for i := range ballots {
+		for position, movieID := range movieIDs {
+			ballots[i].Rankings = append(ballots[i].Rankings, models.BallotRanking{Position: position + 1, MovieID: movieID, Title: "Movie " + movieID})
+		}
+	}
+	return ballots
+}
+
+func TestTallyPeriod(t *testing.T) {
+	rankedPeriod := &models.VotingPeriod{ID: "period1", BallotMode: models.BallotModeRanked, MaxRankings: 2}
+	ballots := append(append(rankedBallots(4, "A", "B"), rankedBallots(3, "B", "C")...), rankedBallots(2, "C", "B")...)
+
+	t.Run("Instant-runoff - Eliminates the last movie until one has a majority", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+		mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+		mockRepo.EXPECT().ListBallots(gomock.Any(), "period1").Return(ballots, nil)
+
+		tally, err := votingService.TallyPeriod(context.Background(), "period1", "")
+		require.NoError(t, err)
+		assert.Equal(t, models.TallyMethodInstantRunoff, tally.Method)
+		assert.Equal(t, 9, tally.Ballots)
+		require.Len(t, tally.Rounds, 2)
+		assert.Equal(t, []models.TallyResult{
+			{MovieID: "A", Title: "Movie A", Score: 4},
+			{MovieID: "B", Title: "Movie B", Score: 3},
+			{MovieID: "C", Title: "Movie C", Score: 2},
+		}, tally.Rounds[0].Results)
+		assert.Equal(t, []string{"C"}, tally.Rounds[0].Eliminated)
+		assert.Equal(t, []models.TallyResult{
+			{MovieID: "B", Title: "Movie B", Score: 5},
+			{MovieID: "A", Title: "Movie A", Score: 4},
+		}, tally.Rounds[1].Results)
+		assert.Equal(t, []models.TallyResult{{MovieID: "B", Title: "Movie B", Score: 5}}, tally.Winners)
+	})
+
+	t.Run("Instant-runoff - Exhausted ballots leave the majority", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+		mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+		mockRepo.EXPECT().ListBallots(gomock.Any(), "period1").Return(
+			append(append(rankedBallots(2, "A"), rankedBallots(1, "B")...), rankedBallots(1, "C")...), nil)
+
+		tally, err := votingService.TallyPeriod(context.Background(), "period1", models.TallyMethodInstantRunoff)
+		require.NoError(t, err)
+		require.Len(t, tally.Rounds, 2)
+		assert.Equal(t, []string{"B", "C"}, tally.Rounds[0].Eliminated)
+		assert.Equal(t, 2, tally.Rounds[1].Exhausted)
+		assert.Equal(t, []models.TallyResult{{MovieID: "A", Title: "Movie A", Score: 2}}, tally.Winners)
+	})
+
+	t.Run("Instant-runoff - Movies left with the same votes tie", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+		mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+		mockRepo.EXPECT().ListBallots(gomock.Any(), "period1").Return(append(rankedBallots(1, "A"), rankedBallots(1, "B")...), nil)
+
+		tally, err := votingService.TallyPeriod(context.Background(), "period1", "")
+		require.NoError(t, err)
+		require.Len(t, tally.Rounds, 1)
+		assert.Empty(t, tally.Rounds[0].Eliminated)
+		assert.Len(t, tally.Winners, 2)
+	})
+
+	t.Run("Borda count - Points by preference", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+		mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+		mockRepo.EXPECT().ListBallots(gomock.Any(), "period1").Return(ballots, nil)
+
+		tally, err := votingService.TallyPeriod(context.Background(), "period1", models.TallyMethodBorda)
+		require.NoError(t, err)
+		require.Len(t, tally.Rounds, 1)
+		assert.Equal(t, []models.TallyResult{
+			{MovieID: "B", Title: "Movie B", Score: 12},
+			{MovieID: "A", Title: "Movie A", Score: 8},
+			{MovieID: "C", Title: "Movie C", Score: 7},
+		}, tally.Rounds[0].Results)
+		assert.Equal(t, "B", tally.Winners[0].MovieID)
+	})
+
+	t.Run("Approval - Votes of the period", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+		mockRepo.EXPECT().FindPeriod(gomock.Any(), "period2").Return(&models.VotingPeriod{ID: "period2", BallotMode: models.BallotModeApproval}, nil)
+		mockRepo.EXPECT().ListApprovals(gomock.Any(), "period2").Return([]models.TallyResult{
+			{MovieID: "A", Title: "Movie A", Score: 3},
+			{MovieID: "B", Title: "Movie B", Score: 3},
+			{MovieID: "C", Title: "Movie C", Score: 1},
+		}, nil)
+
+		tally, err := votingService.TallyPeriod(context.Background(), "period2", "")
+		require.NoError(t, err)
+		assert.Equal(t, models.TallyMethodApproval, tally.Method)
+		assert.Equal(t, 7, tally.Ballots)
+		require.Len(t, tally.Rounds, 1)
+		assert.Len(t, tally.Winners, 2)
+	})
+
+	t.Run("Failure - Method of another ballot mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		votingService, mockRepo, _, _, _ := newVotingService(ctrl)
+		mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+
+		_, err := votingService.TallyPeriod(context.Background(), "period1", models.TallyMethodApproval)
+		assert.ErrorIs(t, err, services.ErrInvalidTallyMethod)
+	})
+}
+
+func TestCastBallot(t *testing.T) {
+	now := time.Now()
+	rankedPeriod := &models.VotingPeriod{ID: "period1", EditionID: "edition1", BallotMode: models.BallotModeRanked, MaxRankings: 2,
+		OpensAt: now.Add(-time.Hour), ClosesAt: now.Add(time.Hour)}
+	openEdition := &models.Edition{ID: "edition1", Status: models.EditionStatusOpen}
+
+	// Define test cases
+	testCases := []struct {
+		name          string
+		movieIDs      []string
+		mockSetup     func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository)
+		expectedError error
+	}{
+		{
+			name:     "Success - Ballot cast",
+			movieIDs: []string{"movie2", "movie1"},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(openEdition, nil)
+				mockRepo.EXPECT().CountEligibleMovies(gomock.Any(), "period1", []string{"movie2", "movie1"}).Return(2, nil)
+				mockRepo.EXPECT().SaveBallot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, ballot *models.Ballot) error {
+					assert.Equal(t, "movie2", ballot.Rankings[0].MovieID)
+					return nil
+				})
+				mockRepo.EXPECT().FindBallot(gomock.Any(), "period1", "user1").Return(&models.Ballot{ID: "ballot1"}, nil)
+			},
+		},
+		{
+			name:     "Failure - Approval period",
+			movieIDs: []string{"movie1"},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(&models.VotingPeriod{ID: "period1", BallotMode: models.BallotModeApproval}, nil)
+			},
+			expectedError: services.ErrNotRankedPeriod,
+		},
+		{
+			name:     "Failure - Edition no longer open",
+			movieIDs: []string{"movie1"},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusClosed}, nil)
+			},
+			expectedError: services.ErrVotingPeriodClosed,
+		},
+		{
+			name:     "Failure - More movies than max_rankings",
+			movieIDs: []string{"movie1", "movie2", "movie3"},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(openEdition, nil)
+			},
+			expectedError: services.ErrInvalidBallot,
+		},
+		{
+			name:     "Failure - Movie ranked twice",
+			movieIDs: []string{"movie1", "movie1"},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(openEdition, nil)
+			},
+			expectedError: services.ErrInvalidBallot,
+		},
+		{
+			name:     "Failure - Movie not in the voting period",
+			movieIDs: []string{"movie1", "movie9"},
+			mockSetup: func(mockRepo *mocks.MockVotingRepository, mockEditionRepo *mocks.MockEditionRepository) {
+				mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(rankedPeriod, nil)
+				mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(openEdition, nil)
+				mockRepo.EXPECT().CountEligibleMovies(gomock.Any(), "period1", []string{"movie1", "movie9"}).Return(1, nil)
+			},
+			expectedError: services.ErrMovieNotEligible,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			votingService, mockRepo, _, mockEditionRepo, _ := newVotingService(ctrl)
+			tc.mockSetup(mockRepo, mockEditionRepo)
+
+			ballot, err := votingService.CastBallot(context.Background(), "user1", "period1", models.BallotRequest{MovieIDs: tc.movieIDs})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, ballot)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ballot1", ballot.ID)
+			}
+		})
+	}
+}
+
+func TestUpdateVotingPeriodBallotMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	votingService, mockRepo, _, mockEditionRepo, _ := newVotingService(ctrl)
+
+	opensAt := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().FindPeriod(gomock.Any(), "period1").Return(&models.VotingPeriod{ID: "period1", EditionID: "edition1", BallotMode: models.BallotModeApproval}, nil)
+	mockEditionRepo.EXPECT().FindByID(gomock.Any(), "edition1").Return(&models.Edition{ID: "edition1", Status: models.EditionStatusOpen}, nil)
+	mockRepo.EXPECT().HasBallots(gomock.Any(), "period1").Return(true, nil)
+
+	_, err := votingService.UpdatePeriod(context.Background(), "period1", models.VotingPeriodRequest{
+		OpensAt: opensAt, ClosesAt: opensAt.Add(time.Hour), BallotMode: models.BallotModeRanked, MaxRankings: 3,
+	})
+	assert.ErrorIs(t, err, services.ErrBallotModeLocked)
+}
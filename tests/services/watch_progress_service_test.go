@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stwrtrio/movie-festival/internal/models"
+	"github.com/stwrtrio/movie-festival/internal/services"
+	"github.com/stwrtrio/movie-festival/tests/mocks"
+)
+
+func TestSaveWatchProgress(t *testing.T) {
+	t.Setenv("WATCH_COMPLETION_THRESHOLD", "0.9")
+
+	// Define test cases
+	tests := []struct {
+		name              string
+		position          int
+		mockSetup         func(mockRepo *mocks.MockWatchProgressRepository, mockMovieRepo *mocks.MockMovieRepository)
+		expectedPosition  int
+		expectedCompleted bool
+		expectedError     error
+	}{
+		{
+			name:     "Success - Progress saved",
+			position: 3000,
+			mockSetup: func(mockRepo *mocks.MockWatchProgressRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1", Duration: 100}, nil)
+				mockRepo.EXPECT().SaveProgress(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedPosition:  3000,
+			expectedCompleted: false,
+		},
+		{
+			name:     "Success - Completed past the threshold",
+			position: 5400,
+			mockSetup: func(mockRepo *mocks.MockWatchProgressRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1", Duration: 100}, nil)
+				mockRepo.EXPECT().SaveProgress(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedPosition:  5400,
+			expectedCompleted: true,
+		},
+		{
+			name:     "Success - Position capped at the duration",
+			position: 9000,
+			mockSetup: func(mockRepo *mocks.MockWatchProgressRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{ID: "movie1", Duration: 100}, nil)
+				mockRepo.EXPECT().SaveProgress(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedPosition:  6000,
+			expectedCompleted: true,
+		},
+		{
+			name:     "Failure - Movie does not exist",
+			position: 60,
+			mockSetup: func(mockRepo *mocks.MockWatchProgressRepository, mockMovieRepo *mocks.MockMovieRepository) {
+				mockMovieRepo.EXPECT().FindMovieByID(gomock.Any(), "movie1").Return(models.Movie{}, sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockWatchProgressRepository(ctrl)
+			mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+			tt.mockSetup(mockRepo, mockMovieRepo)
+
+			watchProgressService := services.NewWatchProgressService(mockRepo, mockMovieRepo)
+
+			progress, err := watchProgressService.SaveProgress(context.TODO(), "user1", "movie1", tt.position)
+
+			if tt.expectedError != nil {
+				assert.Nil(t, progress)
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosition, progress.PositionSeconds)
+				assert.Equal(t, tt.expectedCompleted, progress.Completed)
+				assert.Equal(t, 6000, progress.DurationSeconds)
+			}
+		})
+	}
+}
+
+func TestContinueWatching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWatchProgressRepository(ctrl)
+	mockMovieRepo := mocks.NewMockMovieRepository(ctrl)
+
+	mockRepo.EXPECT().ListInProgress(gomock.Any(), "user1", 10).Return([]models.WatchProgress{
+		{MovieID: "movie2", PositionSeconds: 1800},
+		{MovieID: "deleted", PositionSeconds: 60},
+		{MovieID: "movie1", PositionSeconds: 600},
+	}, nil)
+	mockMovieRepo.EXPECT().GetMoviesByIDs(gomock.Any(), []string{"movie2", "deleted", "movie1"}).Return([]models.Movie{
+		{ID: "movie1", Title: "Movie 1", Duration: 100},
+		{ID: "movie2", Title: "Movie 2", Duration: 60},
+	}, nil)
+
+	watchProgressService := services.NewWatchProgressService(mockRepo, mockMovieRepo)
+
+	entries, err := watchProgressService.ContinueWatching(context.TODO(), "user1", 10)
+
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "Movie 2", entries[0].Movie.Title)
+		assert.Equal(t, 0.5, entries[0].Progress)
+		assert.Equal(t, "Movie 1", entries[1].Movie.Title)
+		assert.Equal(t, 0.1, entries[1].Progress)
+	}
+}